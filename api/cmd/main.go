@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/klaus-creations/klaus-judge/api/internal/api/routes"
@@ -26,6 +27,11 @@ func main() {
 		log.Fatal("failed to connect redis:", err)
 	}
 
+	// Connect file storage (attachments)
+	if _, err := config.ConnectStorage(); err != nil {
+		log.Fatal("failed to set up storage:", err)
+	}
+
 	fmt.Println("Database Connected Successfully")
 	fmt.Println("Redis Connected Successfully")
 
@@ -38,7 +44,17 @@ func main() {
 	// SETUP THE GIN SERVER
 	r := gin.Default()
 
-	routes.SetupRouter(r, db)
+	jobs := routes.SetupRouter(r, db)
+
+	// Record the verdicts workers push onto the judge results queue
+	jobs.Submissions.StartResultConsumer()
+
+	// Periodic jobs must run once per deployment, so only the replica started
+	// with RUN_SCHEDULERS=true (the default for single-instance setups) runs them
+	if config.GetEnv("RUN_SCHEDULERS", "true") == "true" {
+		jobs.Ratings.StartScheduler(time.Minute)
+		jobs.Problems.StartPublishScheduler(time.Minute)
+	}
 
 	port := config.GetEnv("PORT", "8080")
 	fmt.Printf("this is the port %v \n", port)
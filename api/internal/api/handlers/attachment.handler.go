@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/dto"
+	"github.com/klaus-creations/klaus-judge/api/internal/services"
+)
+
+// maxAttachmentSize bounds uploaded attachments.
+const maxAttachmentSize = 32 << 20
+
+// inlineAttachmentTypes are served for display in the browser; anything else
+// is sent as a download so uploaded HTML or SVG never runs on our origin.
+var inlineAttachmentTypes = map[string]bool{
+	"image/png":       true,
+	"image/jpeg":      true,
+	"image/gif":       true,
+	"image/webp":      true,
+	"application/pdf": true,
+	"text/plain":      true,
+}
+
+// AttachmentHandler handles HTTP requests for problem attachments.
+type AttachmentHandler struct {
+	attachmentService *services.AttachmentService
+}
+
+// NewAttachmentHandler creates a new attachment handler.
+func NewAttachmentHandler(attachmentService *services.AttachmentService) *AttachmentHandler {
+	return &AttachmentHandler{attachmentService: attachmentService}
+}
+
+// ListAttachments handles listing the attachments of a problem.
+func (h *AttachmentHandler) ListAttachments(c *gin.Context) {
+	attachments, err := h.attachmentService.ListAttachments(c.Param("slug"))
+	if err != nil {
+		respondAttachmentError(c, err)
+		return
+	}
+
+	resp := make([]dto.AttachmentResponse, 0, len(attachments))
+	for _, a := range attachments {
+		resp = append(resp, dto.AttachmentResponseFromDomain(a))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// UploadAttachment handles uploading a file (multipart field "file") to a problem.
+func (h *AttachmentHandler) UploadAttachment(c *gin.Context) {
+	userID := h.getUserIDFromContext(c)
+	if userID == uuid.Nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+		return
+	}
+	if fileHeader.Size > maxAttachmentSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "attachment is too large"})
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer file.Close()
+
+	attachment, err := h.attachmentService.UploadAttachment(c.Request.Context(), c.Param("slug"), fileHeader.Filename, fileHeader.Size, file, userID)
+	if err != nil {
+		respondAttachmentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.AttachmentResponseFromDomain(attachment))
+}
+
+// DeleteAttachment handles removing an attachment from a problem.
+func (h *AttachmentHandler) DeleteAttachment(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid attachment id"})
+		return
+	}
+
+	if err := h.attachmentService.DeleteAttachment(c.Request.Context(), c.Param("slug"), id); err != nil {
+		respondAttachmentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "attachment deleted"})
+}
+
+// DownloadAttachment handles serving an attachment. The file name segment of
+// the URL is cosmetic; the ID alone identifies the attachment.
+func (h *AttachmentHandler) DownloadAttachment(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "attachment not found"})
+		return
+	}
+	role, _ := c.Get("role")
+	includeUnreleased := role == "admin"
+
+	attachment, content, err := h.attachmentService.OpenAttachment(c.Request.Context(), id, includeUnreleased, h.getUserIDFromContext(c))
+	if err != nil {
+		respondAttachmentError(c, err)
+		return
+	}
+	defer content.Close()
+
+	disposition := "attachment"
+	mediaType, _, _ := mime.ParseMediaType(attachment.ContentType)
+	if inlineAttachmentTypes[strings.ToLower(mediaType)] {
+		disposition = "inline"
+	}
+
+	c.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, content, map[string]string{
+		"Content-Disposition":    mime.FormatMediaType(disposition, map[string]string{"filename": attachment.FileName}),
+		"X-Content-Type-Options": "nosniff",
+		"Cache-Control":          "public, max-age=86400",
+	})
+}
+
+// respondAttachmentError maps attachment service errors to HTTP responses.
+func respondAttachmentError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrProblemNotFound), errors.Is(err, services.ErrAttachmentNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInvalidAttachment):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// getUserIDFromContext extracts user ID from context.
+func (h *AttachmentHandler) getUserIDFromContext(c *gin.Context) uuid.UUID {
+	uid, exists := c.Get("user_id")
+	if !exists {
+		return uuid.Nil
+	}
+
+	userID, ok := uid.(uuid.UUID)
+	if !ok {
+		return uuid.Nil
+	}
+
+	return userID
+}
@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/services"
+)
+
+// CCSHandler serves contests through the ICPC CCS Contest API.
+type CCSHandler struct {
+	ccsService *services.CCSService
+}
+
+// NewCCSHandler creates a new CCS Contest API handler.
+func NewCCSHandler(ccsService *services.CCSService) *CCSHandler {
+	return &CCSHandler{
+		ccsService: ccsService,
+	}
+}
+
+// GetContest handles getting the contest object.
+func (h *CCSHandler) GetContest(c *gin.Context) {
+	id, ok := h.parseContestID(c)
+	if !ok {
+		return
+	}
+	h.respond(c, func() (interface{}, error) { return h.ccsService.GetContest(id) })
+}
+
+// GetState handles getting the contest state.
+func (h *CCSHandler) GetState(c *gin.Context) {
+	id, ok := h.parseContestID(c)
+	if !ok {
+		return
+	}
+	h.respond(c, func() (interface{}, error) { return h.ccsService.GetState(id) })
+}
+
+// ListJudgementTypes handles listing the judgement types.
+func (h *CCSHandler) ListJudgementTypes(c *gin.Context) {
+	c.JSON(http.StatusOK, h.ccsService.JudgementTypes())
+}
+
+// ListLanguages handles listing the languages.
+func (h *CCSHandler) ListLanguages(c *gin.Context) {
+	c.JSON(http.StatusOK, h.ccsService.Languages())
+}
+
+// ListProblems handles listing the contest problems.
+func (h *CCSHandler) ListProblems(c *gin.Context) {
+	id, ok := h.parseContestID(c)
+	if !ok {
+		return
+	}
+	h.respond(c, func() (interface{}, error) { return h.ccsService.Problems(id) })
+}
+
+// ListTeams handles listing the contest teams.
+func (h *CCSHandler) ListTeams(c *gin.Context) {
+	id, ok := h.parseContestID(c)
+	if !ok {
+		return
+	}
+	h.respond(c, func() (interface{}, error) { return h.ccsService.Teams(id) })
+}
+
+// ListSubmissions handles listing the contest submissions.
+func (h *CCSHandler) ListSubmissions(c *gin.Context) {
+	id, ok := h.parseContestID(c)
+	if !ok {
+		return
+	}
+	h.respond(c, func() (interface{}, error) { return h.ccsService.Submissions(id) })
+}
+
+// ListJudgements handles listing the contest judgements.
+func (h *CCSHandler) ListJudgements(c *gin.Context) {
+	id, ok := h.parseContestID(c)
+	if !ok {
+		return
+	}
+	h.respond(c, func() (interface{}, error) { return h.ccsService.Judgements(id) })
+}
+
+// GetScoreboard handles getting the contest scoreboard.
+func (h *CCSHandler) GetScoreboard(c *gin.Context) {
+	id, ok := h.parseContestID(c)
+	if !ok {
+		return
+	}
+	h.respond(c, func() (interface{}, error) { return h.ccsService.Scoreboard(id) })
+}
+
+// GetEventFeed handles streaming the contest event feed as NDJSON.
+func (h *CCSHandler) GetEventFeed(c *gin.Context) {
+	id, ok := h.parseContestID(c)
+	if !ok {
+		return
+	}
+
+	events, err := h.ccsService.EventFeed(id, c.Query("since_token"))
+	if errors.Is(err, services.ErrInvalidEventToken) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "contest not found"})
+		return
+	}
+
+	c.Header("Content-Type", "application/x-ndjson")
+	c.Status(http.StatusOK)
+
+	enc := json.NewEncoder(c.Writer)
+	for _, event := range events {
+		if err := enc.Encode(event); err != nil {
+			return
+		}
+	}
+}
+
+// respond writes the result of fetch, or a not found error.
+func (h *CCSHandler) respond(c *gin.Context, fetch func() (interface{}, error)) {
+	resp, err := fetch()
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "contest not found"})
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *CCSHandler) parseContestID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid contest id"})
+		return uuid.Nil, false
+	}
+	return id, true
+}
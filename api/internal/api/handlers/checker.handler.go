@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/dto"
+	"github.com/klaus-creations/klaus-judge/api/internal/services"
+)
+
+// CheckerHandler handles HTTP requests for problem checkers.
+type CheckerHandler struct {
+	checkerService *services.CheckerService
+}
+
+// NewCheckerHandler creates a new checker handler.
+func NewCheckerHandler(checkerService *services.CheckerService) *CheckerHandler {
+	return &CheckerHandler{checkerService: checkerService}
+}
+
+// GetChecker handles getting the checker of a problem.
+func (h *CheckerHandler) GetChecker(c *gin.Context) {
+	checker, err := h.checkerService.GetChecker(c.Param("slug"))
+	if err != nil {
+		respondCheckerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.CheckerResponseFromDomain(checker))
+}
+
+// SetChecker handles uploading or replacing the checker of a problem.
+func (h *CheckerHandler) SetChecker(c *gin.Context) {
+	userID := h.getUserIDFromContext(c)
+	if userID == uuid.Nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req dto.SetCheckerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	checker, err := h.checkerService.SetChecker(c.Param("slug"), &req, userID)
+	if err != nil {
+		respondCheckerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.CheckerResponseFromDomain(checker))
+}
+
+// DeleteChecker handles removing the checker of a problem.
+func (h *CheckerHandler) DeleteChecker(c *gin.Context) {
+	userID := h.getUserIDFromContext(c)
+	if userID == uuid.Nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	if err := h.checkerService.DeleteChecker(c.Param("slug"), userID); err != nil {
+		respondCheckerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "checker deleted"})
+}
+
+// respondCheckerError maps checker service errors to HTTP responses.
+func respondCheckerError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrProblemNotFound), errors.Is(err, services.ErrCheckerNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrUnsupportedLanguage):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// getUserIDFromContext extracts user ID from context.
+func (h *CheckerHandler) getUserIDFromContext(c *gin.Context) uuid.UUID {
+	uid, exists := c.Get("user_id")
+	if !exists {
+		return uuid.Nil
+	}
+
+	userID, ok := uid.(uuid.UUID)
+	if !ok {
+		return uuid.Nil
+	}
+
+	return userID
+}
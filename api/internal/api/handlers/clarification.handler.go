@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+	"github.com/klaus-creations/klaus-judge/api/internal/dto"
+	"github.com/klaus-creations/klaus-judge/api/internal/services"
+)
+
+// ClarificationHandler handles HTTP requests for contest clarifications.
+type ClarificationHandler struct {
+	clarificationService *services.ClarificationService
+}
+
+// NewClarificationHandler creates a new clarification handler.
+func NewClarificationHandler(clarificationService *services.ClarificationService) *ClarificationHandler {
+	return &ClarificationHandler{
+		clarificationService: clarificationService,
+	}
+}
+
+// Ask handles a participant asking a question.
+func (h *ClarificationHandler) Ask(c *gin.Context) {
+	userID := h.getUserIDFromContext(c)
+	if userID == uuid.Nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid contest id"})
+		return
+	}
+
+	var req dto.AskClarificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.clarificationService.Ask(id, userID, &req, h.isJudge(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrContestEnded), errors.Is(err, services.ErrNotRegistered):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+// List handles listing the clarifications visible to the current user.
+func (h *ClarificationHandler) List(c *gin.Context) {
+	userID := h.getUserIDFromContext(c)
+	if userID == uuid.Nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid contest id"})
+		return
+	}
+
+	unansweredOnly := c.Query("unanswered") == "true"
+
+	resp, err := h.clarificationService.List(id, userID, h.isJudge(c), unansweredOnly)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "contest not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// Answer handles a moderator answering a clarification.
+func (h *ClarificationHandler) Answer(c *gin.Context) {
+	userID := h.getUserIDFromContext(c)
+	if userID == uuid.Nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid contest id"})
+		return
+	}
+	clarificationID, err := uuid.Parse(c.Param("clarificationId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid clarification id"})
+		return
+	}
+
+	var req dto.AnswerClarificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.clarificationService.Answer(id, clarificationID, userID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrClarificationAnswered):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrClarificationNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *ClarificationHandler) isJudge(c *gin.Context) bool {
+	role, _ := c.Get("role")
+	return role == domain.RoleAdmin || role == domain.RoleModerator
+}
+
+func (h *ClarificationHandler) getUserIDFromContext(c *gin.Context) uuid.UUID {
+	uid, exists := c.Get("user_id")
+	if !exists {
+		return uuid.Nil
+	}
+
+	userID, ok := uid.(uuid.UUID)
+	if !ok {
+		return uuid.Nil
+	}
+
+	return userID
+}
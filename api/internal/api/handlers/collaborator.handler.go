@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/dto"
+	"github.com/klaus-creations/klaus-judge/api/internal/services"
+)
+
+// CollaboratorHandler handles HTTP requests for problem collaborators.
+type CollaboratorHandler struct {
+	collaboratorService *services.CollaboratorService
+}
+
+// NewCollaboratorHandler creates a new collaborator handler.
+func NewCollaboratorHandler(collaboratorService *services.CollaboratorService) *CollaboratorHandler {
+	return &CollaboratorHandler{collaboratorService: collaboratorService}
+}
+
+// ListCollaborators handles listing the collaborators of a problem.
+func (h *CollaboratorHandler) ListCollaborators(c *gin.Context) {
+	collaborators, err := h.collaboratorService.ListCollaborators(c.Param("slug"))
+	if err != nil {
+		respondCollaboratorError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, collaborators)
+}
+
+// AddCollaborator handles giving a user a role on a problem.
+func (h *CollaboratorHandler) AddCollaborator(c *gin.Context) {
+	var req dto.AddCollaboratorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	collaborator, err := h.collaboratorService.AddCollaborator(c.Param("slug"), &req)
+	if err != nil {
+		respondCollaboratorError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, collaborator)
+}
+
+// UpdateCollaborator handles changing a collaborator's role.
+func (h *CollaboratorHandler) UpdateCollaborator(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+
+	var req dto.UpdateCollaboratorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	collaborator, err := h.collaboratorService.UpdateCollaborator(c.Param("slug"), userID, &req)
+	if err != nil {
+		respondCollaboratorError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, collaborator)
+}
+
+// RemoveCollaborator handles taking away a user's role on a problem.
+func (h *CollaboratorHandler) RemoveCollaborator(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+
+	if err := h.collaboratorService.RemoveCollaborator(c.Param("slug"), userID); err != nil {
+		respondCollaboratorError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "collaborator removed"})
+}
+
+// respondCollaboratorError maps collaborator service errors to HTTP responses.
+func respondCollaboratorError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrProblemNotFound),
+		errors.Is(err, services.ErrCollaboratorNotFound),
+		errors.Is(err, services.ErrCollaboratorUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrAlreadyCollaborator), errors.Is(err, services.ErrLastOwner):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,674 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/dto"
+	"github.com/klaus-creations/klaus-judge/api/internal/services"
+)
+
+// ContestHandler handles HTTP requests for contests.
+type ContestHandler struct {
+	contestService    *services.ContestService
+	scoreboardService *services.ScoreboardService
+}
+
+// NewContestHandler creates a new contest handler.
+func NewContestHandler(contestService *services.ContestService, scoreboardService *services.ScoreboardService) *ContestHandler {
+	return &ContestHandler{
+		contestService:    contestService,
+		scoreboardService: scoreboardService,
+	}
+}
+
+// CreateContest handles contest creation.
+func (h *ContestHandler) CreateContest(c *gin.Context) {
+	userID := h.getUserIDFromContext(c)
+	if userID == uuid.Nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req dto.CreateContestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	contest, err := h.contestService.CreateContest(&req, userID)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidContestWindow) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ContestResponseFromDomain(contest))
+}
+
+// GetContest handles getting a contest.
+func (h *ContestHandler) GetContest(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid contest id"})
+		return
+	}
+
+	role, _ := c.Get("role")
+	isAdmin := role == "admin"
+
+	resp, err := h.contestService.GetContest(id, h.getUserIDFromContext(c), isAdmin)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "contest not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// ListContests handles listing contests.
+func (h *ContestHandler) ListContests(c *gin.Context) {
+	pagination := dto.ParsePagination(c)
+	filters := dto.ParseContestFilters(c)
+
+	role, _ := c.Get("role")
+	isAdmin := role == "admin"
+
+	resp, err := h.contestService.ListContests(pagination, filters, h.getUserIDFromContext(c), isAdmin)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidContestStatus) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// UpdateContest handles updating a contest.
+func (h *ContestHandler) UpdateContest(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid contest id"})
+		return
+	}
+
+	var req dto.UpdateContestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	contest, err := h.contestService.UpdateContest(id, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidContestWindow):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrContestNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ContestResponseFromDomain(contest))
+}
+
+// DeleteContest handles deleting a contest.
+func (h *ContestHandler) DeleteContest(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid contest id"})
+		return
+	}
+
+	if err := h.contestService.DeleteContest(id); err != nil {
+		if errors.Is(err, services.ErrContestNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "contest deleted"})
+}
+
+// ListContestProblems handles listing the problem set of a contest.
+func (h *ContestHandler) ListContestProblems(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid contest id"})
+		return
+	}
+
+	role, _ := c.Get("role")
+	isAdmin := role == "admin"
+
+	problems, err := h.contestService.ListContestProblems(id, h.getUserIDFromContext(c), isAdmin)
+	if err != nil {
+		if errors.Is(err, services.ErrContestNotStarted) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusNotFound, gin.H{"error": "contest not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"problems": problems})
+}
+
+// AddContestProblem handles assigning a problem to a contest.
+func (h *ContestHandler) AddContestProblem(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid contest id"})
+		return
+	}
+
+	var req dto.AddContestProblemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	contestProblem, err := h.contestService.AddContestProblem(id, &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ContestProblemDTOFromDomain(contestProblem))
+}
+
+// UpdateContestProblem handles updating a contest problem's label or points.
+func (h *ContestHandler) UpdateContestProblem(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid contest id"})
+		return
+	}
+
+	var req dto.UpdateContestProblemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	contestProblem, err := h.contestService.UpdateContestProblem(id, c.Param("label"), &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ContestProblemDTOFromDomain(contestProblem))
+}
+
+// RemoveContestProblem handles removing a problem from a contest.
+func (h *ContestHandler) RemoveContestProblem(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid contest id"})
+		return
+	}
+
+	if err := h.contestService.RemoveContestProblem(id, c.Param("label")); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "contest problem not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "problem removed from contest"})
+}
+
+// Register handles registering the current user for a contest.
+func (h *ContestHandler) Register(c *gin.Context) {
+	userID := h.getUserIDFromContext(c)
+	if userID == uuid.Nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid contest id"})
+		return
+	}
+
+	// The body is optional: public contests need no credentials.
+	var req dto.RegisterContestRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.contestService.Register(id, userID, &req); err != nil {
+		switch {
+		case errors.Is(err, services.ErrContestStarted),
+			errors.Is(err, services.ErrContestAccessDenied),
+			errors.Is(err, services.ErrInvalidInviteCode),
+			errors.Is(err, services.ErrTeamContest):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrAlreadyRegistered):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrContestNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "registered for contest"})
+}
+
+// Unregister handles withdrawing the current user from a contest.
+func (h *ContestHandler) Unregister(c *gin.Context) {
+	userID := h.getUserIDFromContext(c)
+	if userID == uuid.Nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid contest id"})
+		return
+	}
+
+	if err := h.contestService.Unregister(id, userID); err != nil {
+		switch {
+		case errors.Is(err, services.ErrContestStarted):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrContestNotFound), errors.Is(err, services.ErrNotRegistered):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "unregistered from contest"})
+}
+
+// JoinContest handles registering for a private contest with an invite code.
+func (h *ContestHandler) JoinContest(c *gin.Context) {
+	userID := h.getUserIDFromContext(c)
+	if userID == uuid.Nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req dto.JoinContestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	contest, err := h.contestService.JoinWithInvite(req.Code, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidInviteCode), errors.Is(err, services.ErrContestStarted), errors.Is(err, services.ErrTeamContest):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrAlreadyRegistered):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ContestResponseFromDomain(contest))
+}
+
+// StartVirtual handles starting a virtual run of a finished contest.
+func (h *ContestHandler) StartVirtual(c *gin.Context) {
+	userID := h.getUserIDFromContext(c)
+	if userID == uuid.Nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid contest id"})
+		return
+	}
+
+	participation, err := h.contestService.StartVirtual(id, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrContestNotEnded), errors.Is(err, services.ErrAlreadyParticipated):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrVirtualStarted):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusNotFound, gin.H{"error": "contest not found"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.VirtualParticipationResponse{
+		ContestID: participation.ContestID,
+		StartedAt: participation.StartedAt,
+		EndsAt:    participation.EndsAt,
+	})
+}
+
+// GetVirtualStanding handles getting the current user's virtual scoreboard placement.
+func (h *ContestHandler) GetVirtualStanding(c *gin.Context) {
+	userID := h.getUserIDFromContext(c)
+	if userID == uuid.Nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid contest id"})
+		return
+	}
+
+	resp, err := h.scoreboardService.GetVirtualStanding(id, userID)
+	if err != nil {
+		if errors.Is(err, services.ErrNoVirtualRun) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusNotFound, gin.H{"error": "contest not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// ListParticipants handles listing the contest roster (admin).
+func (h *ContestHandler) ListParticipants(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid contest id"})
+		return
+	}
+
+	resp, err := h.contestService.ListParticipants(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "contest not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// ExportParticipants handles exporting the contest roster as CSV (admin).
+func (h *ContestHandler) ExportParticipants(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid contest id"})
+		return
+	}
+
+	resp, err := h.contestService.ListParticipants(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "contest not found"})
+		return
+	}
+
+	// Build the file first so a write error can still be reported as such.
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	rows := [][]string{{"user_id", "username", "full_name", "email", "team", "registered_at"}}
+	for _, p := range resp.Participants {
+		rows = append(rows, []string{p.UserID.String(), p.Username, p.FullName, p.Email, p.TeamName, p.RegisteredAt.Format(time.RFC3339)})
+	}
+	if err := w.WriteAll(rows); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=contest-%s-participants.csv", id))
+	c.Data(http.StatusOK, "text/csv", buf.Bytes())
+}
+
+// CreateInvite handles generating an invite code for a contest (admin).
+func (h *ContestHandler) CreateInvite(c *gin.Context) {
+	userID := h.getUserIDFromContext(c)
+	if userID == uuid.Nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid contest id"})
+		return
+	}
+
+	var req dto.CreateContestInviteRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	invite, err := h.contestService.CreateInvite(id, &req, userID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "contest not found"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ContestInviteDTOFromDomain(invite))
+}
+
+// ListInvites handles listing the invite codes of a contest (admin).
+func (h *ContestHandler) ListInvites(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid contest id"})
+		return
+	}
+
+	invites, err := h.contestService.ListInvites(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "contest not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"invites": invites})
+}
+
+// RevokeInvite handles deleting an invite code (admin).
+func (h *ContestHandler) RevokeInvite(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid contest id"})
+		return
+	}
+	inviteID, err := uuid.Parse(c.Param("inviteId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid invite id"})
+		return
+	}
+
+	if err := h.contestService.RevokeInvite(id, inviteID); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "invite revoked"})
+}
+
+// ListAllowedUsers handles listing the allowlist of a contest (admin).
+func (h *ContestHandler) ListAllowedUsers(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid contest id"})
+		return
+	}
+
+	allowed, err := h.contestService.ListAllowedUsers(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "contest not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"users": allowed})
+}
+
+// AllowUser handles adding a user to the allowlist of a contest (admin).
+func (h *ContestHandler) AllowUser(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid contest id"})
+		return
+	}
+
+	var req dto.AddAllowedUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	allowed, err := h.contestService.AllowUser(id, &req)
+	if err != nil {
+		if errors.Is(err, services.ErrAlreadyAllowed) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, allowed)
+}
+
+// DisallowUser handles removing a user from the allowlist of a contest (admin).
+func (h *ContestHandler) DisallowUser(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid contest id"})
+		return
+	}
+	userID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+
+	if err := h.contestService.DisallowUser(id, userID); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "user removed from allowlist"})
+}
+
+// GetScoreboard handles getting the contest scoreboard.
+func (h *ContestHandler) GetScoreboard(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid contest id"})
+		return
+	}
+
+	role, _ := c.Get("role")
+	isAdmin := role == "admin"
+
+	if err := h.contestService.CheckAccess(id, h.getUserIDFromContext(c), isAdmin); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "contest not found"})
+		return
+	}
+
+	resp, err := h.scoreboardService.GetScoreboard(id, isAdmin)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "contest not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetResolver handles getting the award ceremony state of a frozen contest (admin).
+func (h *ContestHandler) GetResolver(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid contest id"})
+		return
+	}
+
+	resp, err := h.scoreboardService.GetResolverState(id)
+	if err != nil {
+		h.respondResolverError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// RevealNext handles revealing the next frozen result in resolver order (admin).
+func (h *ContestHandler) RevealNext(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid contest id"})
+		return
+	}
+
+	resp, err := h.scoreboardService.RevealNext(id)
+	if err != nil {
+		h.respondResolverError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// Unfreeze handles revealing all frozen results at once (admin).
+func (h *ContestHandler) Unfreeze(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid contest id"})
+		return
+	}
+
+	if err := h.scoreboardService.Unfreeze(id); err != nil {
+		h.respondResolverError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "scoreboard unfrozen"})
+}
+
+// respondResolverError maps resolver errors to HTTP responses.
+func (h *ContestHandler) respondResolverError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrContestNotEnded), errors.Is(err, services.ErrContestNotFrozen):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// getUserIDFromContext extracts user ID from context (same as auth).
+func (h *ContestHandler) getUserIDFromContext(c *gin.Context) uuid.UUID {
+	uid, exists := c.Get("user_id")
+	if !exists {
+		return uuid.Nil
+	}
+
+	userID, ok := uid.(uuid.UUID)
+	if !ok {
+		return uuid.Nil
+	}
+
+	return userID
+}
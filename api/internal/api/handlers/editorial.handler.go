@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/dto"
+	"github.com/klaus-creations/klaus-judge/api/internal/services"
+)
+
+// EditorialHandler handles HTTP requests for problem editorials.
+type EditorialHandler struct {
+	editorialService *services.EditorialService
+}
+
+// NewEditorialHandler creates a new editorial handler.
+func NewEditorialHandler(editorialService *services.EditorialService) *EditorialHandler {
+	return &EditorialHandler{editorialService: editorialService}
+}
+
+// GetEditorial handles reading the editorial of a problem.
+func (h *EditorialHandler) GetEditorial(c *gin.Context) {
+	role, _ := c.Get("role")
+	isAdmin := role == "admin"
+
+	editorial, err := h.editorialService.GetEditorial(c.Param("slug"), h.getUserIDFromContext(c), isAdmin)
+	if err != nil {
+		respondEditorialError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, editorial)
+}
+
+// SetEditorial handles creating or replacing the editorial of a problem.
+func (h *EditorialHandler) SetEditorial(c *gin.Context) {
+	userID := h.getUserIDFromContext(c)
+	if userID == uuid.Nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req dto.EditorialRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	editorial, err := h.editorialService.SetEditorial(c.Param("slug"), &req, userID)
+	if err != nil {
+		respondEditorialError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, editorial)
+}
+
+// DeleteEditorial handles removing the editorial of a problem.
+func (h *EditorialHandler) DeleteEditorial(c *gin.Context) {
+	if err := h.editorialService.DeleteEditorial(c.Param("slug")); err != nil {
+		respondEditorialError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "editorial deleted"})
+}
+
+// respondEditorialError maps editorial service errors to HTTP responses.
+func respondEditorialError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrProblemNotFound), errors.Is(err, services.ErrEditorialNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrEditorialLocked), errors.Is(err, services.ErrEditorialContestRunning):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrUnsupportedLanguage), errors.Is(err, services.ErrDuplicateSolutionLanguage):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// getUserIDFromContext extracts user ID from context.
+func (h *EditorialHandler) getUserIDFromContext(c *gin.Context) uuid.UUID {
+	uid, exists := c.Get("user_id")
+	if !exists {
+		return uuid.Nil
+	}
+
+	userID, ok := uid.(uuid.UUID)
+	if !ok {
+		return uuid.Nil
+	}
+
+	return userID
+}
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/dto"
+	"github.com/klaus-creations/klaus-judge/api/internal/services"
+)
+
+// InteractorHandler handles HTTP requests for problem interactors.
+type InteractorHandler struct {
+	interactorService *services.InteractorService
+}
+
+// NewInteractorHandler creates a new interactor handler.
+func NewInteractorHandler(interactorService *services.InteractorService) *InteractorHandler {
+	return &InteractorHandler{interactorService: interactorService}
+}
+
+// GetInteractor handles getting the interactor of a problem.
+func (h *InteractorHandler) GetInteractor(c *gin.Context) {
+	interactor, err := h.interactorService.GetInteractor(c.Param("slug"))
+	if err != nil {
+		respondInteractorError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.InteractorResponseFromDomain(interactor))
+}
+
+// SetInteractor handles uploading or replacing the interactor of a problem.
+func (h *InteractorHandler) SetInteractor(c *gin.Context) {
+	userID := h.getUserIDFromContext(c)
+	if userID == uuid.Nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req dto.SetInteractorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	interactor, err := h.interactorService.SetInteractor(c.Param("slug"), &req, userID)
+	if err != nil {
+		respondInteractorError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.InteractorResponseFromDomain(interactor))
+}
+
+// DeleteInteractor handles removing the interactor of a problem.
+func (h *InteractorHandler) DeleteInteractor(c *gin.Context) {
+	userID := h.getUserIDFromContext(c)
+	if userID == uuid.Nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	if err := h.interactorService.DeleteInteractor(c.Param("slug"), userID); err != nil {
+		respondInteractorError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "interactor deleted"})
+}
+
+// respondInteractorError maps interactor service errors to HTTP responses.
+func respondInteractorError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrProblemNotFound), errors.Is(err, services.ErrInteractorNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrUnsupportedLanguage), errors.Is(err, services.ErrNotInteractive):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// getUserIDFromContext extracts user ID from context.
+func (h *InteractorHandler) getUserIDFromContext(c *gin.Context) uuid.UUID {
+	uid, exists := c.Get("user_id")
+	if !exists {
+		return uuid.Nil
+	}
+
+	userID, ok := uid.(uuid.UUID)
+	if !ok {
+		return uuid.Nil
+	}
+
+	return userID
+}
@@ -1,7 +1,11 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -9,6 +13,13 @@ import (
 	"github.com/klaus-creations/klaus-judge/api/internal/services"
 )
 
+const (
+	// maxProblemPackageSize bounds uploaded problem packages.
+	maxProblemPackageSize = 256 << 20
+	// maxProblemSuggestions bounds (and defaults) the typeahead result count.
+	maxProblemSuggestions = 10
+)
+
 // ProblemHandler handles HTTP requests for problems.
 type ProblemHandler struct {
 	problemService *services.ProblemService
@@ -35,7 +46,7 @@ func (h *ProblemHandler) CreateProblem(c *gin.Context) {
 
 	problem, err := h.problemService.CreateProblem(&req, userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondProblemError(c, err)
 		return
 	}
 
@@ -46,14 +57,21 @@ func (h *ProblemHandler) CreateProblem(c *gin.Context) {
 func (h *ProblemHandler) GetProblem(c *gin.Context) {
 	slug := c.Param("slug")
 	role, _ := c.Get("role")
-	includeHidden := role == "admin"
+	isAdmin := role == "admin"
 
-	resp, err := h.problemService.GetProblem(slug, includeHidden)
+	resp, err := h.problemService.GetProblem(slug, isAdmin, h.getUserIDFromContext(c), dto.ParseLocalePreferences(c))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "problem not found"})
+		if errors.Is(err, services.ErrProblemNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
+	c.Header("Content-Language", resp.Locale)
+	c.Header("Vary", "Accept-Language")
+
 	c.JSON(http.StatusOK, resp)
 }
 
@@ -61,8 +79,10 @@ func (h *ProblemHandler) GetProblem(c *gin.Context) {
 func (h *ProblemHandler) ListProblems(c *gin.Context) {
 	pagination := dto.ParsePagination(c)
 	filters := dto.ParseProblemFilters(c)
+	role, _ := c.Get("role")
+	includeUnreleased := role == "admin"
 
-	resp, err := h.problemService.ListProblems(pagination, filters)
+	resp, err := h.problemService.ListProblems(pagination, filters, includeUnreleased, h.getUserIDFromContext(c), dto.ParseLocalePreferences(c))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -71,8 +91,51 @@ func (h *ProblemHandler) ListProblems(c *gin.Context) {
 	c.JSON(http.StatusOK, resp)
 }
 
+// SetProblemStatus handles publishing, unpublishing and archiving a problem.
+func (h *ProblemHandler) SetProblemStatus(c *gin.Context) {
+	var req dto.ProblemStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	role, _ := c.Get("role")
+	isAdmin := role == "admin"
+
+	problem, err := h.problemService.SetProblemStatus(c.Param("slug"), &req, isAdmin)
+	if err != nil {
+		respondProblemError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ProblemResponseFromDomain(problem))
+}
+
+// SuggestProblems handles title typeahead for problem search.
+func (h *ProblemHandler) SuggestProblems(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	if limit <= 0 || limit > maxProblemSuggestions {
+		limit = maxProblemSuggestions
+	}
+	role, _ := c.Get("role")
+	includeUnreleased := role == "admin"
+
+	suggestions, err := h.problemService.SuggestProblems(c.Query("q"), limit, includeUnreleased)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, suggestions)
+}
+
 // UpdateProblem handles updating a problem.
 func (h *ProblemHandler) UpdateProblem(c *gin.Context) {
+	userID := h.getUserIDFromContext(c)
+	if userID == uuid.Nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
 	slug := c.Param("slug")
 
 	var req dto.UpdateProblemRequest
@@ -81,9 +144,9 @@ func (h *ProblemHandler) UpdateProblem(c *gin.Context) {
 		return
 	}
 
-	problem, err := h.problemService.UpdateProblem(slug, &req)
+	problem, err := h.problemService.UpdateProblem(slug, &req, userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondProblemError(c, err)
 		return
 	}
 
@@ -95,7 +158,14 @@ func (h *ProblemHandler) DeleteProblem(c *gin.Context) {
 	slug := c.Param("slug")
 
 	if err := h.problemService.DeleteProblem(slug); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		switch {
+		case errors.Is(err, services.ErrProblemNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrProblemInUse):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
 		return
 	}
 
@@ -104,6 +174,11 @@ func (h *ProblemHandler) DeleteProblem(c *gin.Context) {
 
 // AddTestCase handles adding a test case.
 func (h *ProblemHandler) AddTestCase(c *gin.Context) {
+	userID := h.getUserIDFromContext(c)
+	if userID == uuid.Nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
 	slug := c.Param("slug")
 
 	var req dto.TestCaseRequest
@@ -112,9 +187,9 @@ func (h *ProblemHandler) AddTestCase(c *gin.Context) {
 		return
 	}
 
-	testCase, err := h.problemService.AddTestCase(slug, &req)
+	testCase, err := h.problemService.AddTestCase(slug, &req, userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondTestCaseError(c, err)
 		return
 	}
 
@@ -123,6 +198,11 @@ func (h *ProblemHandler) AddTestCase(c *gin.Context) {
 
 // UpdateTestCase handles updating a test case.
 func (h *ProblemHandler) UpdateTestCase(c *gin.Context) {
+	userID := h.getUserIDFromContext(c)
+	if userID == uuid.Nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -136,9 +216,9 @@ func (h *ProblemHandler) UpdateTestCase(c *gin.Context) {
 		return
 	}
 
-	testCase, err := h.problemService.UpdateTestCase(id, &req)
+	testCase, err := h.problemService.UpdateTestCase(c.Param("slug"), id, &req, userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondTestCaseError(c, err)
 		return
 	}
 
@@ -147,6 +227,11 @@ func (h *ProblemHandler) UpdateTestCase(c *gin.Context) {
 
 // DeleteTestCase handles deleting a test case.
 func (h *ProblemHandler) DeleteTestCase(c *gin.Context) {
+	userID := h.getUserIDFromContext(c)
+	if userID == uuid.Nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -154,14 +239,187 @@ func (h *ProblemHandler) DeleteTestCase(c *gin.Context) {
 		return
 	}
 
-	if err := h.problemService.DeleteTestCase(id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.problemService.DeleteTestCase(c.Param("slug"), id, userID); err != nil {
+		respondTestCaseError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "test case deleted"})
 }
 
+// ExportProblem handles downloading a problem package.
+func (h *ProblemHandler) ExportProblem(c *gin.Context) {
+	slug := c.Param("slug")
+
+	data, err := h.problemService.ExportProblem(slug)
+	if err != nil {
+		if errors.Is(err, services.ErrProblemNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "problem not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.zip"`, slug))
+	c.Data(http.StatusOK, "application/zip", data)
+}
+
+// ImportProblem handles creating a problem from an uploaded package.
+func (h *ProblemHandler) ImportProblem(c *gin.Context) {
+	userID := h.getUserIDFromContext(c)
+	if userID == uuid.Nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	fileHeader, err := c.FormFile("package")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "package file is required"})
+		return
+	}
+	if fileHeader.Size > maxProblemPackageSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "package is too large"})
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	problem, err := h.problemService.ImportProblem(data, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidProblemPackage):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrProblemSlugTaken):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ProblemResponseFromDomain(problem))
+}
+
+// ListRevisions handles listing the revision history of a problem.
+func (h *ProblemHandler) ListRevisions(c *gin.Context) {
+	revisions, err := h.problemService.ListRevisions(c.Param("slug"))
+	if err != nil {
+		respondRevisionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, revisions)
+}
+
+// GetRevision handles getting one revision of a problem.
+func (h *ProblemHandler) GetRevision(c *gin.Context) {
+	number, err := strconv.Atoi(c.Param("number"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid revision number"})
+		return
+	}
+
+	revision, err := h.problemService.GetRevision(c.Param("slug"), number)
+	if err != nil {
+		respondRevisionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, revision)
+}
+
+// DiffRevisions handles comparing two revisions given as ?from= and ?to=.
+func (h *ProblemHandler) DiffRevisions(c *gin.Context) {
+	from, errFrom := strconv.Atoi(c.Query("from"))
+	to, errTo := strconv.Atoi(c.Query("to"))
+	if errFrom != nil || errTo != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from and to must be revision numbers"})
+		return
+	}
+
+	diff, err := h.problemService.DiffRevisions(c.Param("slug"), from, to)
+	if err != nil {
+		respondRevisionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
+
+// RollbackProblem handles restoring a problem to one of its revisions.
+func (h *ProblemHandler) RollbackProblem(c *gin.Context) {
+	userID := h.getUserIDFromContext(c)
+	if userID == uuid.Nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	number, err := strconv.Atoi(c.Param("number"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid revision number"})
+		return
+	}
+
+	problem, err := h.problemService.RollbackProblem(c.Param("slug"), number, userID)
+	if err != nil {
+		respondRevisionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ProblemResponseFromDomain(problem))
+}
+
+// respondRevisionError maps problem revision errors to HTTP responses.
+func respondRevisionError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrProblemNotFound), errors.Is(err, services.ErrRevisionNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrRollbackJudged):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// respondProblemError maps problem creation and update errors to HTTP responses.
+func respondProblemError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrProblemNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInvalidLocale), errors.Is(err, services.ErrInvalidPublishAt),
+		errors.Is(err, services.ErrExpectedOutputRequired):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrProblemAccessDenied):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrStatementLocaleTaken):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// respondTestCaseError maps test case errors to HTTP responses.
+func respondTestCaseError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrProblemNotFound), errors.Is(err, services.ErrTestCaseNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrExpectedOutputRequired), errors.Is(err, services.ErrTestGroupNotFound):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
 // getUserIDFromContext extracts user ID from context (same as auth).
 func (h *ProblemHandler) getUserIDFromContext(c *gin.Context) uuid.UUID {
 	uid, exists := c.Get("user_id")
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/services"
+)
+
+// RatingHandler handles HTTP requests for contest ratings.
+type RatingHandler struct {
+	ratingService *services.RatingService
+}
+
+// NewRatingHandler creates a new rating handler.
+func NewRatingHandler(ratingService *services.RatingService) *RatingHandler {
+	return &RatingHandler{
+		ratingService: ratingService,
+	}
+}
+
+// GetRatingHistory handles getting a user's rating graph.
+func (h *RatingHandler) GetRatingHistory(c *gin.Context) {
+	resp, err := h.ratingService.GetRatingHistory(c.Param("username"))
+	if err != nil {
+		if errors.Is(err, services.ErrRatingUserNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// ApplyContestRatings handles applying the ratings of an ended contest now
+// instead of waiting for the scheduler (admin).
+func (h *RatingHandler) ApplyContestRatings(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid contest id"})
+		return
+	}
+
+	if err := h.ratingService.ApplyContestRatings(id); err != nil {
+		switch {
+		case errors.Is(err, services.ErrContestNotRated), errors.Is(err, services.ErrContestNotEnded):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrRatingsApplied), errors.Is(err, services.ErrJudgingPending):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "ratings applied"})
+}
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/dto"
+	"github.com/klaus-creations/klaus-judge/api/internal/services"
+)
+
+// StatementHandler handles HTTP requests for problem statement translations.
+type StatementHandler struct {
+	statementService *services.StatementService
+}
+
+// NewStatementHandler creates a new statement handler.
+func NewStatementHandler(statementService *services.StatementService) *StatementHandler {
+	return &StatementHandler{statementService: statementService}
+}
+
+// ListStatements handles listing the statement of a problem in every locale.
+func (h *StatementHandler) ListStatements(c *gin.Context) {
+	statements, err := h.statementService.ListStatements(c.Param("slug"))
+	if err != nil {
+		respondStatementError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, statements)
+}
+
+// SetStatement handles adding or replacing a translation of a problem.
+func (h *StatementHandler) SetStatement(c *gin.Context) {
+	userID := h.getUserIDFromContext(c)
+	if userID == uuid.Nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req dto.StatementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	statement, err := h.statementService.SetStatement(c.Param("slug"), c.Param("locale"), &req, userID)
+	if err != nil {
+		respondStatementError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.StatementDTOFromDomain(statement))
+}
+
+// DeleteStatement handles removing a translation of a problem.
+func (h *StatementHandler) DeleteStatement(c *gin.Context) {
+	userID := h.getUserIDFromContext(c)
+	if userID == uuid.Nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	if err := h.statementService.DeleteStatement(c.Param("slug"), c.Param("locale"), userID); err != nil {
+		respondStatementError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "statement deleted"})
+}
+
+// respondStatementError maps statement service errors to HTTP responses.
+func respondStatementError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrProblemNotFound), errors.Is(err, services.ErrStatementNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInvalidLocale), errors.Is(err, services.ErrDefaultStatement):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// getUserIDFromContext extracts user ID from context.
+func (h *StatementHandler) getUserIDFromContext(c *gin.Context) uuid.UUID {
+	uid, exists := c.Get("user_id")
+	if !exists {
+		return uuid.Nil
+	}
+
+	userID, ok := uid.(uuid.UUID)
+	if !ok {
+		return uuid.Nil
+	}
+
+	return userID
+}
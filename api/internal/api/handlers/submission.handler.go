@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -34,9 +35,19 @@ func (h *SubmissionHandler) SubmitSolution(c *gin.Context) {
 	}
 
 	ip := c.ClientIP()
+	role, _ := c.Get("role")
+	isAdmin := role == "admin"
 
-	submission, err := h.submissionService.SubmitSolution(&req, userID, ip)
+	submission, err := h.submissionService.SubmitSolution(&req, userID, ip, isAdmin)
 	if err != nil {
+		if errors.Is(err, services.ErrNotRegisteredForContest) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, services.ErrAmbiguousContest) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -103,6 +114,37 @@ func (h *SubmissionHandler) ListAllSubmissions(c *gin.Context) {
 	c.JSON(http.StatusOK, resp)
 }
 
+// ListContestSubmissions handles listing the submissions of a contest.
+func (h *SubmissionHandler) ListContestSubmissions(c *gin.Context) {
+	contestID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid contest id"})
+		return
+	}
+
+	userID := h.getUserIDFromContext(c)
+	role, _ := c.Get("role")
+	isAdmin := role == "admin"
+
+	pagination := dto.ParsePagination(c)
+	filters := dto.ParseSubmissionFilters(c)
+
+	resp, err := h.submissionService.ListContestSubmissions(contestID, userID, isAdmin, pagination, filters)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrContestNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrContestAccessDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
 // getUserIDFromContext same as above.
 func (h *SubmissionHandler) getUserIDFromContext(c *gin.Context) uuid.UUID {
 	uid, exists := c.Get("user_id")
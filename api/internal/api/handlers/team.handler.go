@@ -0,0 +1,262 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/dto"
+	"github.com/klaus-creations/klaus-judge/api/internal/services"
+)
+
+// TeamHandler handles HTTP requests for contest teams.
+type TeamHandler struct {
+	teamService *services.TeamService
+}
+
+// NewTeamHandler creates a new team handler.
+func NewTeamHandler(teamService *services.TeamService) *TeamHandler {
+	return &TeamHandler{
+		teamService: teamService,
+	}
+}
+
+// CreateTeam handles creating a team captained by the current user.
+func (h *TeamHandler) CreateTeam(c *gin.Context) {
+	userID := h.getUserIDFromContext(c)
+	if userID == uuid.Nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid contest id"})
+		return
+	}
+
+	var req dto.CreateTeamRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	team, err := h.teamService.CreateTeam(id, userID, &req)
+	if err != nil {
+		h.respondTeamError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, team)
+}
+
+// ListTeams handles listing the teams of a contest.
+func (h *TeamHandler) ListTeams(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid contest id"})
+		return
+	}
+
+	role, _ := c.Get("role")
+	isAdmin := role == "admin"
+
+	teams, err := h.teamService.ListTeams(id, h.getUserIDFromContext(c), isAdmin)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "contest not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"teams": teams})
+}
+
+// GetMyTeam handles getting the current user's team and pending invites.
+func (h *TeamHandler) GetMyTeam(c *gin.Context) {
+	userID := h.getUserIDFromContext(c)
+	if userID == uuid.Nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid contest id"})
+		return
+	}
+
+	resp, err := h.teamService.GetMyTeam(id, userID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "contest not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// InviteMember handles the captain inviting a user to the team.
+func (h *TeamHandler) InviteMember(c *gin.Context) {
+	userID, contestID, teamID, ok := h.parseTeamRequest(c)
+	if !ok {
+		return
+	}
+
+	var req dto.InviteTeamMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.teamService.InviteMember(contestID, teamID, userID, &req); err != nil {
+		h.respondTeamError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "invite sent"})
+}
+
+// AcceptInvite handles the current user joining a team that invited them.
+func (h *TeamHandler) AcceptInvite(c *gin.Context) {
+	userID, contestID, teamID, ok := h.parseTeamRequest(c)
+	if !ok {
+		return
+	}
+
+	team, err := h.teamService.AcceptInvite(contestID, teamID, userID)
+	if err != nil {
+		h.respondTeamError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, team)
+}
+
+// DeclineInvite handles the current user declining a team invite.
+func (h *TeamHandler) DeclineInvite(c *gin.Context) {
+	userID, contestID, teamID, ok := h.parseTeamRequest(c)
+	if !ok {
+		return
+	}
+
+	if err := h.teamService.DeclineInvite(contestID, teamID, userID); err != nil {
+		h.respondTeamError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "invite declined"})
+}
+
+// LeaveTeam handles the current user leaving their team.
+func (h *TeamHandler) LeaveTeam(c *gin.Context) {
+	userID, contestID, teamID, ok := h.parseTeamRequest(c)
+	if !ok {
+		return
+	}
+
+	if err := h.teamService.LeaveTeam(contestID, teamID, userID); err != nil {
+		h.respondTeamError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "left team"})
+}
+
+// RegisterTeam handles the captain registering the team for the contest.
+func (h *TeamHandler) RegisterTeam(c *gin.Context) {
+	userID, contestID, teamID, ok := h.parseTeamRequest(c)
+	if !ok {
+		return
+	}
+
+	// The body is optional: public contests need no credentials.
+	var req dto.RegisterContestRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.teamService.RegisterTeam(contestID, teamID, userID, &req); err != nil {
+		h.respondTeamError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "team registered for contest"})
+}
+
+// UnregisterTeam handles the captain withdrawing the team from the contest.
+func (h *TeamHandler) UnregisterTeam(c *gin.Context) {
+	userID, contestID, teamID, ok := h.parseTeamRequest(c)
+	if !ok {
+		return
+	}
+
+	if err := h.teamService.UnregisterTeam(contestID, teamID, userID); err != nil {
+		h.respondTeamError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "team unregistered from contest"})
+}
+
+// parseTeamRequest extracts the current user, contest and team IDs, writing
+// an error response if any is missing or malformed.
+func (h *TeamHandler) parseTeamRequest(c *gin.Context) (uuid.UUID, uuid.UUID, uuid.UUID, bool) {
+	userID := h.getUserIDFromContext(c)
+	if userID == uuid.Nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return uuid.Nil, uuid.Nil, uuid.Nil, false
+	}
+
+	contestID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid contest id"})
+		return uuid.Nil, uuid.Nil, uuid.Nil, false
+	}
+	teamID, err := uuid.Parse(c.Param("teamId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid team id"})
+		return uuid.Nil, uuid.Nil, uuid.Nil, false
+	}
+
+	return userID, contestID, teamID, true
+}
+
+// respondTeamError maps team errors to HTTP responses.
+func (h *TeamHandler) respondTeamError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrNotTeamCaptain),
+		errors.Is(err, services.ErrNotTeamMember),
+		errors.Is(err, services.ErrContestStarted),
+		errors.Is(err, services.ErrContestAccessDenied),
+		errors.Is(err, services.ErrInvalidInviteCode):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrAlreadyInTeam),
+		errors.Is(err, services.ErrUserAlreadyInTeam),
+		errors.Is(err, services.ErrAlreadyInvited),
+		errors.Is(err, services.ErrAlreadyRegistered),
+		errors.Is(err, services.ErrTeamFull):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrContestNotFound),
+		errors.Is(err, services.ErrTeamNotFound),
+		errors.Is(err, services.ErrTeamInviteNotFound),
+		errors.Is(err, services.ErrNotRegistered):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
+
+func (h *TeamHandler) getUserIDFromContext(c *gin.Context) uuid.UUID {
+	uid, exists := c.Get("user_id")
+	if !exists {
+		return uuid.Nil
+	}
+
+	userID, ok := uid.(uuid.UUID)
+	if !ok {
+		return uuid.Nil
+	}
+
+	return userID
+}
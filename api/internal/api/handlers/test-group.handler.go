@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/dto"
+	"github.com/klaus-creations/klaus-judge/api/internal/services"
+)
+
+// TestGroupHandler handles HTTP requests for test groups (subtasks).
+type TestGroupHandler struct {
+	testGroupService *services.TestGroupService
+}
+
+// NewTestGroupHandler creates a new test group handler.
+func NewTestGroupHandler(testGroupService *services.TestGroupService) *TestGroupHandler {
+	return &TestGroupHandler{testGroupService: testGroupService}
+}
+
+// ListGroups handles listing the test groups of a problem.
+func (h *TestGroupHandler) ListGroups(c *gin.Context) {
+	groups, err := h.testGroupService.ListGroups(c.Param("slug"))
+	if err != nil {
+		respondTestGroupError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"groups": groups})
+}
+
+// CreateGroup handles adding a test group to a problem.
+func (h *TestGroupHandler) CreateGroup(c *gin.Context) {
+	userID := h.getUserIDFromContext(c)
+	if userID == uuid.Nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req dto.TestGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	group, err := h.testGroupService.CreateGroup(c.Param("slug"), &req, userID)
+	if err != nil {
+		respondTestGroupError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.TestGroupDTOFromDomain(group))
+}
+
+// UpdateGroup handles replacing the settings of a test group.
+func (h *TestGroupHandler) UpdateGroup(c *gin.Context) {
+	userID := h.getUserIDFromContext(c)
+	if userID == uuid.Nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	groupID, err := uuid.Parse(c.Param("groupId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid test group id"})
+		return
+	}
+
+	var req dto.TestGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	group, err := h.testGroupService.UpdateGroup(c.Param("slug"), groupID, &req, userID)
+	if err != nil {
+		respondTestGroupError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.TestGroupDTOFromDomain(group))
+}
+
+// DeleteGroup handles removing a test group.
+func (h *TestGroupHandler) DeleteGroup(c *gin.Context) {
+	userID := h.getUserIDFromContext(c)
+	if userID == uuid.Nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	groupID, err := uuid.Parse(c.Param("groupId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid test group id"})
+		return
+	}
+
+	if err := h.testGroupService.DeleteGroup(c.Param("slug"), groupID, userID); err != nil {
+		respondTestGroupError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "test group deleted"})
+}
+
+// respondTestGroupError maps test group service errors to HTTP responses.
+func respondTestGroupError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrProblemNotFound), errors.Is(err, services.ErrTestGroupNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInvalidGroupDependency), errors.Is(err, services.ErrGroupDependencyCycle):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// getUserIDFromContext extracts user ID from context.
+func (h *TestGroupHandler) getUserIDFromContext(c *gin.Context) uuid.UUID {
+	uid, exists := c.Get("user_id")
+	if !exists {
+		return uuid.Nil
+	}
+
+	userID, ok := uid.(uuid.UUID)
+	if !ok {
+		return uuid.Nil
+	}
+
+	return userID
+}
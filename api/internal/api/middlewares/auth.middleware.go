@@ -58,6 +58,33 @@ func AuthMiddleware() gin.HandlerFunc {
 	}
 }
 
+// OptionalAuthMiddleware sets user_id and role when a valid bearer token is
+// present, but lets anonymous requests through on public routes.
+func OptionalAuthMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		parts := strings.Split(c.GetHeader("Authorization"), " ")
+		if len(parts) != 2 || parts[0] != "Bearer" {
+			c.Next()
+			return
+		}
+
+		claims, err := validateJWT(parts[1])
+		if err != nil {
+			c.Next()
+			return
+		}
+
+		userIDStr, _ := claims["user_id"].(string)
+		if userID, err := uuid.Parse(userIDStr); err == nil {
+			role, _ := claims["role"].(string)
+			c.Set("user_id", userID)
+			c.Set("role", role)
+		}
+
+		c.Next()
+	}
+}
+
 func AdminMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		role, exists := c.Get("role")
@@ -70,6 +97,19 @@ func AdminMiddleware() gin.HandlerFunc {
 	}
 }
 
+// ModeratorMiddleware admits moderators as well as admins.
+func ModeratorMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, exists := c.Get("role")
+		if !exists || (role != "admin" && role != "moderator") {
+			c.JSON(http.StatusForbidden, gin.H{"error": "moderator access required"})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
 func validateJWT(tokenStr string) (jwt.MapClaims, error) {
 	jwtSecret := config.GetEnv("JWT_SECRET", "your-secret-key")
 
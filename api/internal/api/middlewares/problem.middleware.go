@@ -0,0 +1,36 @@
+package middlewares
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/services"
+)
+
+// ProblemPermissionMiddleware admits admins and the collaborators of the
+// :slug problem whose role grants the permission. It must run after
+// AuthMiddleware.
+func ProblemPermissionMiddleware(collaboratorService *services.CollaboratorService, permission services.ProblemPermission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, _ := c.Get("user_id")
+		id, _ := userID.(uuid.UUID)
+		role, _ := c.Get("role")
+		roleStr, _ := role.(string)
+
+		err := collaboratorService.AuthorizeProblem(c.Param("slug"), id, roleStr, permission)
+		switch {
+		case err == nil:
+			c.Next()
+			return
+		case errors.Is(err, services.ErrProblemNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrProblemAccessDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		c.Abort()
+	}
+}
@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/klaus-creations/klaus-judge/api/internal/api/handlers"
+	"github.com/klaus-creations/klaus-judge/api/internal/api/middlewares"
+	"github.com/klaus-creations/klaus-judge/api/internal/services"
+)
+
+func RegisterAttachmentRoutes(rg *gin.RouterGroup, h *handlers.AttachmentHandler, access *services.CollaboratorService) {
+	// Public downloads (admins and collaborators also see attachments of unreleased problems)
+	download := rg.Group("/attachments")
+	download.Use(middlewares.OptionalAuthMiddleware())
+	download.GET("/:id", h.DownloadAttachment)
+	download.GET("/:id/:name", h.DownloadAttachment)
+
+	attachments := rg.Group("/problems/:slug/attachments")
+	{
+		// Uploading and removing files needs edit rights; testers can list them
+		attachments.Use(middlewares.AuthMiddleware())
+		view := middlewares.ProblemPermissionMiddleware(access, services.ProblemPermissionView)
+		edit := middlewares.ProblemPermissionMiddleware(access, services.ProblemPermissionEdit)
+		attachments.GET("", view, h.ListAttachments)
+		attachments.POST("", edit, h.UploadAttachment)
+		attachments.DELETE("/:id", edit, h.DeleteAttachment)
+	}
+}
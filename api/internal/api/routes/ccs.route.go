@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/klaus-creations/klaus-judge/api/internal/api/handlers"
+	"github.com/klaus-creations/klaus-judge/api/internal/api/middlewares"
+)
+
+// RegisterCCSRoutes exposes contests through the ICPC CCS Contest API under
+// /ccs, so tools can use <base>/ccs as their API base URL. The API shows
+// unfrozen results and is admin only.
+func RegisterCCSRoutes(rg *gin.RouterGroup, h *handlers.CCSHandler) {
+	contest := rg.Group("/ccs/contests/:id")
+	{
+		contest.Use(middlewares.AuthMiddleware(), middlewares.AdminMiddleware())
+		contest.GET("", h.GetContest)
+		contest.GET("/state", h.GetState)
+		contest.GET("/judgement-types", h.ListJudgementTypes)
+		contest.GET("/languages", h.ListLanguages)
+		contest.GET("/problems", h.ListProblems)
+		contest.GET("/teams", h.ListTeams)
+		contest.GET("/submissions", h.ListSubmissions)
+		contest.GET("/judgements", h.ListJudgements)
+		contest.GET("/scoreboard", h.GetScoreboard)
+		contest.GET("/event-feed", h.GetEventFeed)
+	}
+}
@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/klaus-creations/klaus-judge/api/internal/api/handlers"
+	"github.com/klaus-creations/klaus-judge/api/internal/api/middlewares"
+	"github.com/klaus-creations/klaus-judge/api/internal/services"
+)
+
+func RegisterCheckerRoutes(rg *gin.RouterGroup, h *handlers.CheckerHandler, access *services.CollaboratorService) {
+	checker := rg.Group("/problems/:slug/checker")
+	{
+		// Testers may inspect the checker, only owners and editors replace it
+		checker.Use(middlewares.AuthMiddleware())
+		view := middlewares.ProblemPermissionMiddleware(access, services.ProblemPermissionView)
+		edit := middlewares.ProblemPermissionMiddleware(access, services.ProblemPermissionEdit)
+		checker.GET("", view, h.GetChecker)
+		checker.PUT("", edit, h.SetChecker)
+		checker.DELETE("", edit, h.DeleteChecker)
+	}
+}
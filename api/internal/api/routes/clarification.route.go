@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/klaus-creations/klaus-judge/api/internal/api/handlers"
+	"github.com/klaus-creations/klaus-judge/api/internal/api/middlewares"
+)
+
+func RegisterClarificationRoutes(rg *gin.RouterGroup, h *handlers.ClarificationHandler) {
+	clarifications := rg.Group("/contests/:id/clarifications")
+	{
+		// Protected routes (auth required)
+		clarifications.Use(middlewares.AuthMiddleware())
+		clarifications.POST("", h.Ask)
+		clarifications.GET("", h.List)
+
+		// Judge routes (moderators and admins)
+		judges := clarifications.Group("")
+		judges.Use(middlewares.ModeratorMiddleware())
+		judges.POST("/:clarificationId/answer", h.Answer)
+	}
+}
@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/klaus-creations/klaus-judge/api/internal/api/handlers"
+	"github.com/klaus-creations/klaus-judge/api/internal/api/middlewares"
+	"github.com/klaus-creations/klaus-judge/api/internal/services"
+)
+
+func RegisterCollaboratorRoutes(rg *gin.RouterGroup, h *handlers.CollaboratorHandler, access *services.CollaboratorService) {
+	collaborators := rg.Group("/problems/:slug/collaborators")
+	{
+		collaborators.Use(middlewares.AuthMiddleware())
+
+		// Every collaborator sees the team; owners change it
+		collaborators.GET("", middlewares.ProblemPermissionMiddleware(access, services.ProblemPermissionView), h.ListCollaborators)
+
+		manage := collaborators.Group("")
+		manage.Use(middlewares.ProblemPermissionMiddleware(access, services.ProblemPermissionManage))
+		manage.POST("", h.AddCollaborator)
+		manage.PUT("/:userId", h.UpdateCollaborator)
+		manage.DELETE("/:userId", h.RemoveCollaborator)
+	}
+}
@@ -0,0 +1,57 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/klaus-creations/klaus-judge/api/internal/api/handlers"
+	"github.com/klaus-creations/klaus-judge/api/internal/api/middlewares"
+)
+
+func RegisterContestRoutes(rg *gin.RouterGroup, h *handlers.ContestHandler) {
+	contests := rg.Group("/contests")
+	{
+		// Public routes for contests (admins see problem sets before the start)
+		public := contests.Group("")
+		public.Use(middlewares.OptionalAuthMiddleware())
+		public.GET("", h.ListContests)
+		public.GET("/:id", h.GetContest)
+		public.GET("/:id/problems", h.ListContestProblems)
+		public.GET("/:id/scoreboard", h.GetScoreboard)
+
+		// Protected routes (auth required)
+		contests.Use(middlewares.AuthMiddleware())
+		contests.POST("/join", h.JoinContest)
+		contests.POST("/:id/register", h.Register)
+		contests.DELETE("/:id/register", h.Unregister)
+		contests.POST("/:id/virtual", h.StartVirtual)
+		contests.GET("/:id/virtual", h.GetVirtualStanding)
+
+		// Admin-only routes
+		admin := contests.Group("")
+		admin.Use(middlewares.AdminMiddleware())
+		admin.POST("", h.CreateContest)
+		admin.PUT("/:id", h.UpdateContest)
+		admin.DELETE("/:id", h.DeleteContest)
+
+		// Problem set management (admin only)
+		admin.POST("/:id/problems", h.AddContestProblem)
+		admin.PUT("/:id/problems/:label", h.UpdateContestProblem)
+		admin.DELETE("/:id/problems/:label", h.RemoveContestProblem)
+
+		// Roster (admin only)
+		admin.GET("/:id/participants", h.ListParticipants)
+		admin.GET("/:id/participants/export", h.ExportParticipants)
+
+		// Private contest access (admin only)
+		admin.GET("/:id/invites", h.ListInvites)
+		admin.POST("/:id/invites", h.CreateInvite)
+		admin.DELETE("/:id/invites/:inviteId", h.RevokeInvite)
+		admin.GET("/:id/allowlist", h.ListAllowedUsers)
+		admin.POST("/:id/allowlist", h.AllowUser)
+		admin.DELETE("/:id/allowlist/:userId", h.DisallowUser)
+
+		// Frozen scoreboard resolution (admin only)
+		admin.GET("/:id/resolver", h.GetResolver)
+		admin.POST("/:id/resolver/next", h.RevealNext)
+		admin.POST("/:id/unfreeze", h.Unfreeze)
+	}
+}
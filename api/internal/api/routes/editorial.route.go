@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/klaus-creations/klaus-judge/api/internal/api/handlers"
+	"github.com/klaus-creations/klaus-judge/api/internal/api/middlewares"
+	"github.com/klaus-creations/klaus-judge/api/internal/services"
+)
+
+func RegisterEditorialRoutes(rg *gin.RouterGroup, h *handlers.EditorialHandler, access *services.CollaboratorService) {
+	editorial := rg.Group("/problems/:slug/editorial")
+	{
+		// Public once unlocked: after solving, at the reveal date or after the contest
+		editorial.GET("", middlewares.OptionalAuthMiddleware(), h.GetEditorial)
+
+		// Admins and the problem's editors
+		edit := editorial.Group("")
+		edit.Use(middlewares.AuthMiddleware())
+		edit.Use(middlewares.ProblemPermissionMiddleware(access, services.ProblemPermissionEdit))
+		edit.PUT("", h.SetEditorial)
+		edit.DELETE("", h.DeleteEditorial)
+	}
+}
@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/klaus-creations/klaus-judge/api/internal/api/handlers"
+	"github.com/klaus-creations/klaus-judge/api/internal/api/middlewares"
+	"github.com/klaus-creations/klaus-judge/api/internal/services"
+)
+
+func RegisterInteractorRoutes(rg *gin.RouterGroup, h *handlers.InteractorHandler, access *services.CollaboratorService) {
+	interactor := rg.Group("/problems/:slug/interactor")
+	{
+		// Read access for testers; owners and editors manage the interactor
+		interactor.Use(middlewares.AuthMiddleware())
+		view := middlewares.ProblemPermissionMiddleware(access, services.ProblemPermissionView)
+		edit := middlewares.ProblemPermissionMiddleware(access, services.ProblemPermissionEdit)
+		interactor.GET("", view, h.GetInteractor)
+		interactor.PUT("", edit, h.SetInteractor)
+		interactor.DELETE("", edit, h.DeleteInteractor)
+	}
+}
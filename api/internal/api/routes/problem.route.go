@@ -4,30 +4,49 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/klaus-creations/klaus-judge/api/internal/api/handlers"
 	"github.com/klaus-creations/klaus-judge/api/internal/api/middlewares"
+	"github.com/klaus-creations/klaus-judge/api/internal/services"
 )
 
-func RegisterProblemRoutes(rg *gin.RouterGroup, h *handlers.ProblemHandler) {
+func RegisterProblemRoutes(rg *gin.RouterGroup, h *handlers.ProblemHandler, access *services.CollaboratorService) {
 	problems := rg.Group("/problems")
 	{
-		// Public routes for problems
-		problems.GET("", h.ListProblems)
-		problems.GET("/:slug", h.GetProblem)
+		// Public routes for problems (admins and collaborators see unreleased problems)
+		public := problems.Group("")
+		public.Use(middlewares.OptionalAuthMiddleware())
+		public.GET("", h.ListProblems)
+		public.GET("/suggest", h.SuggestProblems)
+		public.GET("/:slug", h.GetProblem)
 
 		// Protected routes (auth required)
 		problems.Use(middlewares.AuthMiddleware())
 
-		// Admin-only routes
-		admin := problems.Group("")
-		admin.Use(middlewares.AdminMiddleware())
-		admin.POST("", h.CreateProblem)        // Create new problem
-		admin.PUT("/:slug", h.UpdateProblem)   // Update problem
-		admin.DELETE("/:slug", h.DeleteProblem)// Delete problem
+		// Authoring routes (moderators and admins); the author becomes the owner
+		authoring := problems.Group("")
+		authoring.Use(middlewares.ModeratorMiddleware())
+		authoring.POST("", h.CreateProblem)        // Create new problem
+		authoring.POST("/import", h.ImportProblem) // Create problem from a zip package
 
-		// Test case management (admin only)
+		// Per-problem routes for admins and the problem's collaborators
+		view := middlewares.ProblemPermissionMiddleware(access, services.ProblemPermissionView)
+		edit := middlewares.ProblemPermissionMiddleware(access, services.ProblemPermissionEdit)
+		manage := middlewares.ProblemPermissionMiddleware(access, services.ProblemPermissionManage)
+		problems.PUT("/:slug", edit, h.UpdateProblem)           // Update problem
+		problems.DELETE("/:slug", manage, h.DeleteProblem)      // Delete problem
+		problems.PUT("/:slug/status", edit, h.SetProblemStatus) // Submit for review; admins publish, schedule or archive
+		problems.GET("/:slug/export", view, h.ExportProblem)    // Download problem as a zip package
+
+		// Test case management
 		testcases := problems.Group("/:slug/testcases")
-		testcases.Use(middlewares.AdminMiddleware())
+		testcases.Use(edit)
 		testcases.POST("", h.AddTestCase)      // Add test case
 		testcases.PUT("/:id", h.UpdateTestCase)// Update test case
 		testcases.DELETE("/:id", h.DeleteTestCase) // Delete test case
+
+		// Revision history
+		revisions := problems.Group("/:slug/revisions")
+		revisions.GET("", view, h.ListRevisions)                     // List revisions, newest first
+		revisions.GET("/diff", view, h.DiffRevisions)                // Compare ?from= and ?to=
+		revisions.GET("/:number", view, h.GetRevision)               // Revision snapshot with tests
+		revisions.POST("/:number/rollback", edit, h.RollbackProblem) // Restore a revision
 	}
 }
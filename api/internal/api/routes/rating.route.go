@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/klaus-creations/klaus-judge/api/internal/api/handlers"
+	"github.com/klaus-creations/klaus-judge/api/internal/api/middlewares"
+)
+
+func RegisterRatingRoutes(rg *gin.RouterGroup, h *handlers.RatingHandler) {
+	// Public rating graph
+	rg.GET("/users/:username/rating", h.GetRatingHistory)
+
+	// Admin-only routes
+	admin := rg.Group("/contests/:id/ratings")
+	admin.Use(middlewares.AuthMiddleware(), middlewares.AdminMiddleware())
+	admin.POST("", h.ApplyContestRatings)
+}
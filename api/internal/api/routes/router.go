@@ -42,29 +42,84 @@ func CORSMiddleware() gin.HandlerFunc {
 	}
 }
 
+// BackgroundJobs are the services whose background work main starts next to
+// the HTTP server.
+type BackgroundJobs struct {
+	Submissions *services.SubmissionService
+	Ratings     *services.RatingService
+	Problems    *services.ProblemService
+}
+
 // SetupRouter initializes all routes and dependencies
-func SetupRouter(r *gin.Engine, db *gorm.DB) {
+func SetupRouter(r *gin.Engine, db *gorm.DB) *BackgroundJobs {
 	// ********* CORS MIDDLEWARE **************
 	r.Use(CORSMiddleware())
 	// Repositories
 	userRepo := gormRepo.NewUserRepository(db)
 	problemRepo := gormRepo.NewProblemRepository(db)
 	testCaseRepo := gormRepo.NewTestCaseRepository(db)
+	testGroupRepo := gormRepo.NewTestGroupRepository(db)
 	submissionRepo := gormRepo.NewSubmissionRepository(db)
-	testCaseResultRepo := gormRepo.NewTestCaseResultRepository(db)
+	contestRepo := gormRepo.NewContestRepository(db)
+	contestProblemRepo := gormRepo.NewContestProblemRepository(db)
+	contestParticipantRepo := gormRepo.NewContestParticipantRepository(db)
+	virtualParticipationRepo := gormRepo.NewVirtualParticipationRepository(db)
+	clarificationRepo := gormRepo.NewClarificationRepository(db)
+	contestInviteRepo := gormRepo.NewContestInviteRepository(db)
+	contestAllowedUserRepo := gormRepo.NewContestAllowedUserRepository(db)
+	ratingChangeRepo := gormRepo.NewRatingChangeRepository(db)
+	teamRepo := gormRepo.NewTeamRepository(db)
+	teamInviteRepo := gormRepo.NewTeamInviteRepository(db)
+	problemCheckerRepo := gormRepo.NewProblemCheckerRepository(db)
+	problemInteractorRepo := gormRepo.NewProblemInteractorRepository(db)
+	problemRevisionRepo := gormRepo.NewProblemRevisionRepository(db)
+	problemAttachmentRepo := gormRepo.NewProblemAttachmentRepository(db)
+	problemStatementRepo := gormRepo.NewProblemStatementRepository(db)
+	tagRepo := gormRepo.NewTagRepository(db)
+	problemCollaboratorRepo := gormRepo.NewProblemCollaboratorRepository(db)
+	problemEditorialRepo := gormRepo.NewProblemEditorialRepository(db)
+	problemEditTransactor := gormRepo.NewProblemEditTransactor(db)
+	contestRegistrationTransactor := gormRepo.NewContestRegistrationTransactor(db)
+
+	//  Rate Limiting
+	redisClient := config.GetRedisClient()
 
 	// Services
 	authService := services.NewAuthService(userRepo)
-	problemService := services.NewProblemService(problemRepo, testCaseRepo)
-	submissionService := services.NewSubmissionService(submissionRepo, testCaseResultRepo, problemRepo, userRepo)
+	problemService := services.NewProblemService(problemRepo, testCaseRepo, testGroupRepo, contestProblemRepo, contestParticipantRepo, problemCheckerRepo, problemInteractorRepo, problemRevisionRepo, problemStatementRepo, tagRepo, problemCollaboratorRepo, problemEditTransactor)
+	scoreboardService := services.NewScoreboardService(contestRepo, contestProblemRepo, contestParticipantRepo, submissionRepo, virtualParticipationRepo, redisClient)
+	submissionService := services.NewSubmissionService(submissionRepo, testCaseRepo, testGroupRepo, problemRepo, contestRepo, userRepo, contestProblemRepo, contestParticipantRepo, contestAllowedUserRepo, virtualParticipationRepo, problemCollaboratorRepo, scoreboardService)
+	contestService := services.NewContestService(contestRepo, contestProblemRepo, problemRepo, contestParticipantRepo, virtualParticipationRepo, contestInviteRepo, contestAllowedUserRepo, userRepo, contestRegistrationTransactor)
+	clarificationService := services.NewClarificationService(clarificationRepo, contestRepo, contestProblemRepo, contestParticipantRepo)
+	ratingService := services.NewRatingService(contestRepo, ratingChangeRepo, userRepo, scoreboardService)
+	teamService := services.NewTeamService(teamRepo, teamInviteRepo, contestRepo, contestParticipantRepo, userRepo, contestService)
+	testGroupService := services.NewTestGroupService(testGroupRepo, problemRepo, problemEditTransactor)
+	checkerService := services.NewCheckerService(problemCheckerRepo, problemRepo, problemEditTransactor)
+	interactorService := services.NewInteractorService(problemInteractorRepo, problemRepo, problemEditTransactor)
+	statementService := services.NewStatementService(problemStatementRepo, problemRepo, problemEditTransactor)
+	tagService := services.NewTagService(tagRepo)
+	collaboratorService := services.NewCollaboratorService(problemCollaboratorRepo, problemRepo, userRepo)
+	editorialService := services.NewEditorialService(problemEditorialRepo, problemRepo, submissionRepo, contestProblemRepo, contestParticipantRepo, problemCollaboratorRepo)
+	attachmentService := services.NewAttachmentService(problemAttachmentRepo, problemRepo, contestProblemRepo, contestParticipantRepo, problemCollaboratorRepo, config.GetStorage())
+	ccsService := services.NewCCSService(contestRepo, contestProblemRepo, contestParticipantRepo, submissionRepo, scoreboardService)
 
 	// Handlers
 	authHandler := handlers.NewAuthHandler(authService)
 	problemHandler := handlers.NewProblemHandler(problemService)
 	submissionHandler := handlers.NewSubmissionHandler(submissionService)
-
-	//  Rate Limiting
-	redisClient := config.GetRedisClient()
+	contestHandler := handlers.NewContestHandler(contestService, scoreboardService)
+	clarificationHandler := handlers.NewClarificationHandler(clarificationService)
+	ratingHandler := handlers.NewRatingHandler(ratingService)
+	teamHandler := handlers.NewTeamHandler(teamService)
+	ccsHandler := handlers.NewCCSHandler(ccsService)
+	testGroupHandler := handlers.NewTestGroupHandler(testGroupService)
+	checkerHandler := handlers.NewCheckerHandler(checkerService)
+	interactorHandler := handlers.NewInteractorHandler(interactorService)
+	statementHandler := handlers.NewStatementHandler(statementService)
+	attachmentHandler := handlers.NewAttachmentHandler(attachmentService)
+	tagHandler := handlers.NewTagHandler(tagService)
+	collaboratorHandler := handlers.NewCollaboratorHandler(collaboratorService)
+	editorialHandler := handlers.NewEditorialHandler(editorialService)
 
 	// 1. Global Limiter (IP Based): 1000 req / hour
 	// Helps prevent general abuse / scraping
@@ -83,7 +138,22 @@ func SetupRouter(r *gin.Engine, db *gorm.DB) {
 	RegisterAuthRoutes(authGroup, authHandler)
 
 	// problem routes
-	RegisterProblemRoutes(public, problemHandler)
+	RegisterProblemRoutes(public, problemHandler, collaboratorService)
+	RegisterTestGroupRoutes(public, testGroupHandler, collaboratorService)
+	RegisterCheckerRoutes(public, checkerHandler, collaboratorService)
+	RegisterInteractorRoutes(public, interactorHandler, collaboratorService)
+	RegisterStatementRoutes(public, statementHandler, collaboratorService)
+	RegisterAttachmentRoutes(public, attachmentHandler, collaboratorService)
+	RegisterCollaboratorRoutes(public, collaboratorHandler, collaboratorService)
+	RegisterEditorialRoutes(public, editorialHandler, collaboratorService)
+	RegisterTagRoutes(public, tagHandler)
+
+	// contest routes
+	RegisterContestRoutes(public, contestHandler)
+	RegisterClarificationRoutes(public, clarificationHandler)
+	RegisterRatingRoutes(public, ratingHandler)
+	RegisterTeamRoutes(public, teamHandler)
+	RegisterCCSRoutes(public, ccsHandler)
 
 	// protected routes
 	protected := r.Group("/api/v1")
@@ -93,12 +163,13 @@ func SetupRouter(r *gin.Engine, db *gorm.DB) {
 	RegisterUserRoutes(protected, authHandler)
 
 	// submission routes (Very Strict)
-	// 1 req / 10 seconds to prevent judge overload
-	submissionGroup := protected.Group("/submissions")
-	submissionGroup.Use(middlewares.NewUserRateLimiterMiddleware(redisClient, middlewares.Rate(1, 10*time.Second), "limiter:submission"))
-
-	// We manually register submission routes here to apply the specific middleware group
-	submissionGroup.POST("", submissionHandler.SubmitSolution)
-	submissionGroup.GET("", submissionHandler.ListMySubmissions) // User's own submissions
-	submissionGroup.GET("/:id", submissionHandler.GetSubmission)
+	// 1 submission / 10 seconds to prevent judge overload
+	submitLimit := middlewares.NewUserRateLimiterMiddleware(redisClient, middlewares.Rate(1, 10*time.Second), "limiter:submission")
+	RegisterSubmissionRoutes(public, submissionHandler, submitLimit)
+
+	return &BackgroundJobs{
+		Submissions: submissionService,
+		Ratings:     ratingService,
+		Problems:    problemService,
+	}
 }
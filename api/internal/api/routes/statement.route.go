@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/klaus-creations/klaus-judge/api/internal/api/handlers"
+	"github.com/klaus-creations/klaus-judge/api/internal/api/middlewares"
+	"github.com/klaus-creations/klaus-judge/api/internal/services"
+)
+
+func RegisterStatementRoutes(rg *gin.RouterGroup, h *handlers.StatementHandler, access *services.CollaboratorService) {
+	statements := rg.Group("/problems/:slug/statements")
+	{
+		// Anyone with view rights lists translations; owners and editors change them
+		statements.Use(middlewares.AuthMiddleware())
+		view := middlewares.ProblemPermissionMiddleware(access, services.ProblemPermissionView)
+		edit := middlewares.ProblemPermissionMiddleware(access, services.ProblemPermissionEdit)
+		statements.GET("", view, h.ListStatements)
+		statements.PUT("/:locale", edit, h.SetStatement)
+		statements.DELETE("/:locale", edit, h.DeleteStatement)
+	}
+}
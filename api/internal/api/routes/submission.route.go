@@ -6,12 +6,14 @@ import (
 	"github.com/klaus-creations/klaus-judge/api/internal/api/middlewares"
 )
 
-func RegisterSubmissionRoutes(rg *gin.RouterGroup, h *handlers.SubmissionHandler) {
+// RegisterSubmissionRoutes mounts the submission routes. submitLimit throttles
+// new submissions per user, so the judge queue cannot be flooded.
+func RegisterSubmissionRoutes(rg *gin.RouterGroup, h *handlers.SubmissionHandler, submitLimit gin.HandlerFunc) {
 	submissions := rg.Group("/submissions")
 	{
 		// Protected routes (auth required)
 		submissions.Use(middlewares.AuthMiddleware())
-		submissions.POST("", h.SubmitSolution)
+		submissions.POST("", submitLimit, h.SubmitSolution)
 		submissions.GET("", h.ListMySubmissions)
 		submissions.GET("/:id", h.GetSubmission)
 
@@ -20,4 +22,11 @@ func RegisterSubmissionRoutes(rg *gin.RouterGroup, h *handlers.SubmissionHandler
 		admin.Use(middlewares.AdminMiddleware())
 		admin.GET("/all", h.ListAllSubmissions)
 	}
+
+	// Contest submissions, filtered by the disclosure policy
+	contestSubmissions := rg.Group("/contests/:id/submissions")
+	{
+		contestSubmissions.Use(middlewares.AuthMiddleware())
+		contestSubmissions.GET("", h.ListContestSubmissions)
+	}
 }
@@ -0,0 +1,172 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/api/handlers"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+	"github.com/klaus-creations/klaus-judge/api/internal/dto"
+	"github.com/klaus-creations/klaus-judge/api/internal/repository"
+	"github.com/klaus-creations/klaus-judge/api/internal/services"
+)
+
+const testJWTSecret = "test-secret"
+
+func TestListContestSubmissionsRoute(t *testing.T) {
+	t.Setenv("JWT_SECRET", testJWTSecret)
+	gin.SetMode(gin.TestMode)
+
+	now := time.Now()
+	contestant, rival, allowed, stranger := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	running := &domain.Contest{ID: uuid.New(), IsPublic: true, StartTime: now.Add(-time.Hour), EndTime: now.Add(time.Hour)}
+	ended := &domain.Contest{ID: uuid.New(), IsPublic: true, StartTime: now.Add(-3 * time.Hour), EndTime: now.Add(-time.Hour)}
+	private := &domain.Contest{ID: uuid.New(), StartTime: now.Add(-3 * time.Hour), EndTime: now.Add(-time.Hour)}
+
+	contests := routeContestRepo{contests: map[uuid.UUID]*domain.Contest{}}
+	submissions := &routeSubmissionRepo{}
+	participants := routeParticipantRepo{participants: map[[2]uuid.UUID]*domain.ContestParticipant{}}
+	for _, contest := range []*domain.Contest{running, ended, private} {
+		contests.contests[contest.ID] = contest
+		for _, userID := range []uuid.UUID{contestant, rival} {
+			participants.participants[[2]uuid.UUID{contest.ID, userID}] = &domain.ContestParticipant{ContestID: contest.ID, UserID: userID}
+			submissions.subs = append(submissions.subs, &domain.Submission{
+				ID:          uuid.New(),
+				UserID:      userID,
+				ContestID:   &contest.ID,
+				Verdict:     domain.VerdictAC,
+				SubmittedAt: contest.StartTime.Add(time.Minute),
+			})
+		}
+	}
+	allowlist := routeAllowedUserRepo{allowed: map[[2]uuid.UUID]bool{{private.ID, allowed}: true}}
+
+	service := services.NewSubmissionService(submissions, nil, nil, nil, contests, nil, nil, participants, allowlist, nil, nil, nil)
+	r := gin.New()
+	RegisterSubmissionRoutes(r.Group("/api/v1"), handlers.NewSubmissionHandler(service), func(c *gin.Context) { c.Next() })
+
+	tests := []struct {
+		name      string
+		contestID uuid.UUID
+		viewer    uuid.UUID // uuid.Nil: no token
+		status    int
+		wantUsers []uuid.UUID
+	}{
+		{"anonymous", ended.ID, uuid.Nil, http.StatusUnauthorized, nil},
+		{"unknown contest", uuid.New(), stranger, http.StatusNotFound, nil},
+		{"contestant while running sees only their own", running.ID, contestant, http.StatusOK, []uuid.UUID{contestant}},
+		{"outsider while running sees nothing", running.ID, stranger, http.StatusOK, nil},
+		{"outsider after the end sees everything", ended.ID, stranger, http.StatusOK, []uuid.UUID{contestant, rival}},
+		{"stranger to a private contest", private.ID, stranger, http.StatusForbidden, nil},
+		{"allowlisted user of a private contest", private.ID, allowed, http.StatusOK, []uuid.UUID{contestant, rival}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/contests/"+tt.contestID.String()+"/submissions", nil)
+			if tt.viewer != uuid.Nil {
+				req.Header.Set("Authorization", "Bearer "+testToken(t, tt.viewer))
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.status, w.Body)
+			}
+			if tt.status != http.StatusOK {
+				return
+			}
+			var resp dto.SubmissionListResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			got := map[uuid.UUID]bool{}
+			for _, sub := range resp.Submissions {
+				got[sub.UserID] = true
+			}
+			if len(got) != len(tt.wantUsers) {
+				t.Fatalf("listed submissions of %v, want those of %v", got, tt.wantUsers)
+			}
+			for _, userID := range tt.wantUsers {
+				if !got[userID] {
+					t.Errorf("submissions of %s missing", userID)
+				}
+			}
+		})
+	}
+}
+
+func testToken(t *testing.T, userID uuid.UUID) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"user_id": userID.String(),
+		"role":    "user",
+		"exp":     time.Now().Add(time.Hour).Unix(),
+	})
+	signed, err := token.SignedString([]byte(testJWTSecret))
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+	return signed
+}
+
+type routeContestRepo struct {
+	repository.ContestRepository
+	contests map[uuid.UUID]*domain.Contest
+}
+
+func (r routeContestRepo) FindByID(id uuid.UUID) (*domain.Contest, error) {
+	if contest, ok := r.contests[id]; ok {
+		return contest, nil
+	}
+	return nil, repository.ErrNotFound
+}
+
+// routeSubmissionRepo applies the contest, user and time filters of a listing.
+type routeSubmissionRepo struct {
+	repository.SubmissionRepository
+	subs []*domain.Submission
+}
+
+func (r *routeSubmissionRepo) FindAll(pagination *domain.Pagination, filters *domain.SubmissionFilters) ([]*domain.Submission, int64, error) {
+	var found []*domain.Submission
+	for _, sub := range r.subs {
+		switch {
+		case sub.ContestID == nil || *sub.ContestID != filters.ContestID:
+		case filters.UserID != uuid.Nil && sub.UserID != filters.UserID:
+		case filters.SubmittedBefore != nil && !sub.SubmittedAt.Before(*filters.SubmittedBefore):
+		default:
+			found = append(found, sub)
+		}
+	}
+	return found, int64(len(found)), nil
+}
+
+type routeParticipantRepo struct {
+	repository.ContestParticipantRepository
+	participants map[[2]uuid.UUID]*domain.ContestParticipant
+}
+
+func (r routeParticipantRepo) FindByContestAndMember(contestID uuid.UUID, userID uuid.UUID) (*domain.ContestParticipant, error) {
+	if participant, ok := r.participants[[2]uuid.UUID{contestID, userID}]; ok {
+		return participant, nil
+	}
+	return nil, repository.ErrNotFound
+}
+
+type routeAllowedUserRepo struct {
+	repository.ContestAllowedUserRepository
+	allowed map[[2]uuid.UUID]bool
+}
+
+func (r routeAllowedUserRepo) FindByContestAndUser(contestID uuid.UUID, userID uuid.UUID) (*domain.ContestAllowedUser, error) {
+	if r.allowed[[2]uuid.UUID{contestID, userID}] {
+		return &domain.ContestAllowedUser{ContestID: contestID, UserID: userID}, nil
+	}
+	return nil, repository.ErrNotFound
+}
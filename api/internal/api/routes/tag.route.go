@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/klaus-creations/klaus-judge/api/internal/api/handlers"
+	"github.com/klaus-creations/klaus-judge/api/internal/api/middlewares"
+)
+
+func RegisterTagRoutes(rg *gin.RouterGroup, h *handlers.TagHandler) {
+	// Public listing (admins also count unreleased problems)
+	rg.GET("/tags", middlewares.OptionalAuthMiddleware(), h.ListTags)
+
+	tags := rg.Group("/tags")
+	{
+		// Admin-only routes
+		tags.Use(middlewares.AuthMiddleware())
+		tags.Use(middlewares.AdminMiddleware())
+		tags.PUT("/:id", h.RenameTag)
+		tags.POST("/:id/merge", h.MergeTag)
+	}
+}
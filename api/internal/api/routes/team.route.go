@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/klaus-creations/klaus-judge/api/internal/api/handlers"
+	"github.com/klaus-creations/klaus-judge/api/internal/api/middlewares"
+)
+
+func RegisterTeamRoutes(rg *gin.RouterGroup, h *handlers.TeamHandler) {
+	teams := rg.Group("/contests/:id/teams")
+	{
+		// Protected routes (auth required)
+		teams.Use(middlewares.AuthMiddleware())
+		teams.GET("", h.ListTeams)
+		teams.POST("", h.CreateTeam)
+		teams.GET("/mine", h.GetMyTeam)
+		teams.POST("/:teamId/invites", h.InviteMember)
+		teams.POST("/:teamId/accept", h.AcceptInvite)
+		teams.POST("/:teamId/decline", h.DeclineInvite)
+		teams.POST("/:teamId/leave", h.LeaveTeam)
+
+		// Registration (captain only)
+		teams.POST("/:teamId/register", h.RegisterTeam)
+		teams.DELETE("/:teamId/register", h.UnregisterTeam)
+	}
+}
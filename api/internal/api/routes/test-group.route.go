@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/klaus-creations/klaus-judge/api/internal/api/handlers"
+	"github.com/klaus-creations/klaus-judge/api/internal/api/middlewares"
+	"github.com/klaus-creations/klaus-judge/api/internal/services"
+)
+
+func RegisterTestGroupRoutes(rg *gin.RouterGroup, h *handlers.TestGroupHandler, access *services.CollaboratorService) {
+	groups := rg.Group("/problems/:slug/groups")
+	{
+		// Subtasks: viewers read them, editors change them
+		groups.Use(middlewares.AuthMiddleware())
+		view := middlewares.ProblemPermissionMiddleware(access, services.ProblemPermissionView)
+		edit := middlewares.ProblemPermissionMiddleware(access, services.ProblemPermissionEdit)
+		groups.GET("", view, h.ListGroups)
+		groups.POST("", edit, h.CreateGroup)
+		groups.PUT("/:groupId", edit, h.UpdateGroup)
+		groups.DELETE("/:groupId", edit, h.DeleteGroup)
+	}
+}
@@ -1,17 +1,174 @@
 package config
 
 import (
+	"strings"
+
+	"github.com/google/uuid"
 	"github.com/klaus-creations/klaus-judge/api/internal/domain"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 func Migrate(db *gorm.DB) error {
-    return db.AutoMigrate(
+	if err := db.SetupJoinTable(&domain.Problem{}, "Tags", &domain.ProblemTag{}); err != nil {
+		return err
+	}
+    err := db.AutoMigrate(
 		&domain.User{},
 		&domain.Problem{},
+		&domain.TestGroup{},
+		&domain.TestGroupDependency{},
 		&domain.TestCase{},
 		&domain.Submission{},
 		&domain.TestCaseResult{},
 		&domain.Contest{},
+		&domain.ContestProblem{},
+		&domain.ContestParticipant{},
+		&domain.VirtualParticipation{},
+		&domain.Clarification{},
+		&domain.ContestInvite{},
+		&domain.ContestAllowedUser{},
+		&domain.RatingChange{},
+		&domain.Team{},
+		&domain.TeamMember{},
+		&domain.TeamInvite{},
+		&domain.ProblemChecker{},
+		&domain.ProblemInteractor{},
+		&domain.ProblemTestSet{},
+		&domain.ProblemRevision{},
+		&domain.ProblemAttachment{},
+		&domain.ProblemStatement{},
+		&domain.Tag{},
+		&domain.ProblemTag{},
+		&domain.ProblemCollaborator{},
+		&domain.ProblemEditorial{},
+		&domain.EditorialSolution{},
 	)
+	if err != nil {
+		return err
+	}
+	if err := migrateProblemTags(db); err != nil {
+		return err
+	}
+	if err := migrateProblemOwners(db); err != nil {
+		return err
+	}
+	if err := migrateVerdicts(db); err != nil {
+		return err
+	}
+	return migrateProblemSearch(db)
+}
+
+// migrateProblemOwners makes the creator the owner of every problem without
+// one, which only problems created before collaborators can be, as the last
+// owner cannot be removed. It is safe to run on every start.
+func migrateProblemOwners(db *gorm.DB) error {
+	return db.Exec(`INSERT INTO problem_collaborators (id, problem_id, user_id, role, added_at)
+		SELECT gen_random_uuid(), p.id, p.created_by, ?, NOW() FROM problems p
+		WHERE NOT EXISTS (SELECT 1 FROM problem_collaborators pc WHERE pc.problem_id = p.id AND pc.role = ?)
+		ON CONFLICT (problem_id, user_id) DO NOTHING`,
+		domain.CollaboratorRoleOwner, domain.CollaboratorRoleOwner).Error
+}
+
+// migrateVerdicts rewrites the long verdict names older workers stored into
+// the verdict constants everything else compares against.
+func migrateVerdicts(db *gorm.DB) error {
+	var cases []string
+	var args []interface{}
+	var legacy []string
+	for name, verdict := range domain.LegacyVerdicts() {
+		cases = append(cases, "WHEN ? THEN ?")
+		args = append(args, name, verdict)
+		legacy = append(legacy, name)
+	}
+	args = append(args, legacy)
+
+	for _, table := range []string{"submissions", "test_case_results"} {
+		err := db.Exec("UPDATE "+table+" SET verdict = CASE verdict "+strings.Join(cases, " ")+
+			" END WHERE verdict IN ?", args...).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateProblemTags moves the legacy comma-separated problems.tags column
+// into the tags table and drops it.
+func migrateProblemTags(db *gorm.DB) error {
+	if !db.Migrator().HasColumn("problems", "tags") {
+		return nil
+	}
+
+	var rows []struct {
+		ID   uuid.UUID
+		Tags string
+	}
+	if err := db.Table("problems").Select("id, tags").Where("tags <> ''").Scan(&rows).Error; err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		tagIDs := map[string]uuid.UUID{}
+		for _, row := range rows {
+			for _, raw := range strings.Split(row.Tags, ",") {
+				name := domain.NormalizeTagName(raw)
+				if name == "" {
+					continue
+				}
+				id, ok := tagIDs[name]
+				if !ok {
+					tag := domain.Tag{Name: name}
+					err := tx.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "name"}}, DoNothing: true}).
+						Create(&tag).Error
+					if err != nil {
+						return err
+					}
+					if err := tx.Where("name = ?", name).First(&tag).Error; err != nil {
+						return err
+					}
+					id = tag.ID
+					tagIDs[name] = id
+				}
+				link := domain.ProblemTag{ProblemID: row.ID, TagID: id}
+				if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&link).Error; err != nil {
+					return err
+				}
+			}
+		}
+		return tx.Exec("ALTER TABLE problems DROP COLUMN tags").Error
+	})
+}
+
+// migrateProblemSearch adds the full-text search vector of problems, a
+// generated column weighting titles over tags over statements, and the GIN
+// indexes behind search and title suggestions. A generated column cannot read
+// the tags table, so search_tags mirrors each problem's tag names.
+func migrateProblemSearch(db *gorm.DB) error {
+	if db.Migrator().HasColumn("problems", "search_vector") {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		statements := []string{
+			`ALTER TABLE problems ADD COLUMN IF NOT EXISTS search_tags text NOT NULL DEFAULT ''`,
+			`UPDATE problems SET search_tags = COALESCE((
+				SELECT string_agg(tags.name, ' ' ORDER BY tags.name)
+				FROM problem_tags JOIN tags ON tags.id = problem_tags.tag_id
+				WHERE problem_tags.problem_id = problems.id), '')`,
+			`ALTER TABLE problems ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+				setweight(to_tsvector('english'::regconfig, coalesce(title, '')), 'A') ||
+				setweight(to_tsvector('english'::regconfig, coalesce(search_tags, '')), 'B') ||
+				setweight(to_tsvector('english'::regconfig, coalesce(description, '')), 'C')
+			) STORED`,
+			`CREATE INDEX IF NOT EXISTS idx_problems_search ON problems USING GIN (search_vector)`,
+			`CREATE INDEX IF NOT EXISTS idx_problems_title_words ON problems USING GIN (to_tsvector('simple'::regconfig, title))`,
+		}
+		for _, stmt := range statements {
+			if err := tx.Exec(stmt).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
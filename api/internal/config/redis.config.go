@@ -99,6 +99,45 @@ func PopSubmissionJob(timeout time.Duration) (uuid.UUID, error) {
 	return uuid.Parse(result[1])
 }
 
+// PopJudgeResult waits up to timeout for a judge result pushed by a worker
+// and returns it as JSON, or an empty string if none arrived.
+func PopJudgeResult(timeout time.Duration) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout+2*time.Second)
+	defer cancel()
+
+	result, err := RedisClient.BRPop(ctx, timeout, "judge_results").Result()
+	if errors.Is(err, redis.Nil) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if len(result) < 2 {
+		return "", fmt.Errorf("invalid queue response")
+	}
+
+	return result[1], nil
+}
+
+// RequeueJudgeResult puts back a judge result that could not be recorded, to
+// be retried after the results already waiting.
+func RequeueJudgeResult(result string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	return RedisClient.LPush(ctx, "judge_results", result).Err()
+}
+
+// DeadJudgeResult parks a judge result that could not be recorded after all
+// retries on the judge_results:dead list for an operator to inspect.
+func DeadJudgeResult(result string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	return RedisClient.LPush(ctx, "judge_results:dead", result).Err()
+}
+
 // GetQueueLength returns the number of pending jobs
 func GetQueueLength() (int64, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/klaus-creations/klaus-judge/api/internal/storage"
+)
+
+var Storage storage.Storage
+
+// GetStorage returns the initialized file storage
+func GetStorage() storage.Storage {
+	return Storage
+}
+
+// ConnectStorage sets up the file storage selected by STORAGE_DRIVER: "local"
+// (the default) keeps files below STORAGE_LOCAL_DIR, "s3" keeps them in an
+// S3-compatible bucket such as MinIO.
+func ConnectStorage() (storage.Storage, error) {
+	driver := GetEnv("STORAGE_DRIVER", "local")
+
+	switch driver {
+	case "local":
+		local, err := storage.NewLocalStorage(GetEnv("STORAGE_LOCAL_DIR", "./data/uploads"))
+		if err != nil {
+			return nil, err
+		}
+		Storage = local
+
+	case "s3":
+		s3, err := storage.NewS3Storage(storage.S3Config{
+			Endpoint:        GetEnv("S3_ENDPOINT", "http://minio:9000"),
+			Region:          GetEnv("S3_REGION", "us-east-1"),
+			Bucket:          GetEnv("S3_BUCKET", "klaus-judge"),
+			AccessKeyID:     GetEnv("S3_ACCESS_KEY_ID", ""),
+			SecretAccessKey: GetEnv("S3_SECRET_ACCESS_KEY", ""),
+			PathStyle:       GetEnv("S3_PATH_STYLE", "true") == "true",
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := s3.EnsureBucket(ctx); err != nil {
+			return nil, fmt.Errorf("failed to prepare S3 bucket: %w", err)
+		}
+		Storage = s3
+
+	default:
+		return nil, fmt.Errorf("unknown STORAGE_DRIVER %q", driver)
+	}
+
+	return Storage, nil
+}
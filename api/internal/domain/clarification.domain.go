@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Clarification is a contestant's question about a contest, optionally about
+// one of its problems, together with the judges' answer.
+type Clarification struct {
+	ID               uuid.UUID  `gorm:"primaryKey;type:uuid"`
+	ContestID        uuid.UUID  `gorm:"not null;index;type:uuid"`
+	ContestProblemID *uuid.UUID `gorm:"type:uuid"`
+	AskedBy          uuid.UUID  `gorm:"not null;index;type:uuid"`
+	Question         string     `gorm:"type:text;not null"`
+
+	// Answer
+	Answer      string     `gorm:"type:text"`
+	AnsweredBy  *uuid.UUID `gorm:"type:uuid"`
+	AnsweredAt  *time.Time
+	IsBroadcast bool `gorm:"default:false"` // answer visible to every participant
+
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+
+	// Relationships
+	Contest        Contest         `gorm:"foreignKey:ContestID"`
+	ContestProblem *ContestProblem `gorm:"foreignKey:ContestProblemID"`
+	Asker          User            `gorm:"foreignKey:AskedBy"`
+}
+
+func (c *Clarification) BeforeCreate(tx *gorm.DB) (err error) {
+	if c.ID == uuid.Nil {
+		c.ID, err = uuid.NewV7()
+	}
+	return
+}
@@ -10,8 +10,44 @@ const (
 	VerdictMLE     = "MLE" // Memory Limit Exceeded
 	VerdictRE      = "RE"  // Runtime Error
 	VerdictCE      = "CE"  // Compilation Error
+	VerdictSE      = "SE"  // System Error: the judge failed, not the submission
 )
 
+// legacyVerdicts maps the long verdict names older workers wrote onto the
+// verdicts above.
+var legacyVerdicts = map[string]string{
+	"ACCEPTED":              VerdictAC,
+	"WRONG_ANSWER":          VerdictWA,
+	"TIME_LIMIT_EXCEEDED":   VerdictTLE,
+	"MEMORY_LIMIT_EXCEEDED": VerdictMLE,
+	"RUNTIME_ERROR":         VerdictRE,
+	"COMPILATION_ERROR":     VerdictCE,
+	"SYSTEM_ERROR":          VerdictSE,
+}
+
+// NormalizeVerdict returns the verdict constant for a verdict reported by a
+// worker, accepting the legacy long names too; unknown verdicts become VerdictSE.
+func NormalizeVerdict(verdict string) string {
+	if v, ok := legacyVerdicts[verdict]; ok {
+		return v
+	}
+	switch verdict {
+	case VerdictAC, VerdictWA, VerdictTLE, VerdictMLE, VerdictRE, VerdictCE, VerdictSE:
+		return verdict
+	}
+	return VerdictSE
+}
+
+// LegacyVerdicts returns the legacy verdict names with their replacements,
+// for migrating stored verdicts.
+func LegacyVerdicts() map[string]string {
+	verdicts := make(map[string]string, len(legacyVerdicts))
+	for legacy, v := range legacyVerdicts {
+		verdicts[legacy] = v
+	}
+	return verdicts
+}
+
 // Language constants
 const (
 	LangCPP    = "cpp"
@@ -36,3 +72,43 @@ const (
 	RoleAdmin     = "admin"
 	RoleModerator = "moderator"
 )
+
+// Scoring mode constants
+const (
+	ScoringICPC = "icpc" // all-or-nothing per problem
+	ScoringIOI  = "ioi"  // partial credit from the points of passed tests
+)
+
+// Test group scoring rules
+const (
+	GroupScoringAll = "all" // the group's points only if every test passes
+	GroupScoringMin = "min" // the group's points times the lowest test score
+)
+
+// Problem type constants
+const (
+	ProblemTypeStandard    = "standard"    // output compared with the expected output (or by a checker)
+	ProblemTypeInteractive = "interactive" // solution talks to an interactor over stdin/stdout
+)
+
+// Problem status constants
+const (
+	ProblemStatusDraft     = "draft"     // visible to its author and admins only
+	ProblemStatusReview    = "review"    // finished, awaiting an admin's approval
+	ProblemStatusPublished = "published" // listed publicly
+	ProblemStatusArchived  = "archived"  // withdrawn from the public list
+)
+
+// Problem collaborator roles
+const (
+	CollaboratorRoleOwner  = "owner"  // edits the problem and manages its collaborators
+	CollaboratorRoleEditor = "editor" // edits the statement, tests and judging setup
+	CollaboratorRoleTester = "tester" // sees the unreleased problem and its tests, and may submit
+)
+
+// Contest status constants
+const (
+	ContestStatusUpcoming = "upcoming"
+	ContestStatusRunning  = "running"
+	ContestStatusPast     = "past"
+)
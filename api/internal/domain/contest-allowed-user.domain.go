@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ContestAllowedUser puts a user on the allowlist of a private contest.
+type ContestAllowedUser struct {
+	ID        uuid.UUID `gorm:"primaryKey;type:uuid"`
+	ContestID uuid.UUID `gorm:"not null;type:uuid;uniqueIndex:idx_contest_allowed_user"`
+	UserID    uuid.UUID `gorm:"not null;type:uuid;index;uniqueIndex:idx_contest_allowed_user"`
+
+	AddedAt time.Time `gorm:"autoCreateTime"`
+
+	// Relationships
+	Contest Contest `gorm:"foreignKey:ContestID"`
+	User    User    `gorm:"foreignKey:UserID"`
+}
+
+func (ca *ContestAllowedUser) BeforeCreate(tx *gorm.DB) (err error) {
+	if ca.ID == uuid.Nil {
+		ca.ID, err = uuid.NewV7()
+	}
+	return
+}
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ContestInvite is a code that lets users register for a private contest.
+type ContestInvite struct {
+	ID        uuid.UUID  `gorm:"primaryKey;type:uuid"`
+	ContestID uuid.UUID  `gorm:"not null;index;type:uuid"`
+	Code      string     `gorm:"uniqueIndex;size:32;not null"`
+	MaxUses   int        `gorm:"default:0"` // 0: unlimited
+	Uses      int        `gorm:"default:0"`
+	ExpiresAt *time.Time // nil: never expires
+	CreatedBy uuid.UUID  `gorm:"not null;type:uuid"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+
+	// Relationships
+	Contest Contest `gorm:"foreignKey:ContestID"`
+}
+
+func (ci *ContestInvite) BeforeCreate(tx *gorm.DB) (err error) {
+	if ci.ID == uuid.Nil {
+		ci.ID, err = uuid.NewV7()
+	}
+	return
+}
+
+// Usable reports whether the invite has neither expired nor run out of uses.
+func (ci *ContestInvite) Usable(now time.Time) bool {
+	if ci.ExpiresAt != nil && !now.Before(*ci.ExpiresAt) {
+		return false
+	}
+	return ci.MaxUses == 0 || ci.Uses < ci.MaxUses
+}
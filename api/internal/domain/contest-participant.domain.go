@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ContestParticipant records a user's registration for a contest.
+type ContestParticipant struct {
+	ID        uuid.UUID `gorm:"primaryKey;type:uuid"`
+	ContestID uuid.UUID `gorm:"not null;type:uuid;uniqueIndex:idx_contest_participant"`
+	UserID    uuid.UUID `gorm:"not null;type:uuid;index;uniqueIndex:idx_contest_participant"`
+	// TeamID is set when a team registered; UserID is then the team captain.
+	TeamID *uuid.UUID `gorm:"type:uuid;uniqueIndex"`
+
+	RegisteredAt time.Time `gorm:"autoCreateTime"`
+
+	// Relationships
+	Contest Contest `gorm:"foreignKey:ContestID"`
+	User    User    `gorm:"foreignKey:UserID"`
+	Team    *Team   `gorm:"foreignKey:TeamID"`
+}
+
+// EntrantID identifies the scoreboard row of the participant: the team for
+// team registrations, the user otherwise.
+func (cp *ContestParticipant) EntrantID() uuid.UUID {
+	if cp.TeamID != nil {
+		return *cp.TeamID
+	}
+	return cp.UserID
+}
+
+func (cp *ContestParticipant) BeforeCreate(tx *gorm.DB) (err error) {
+	if cp.ID == uuid.Nil {
+		cp.ID, err = uuid.NewV7()
+	}
+	return
+}
@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ContestProblem assigns a problem to a contest under a letter label (A, B, C...).
+type ContestProblem struct {
+	ID        uuid.UUID `gorm:"primaryKey;type:uuid"`
+	ContestID uuid.UUID `gorm:"not null;type:uuid;uniqueIndex:idx_contest_problem;uniqueIndex:idx_contest_label"`
+	ProblemID uuid.UUID `gorm:"not null;type:uuid;index;uniqueIndex:idx_contest_problem"`
+	Label     string    `gorm:"not null;size:8;uniqueIndex:idx_contest_label"`
+	Points    int       `gorm:"default:1"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+
+	// Relationships
+	Contest Contest `gorm:"foreignKey:ContestID"`
+	Problem Problem `gorm:"foreignKey:ProblemID"`
+}
+
+func (cp *ContestProblem) BeforeCreate(tx *gorm.DB) (err error) {
+	if cp.ID == uuid.Nil {
+		cp.ID, err = uuid.NewV7()
+	}
+	return
+}
@@ -14,7 +14,25 @@ type Contest struct {
 	StartTime   time.Time `gorm:"not null"`
 	EndTime     time.Time `gorm:"not null"`
 	IsPublic    bool      `gorm:"default:true"`
-	CreatedBy   uuid.UUID `gorm:"not null;type:uuid"`
+	ScoringMode string    `gorm:"default:'icpc'"` // icpc, ioi
+
+	// Teams
+	MaxTeamSize int `gorm:"default:0"` // 0: individual contest; otherwise participants register as teams of up to this many
+
+	// Disclosure
+	PublicCodeAfterEnd bool `gorm:"default:false"` // everyone may read contest code once the contest is over
+
+	// Rating
+	IsRated        bool `gorm:"default:false"`
+	RatingsApplied bool `gorm:"default:false"` // set once rating changes have been computed
+
+	// Private contest entry (invite codes and the allowlist are separate tables)
+	PasswordHash string
+
+	// Scoreboard freeze
+	FreezeMinutes int       `gorm:"default:0"`     // minutes before EndTime the public board freezes (0: never)
+	Unfrozen      bool      `gorm:"default:false"` // set once the frozen results have been revealed
+	CreatedBy     uuid.UUID `gorm:"not null;type:uuid"`
 
 	CreatedAt time.Time `gorm:"autoCreateTime"`
 	UpdatedAt time.Time `gorm:"autoUpdateTime"`
@@ -26,3 +44,30 @@ func (c *Contest) BeforeCreate(tx *gorm.DB) (err error) {
 	}
 	return
 }
+
+// Status reports whether the contest is upcoming, running or past at the given time.
+func (c *Contest) Status(now time.Time) string {
+	switch {
+	case now.Before(c.StartTime):
+		return ContestStatusUpcoming
+	case now.Before(c.EndTime):
+		return ContestStatusRunning
+	default:
+		return ContestStatusPast
+	}
+}
+
+// FreezeTime returns when the public scoreboard freezes, or nil if it never does.
+func (c *Contest) FreezeTime() *time.Time {
+	if c.FreezeMinutes <= 0 {
+		return nil
+	}
+	t := c.EndTime.Add(-time.Duration(c.FreezeMinutes) * time.Minute)
+	return &t
+}
+
+// IsFrozen reports whether the public scoreboard is frozen at the given time.
+func (c *Contest) IsFrozen(now time.Time) bool {
+	freeze := c.FreezeTime()
+	return freeze != nil && !now.Before(*freeze) && !c.Unfrozen
+}
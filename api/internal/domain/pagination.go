@@ -1,6 +1,10 @@
 package domain
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type Pagination struct {
 	Limit  int
@@ -8,11 +12,39 @@ type Pagination struct {
 }
 
 type ProblemFilters struct {
-	Difficulty string
-	Tags       string
+	Difficulty   string
+	Tags         []string // normalized tag names
+	MatchAllTags bool     // require every tag in Tags instead of any of them
+	Query        string   // full-text search; results are ranked by relevance
+	Status       string
+	// VisibleAt hides unpublished problems and problems whose contests have
+	// not started by this time, except those CollaboratorID works on.
+	VisibleAt      *time.Time
+	CollaboratorID uuid.UUID
 }
 
 type SubmissionFilters struct {
+	UserID    uuid.UUID
+	TeamID    uuid.UUID // submissions counting for a team
 	ProblemID uuid.UUID
 	Verdict   string
+	IsVirtual bool // contest queries only: select virtual instead of live submissions
+
+	// Listing only
+	ContestID       uuid.UUID  // live submissions of one contest
+	SubmittedBefore *time.Time // e.g. the scoreboard freeze
+}
+
+type ContestFilters struct {
+	Status string
+	// IncludePrivate lists every private contest; otherwise only those
+	// ViewerID is registered for or allowlisted in are listed.
+	IncludePrivate bool
+	ViewerID       uuid.UUID
+}
+
+type ClarificationFilters struct {
+	// VisibleTo restricts results to the user's own questions and broadcast answers.
+	VisibleTo      uuid.UUID
+	UnansweredOnly bool
 }
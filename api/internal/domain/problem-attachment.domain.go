@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ProblemAttachment is a file (an image, a sample archive) that a problem
+// statement links to. The file itself lives in storage under StorageKey.
+type ProblemAttachment struct {
+	ID          uuid.UUID `gorm:"primaryKey;type:uuid"`
+	ProblemID   uuid.UUID `gorm:"not null;index;type:uuid"`
+	FileName    string    `gorm:"not null"`
+	ContentType string    `gorm:"not null"`
+	Size        int64     `gorm:"not null"` // in bytes
+	StorageKey  string    `gorm:"not null"`
+	UploadedBy  uuid.UUID `gorm:"not null;type:uuid"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+func (a *ProblemAttachment) BeforeCreate(tx *gorm.DB) (err error) {
+	if a.ID == uuid.Nil {
+		a.ID, err = uuid.NewV7()
+	}
+	return
+}
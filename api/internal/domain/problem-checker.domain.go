@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ProblemChecker is a special judge that decides whether an output is correct
+// for problems with more than one right answer. The judge runs it as
+//
+//	checker input.txt output.txt answer.txt
+//
+// with the test input, the contestant's output and the expected output. Exit
+// code 0 accepts the output, 1 rejects it and anything else is a checker
+// failure. An optional first line on stdout holding a number between 0 and 1
+// awards that fraction of the test's points; the rest is shown as the message.
+type ProblemChecker struct {
+	ID        uuid.UUID `gorm:"primaryKey;type:uuid"`
+	ProblemID uuid.UUID `gorm:"not null;uniqueIndex;type:uuid"`
+	Language  string    `gorm:"not null"`
+	Code      string    `gorm:"type:text;not null"`
+	CreatedBy uuid.UUID `gorm:"not null;type:uuid"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+func (pc *ProblemChecker) BeforeCreate(tx *gorm.DB) (err error) {
+	if pc.ID == uuid.Nil {
+		pc.ID, err = uuid.NewV7()
+	}
+	return
+}
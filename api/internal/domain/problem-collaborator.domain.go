@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ProblemCollaborator gives a user a role on a problem; see CollaboratorRole*.
+// The creator of a problem becomes its first owner.
+type ProblemCollaborator struct {
+	ID        uuid.UUID `gorm:"primaryKey;type:uuid"`
+	ProblemID uuid.UUID `gorm:"not null;type:uuid;uniqueIndex:idx_problem_collaborator"`
+	UserID    uuid.UUID `gorm:"not null;type:uuid;index;uniqueIndex:idx_problem_collaborator"`
+	Role      string    `gorm:"not null"`
+
+	AddedAt time.Time `gorm:"autoCreateTime"`
+
+	// Relationships
+	User User `gorm:"foreignKey:UserID"`
+}
+
+func (pc *ProblemCollaborator) BeforeCreate(tx *gorm.DB) (err error) {
+	if pc.ID == uuid.Nil {
+		pc.ID, err = uuid.NewV7()
+	}
+	return
+}
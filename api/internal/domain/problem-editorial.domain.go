@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ProblemEditorial explains how to solve a problem. It unlocks for users who
+// solved the problem, and for everyone at RevealAt or once the last contest
+// containing the problem has ended.
+type ProblemEditorial struct {
+	ID        uuid.UUID  `gorm:"primaryKey;type:uuid"`
+	ProblemID uuid.UUID  `gorm:"not null;type:uuid;uniqueIndex"`
+	Content   string     `gorm:"type:text;not null"` // Markdown
+	RevealAt  *time.Time // unlocks the editorial for everyone
+
+	// Metadata
+	AuthorID  uuid.UUID `gorm:"not null;type:uuid"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+
+	// Relationships
+	Solutions []EditorialSolution `gorm:"foreignKey:EditorialID"`
+}
+
+func (e *ProblemEditorial) BeforeCreate(tx *gorm.DB) (err error) {
+	if e.ID == uuid.Nil {
+		e.ID, err = uuid.NewV7()
+	}
+	return
+}
+
+// EditorialSolution is a reference solution of an editorial, at most one per language.
+type EditorialSolution struct {
+	ID          uuid.UUID `gorm:"primaryKey;type:uuid"`
+	EditorialID uuid.UUID `gorm:"not null;type:uuid;uniqueIndex:idx_editorial_solution_language"`
+	Language    string    `gorm:"not null;uniqueIndex:idx_editorial_solution_language"`
+	Code        string    `gorm:"type:text;not null"`
+}
+
+func (s *EditorialSolution) BeforeCreate(tx *gorm.DB) (err error) {
+	if s.ID == uuid.Nil {
+		s.ID, err = uuid.NewV7()
+	}
+	return
+}
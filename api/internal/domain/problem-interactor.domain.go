@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ProblemInteractor is the judge side of an interactive problem. The judge
+// runs it as
+//
+//	interactor input.txt answer.txt
+//
+// with the test input and the test's expected output (which may be empty),
+// and connects its stdout to the solution's stdin and the solution's stdout to
+// its stdin. Its exit code decides the verdict: 0 accepts, 1 rejects and
+// anything else is an interactor failure. Messages go to stderr.
+type ProblemInteractor struct {
+	ID        uuid.UUID `gorm:"primaryKey;type:uuid"`
+	ProblemID uuid.UUID `gorm:"not null;uniqueIndex;type:uuid"`
+	Language  string    `gorm:"not null"`
+	Code      string    `gorm:"type:text;not null"`
+	CreatedBy uuid.UUID `gorm:"not null;type:uuid"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+func (pi *ProblemInteractor) BeforeCreate(tx *gorm.DB) (err error) {
+	if pi.ID == uuid.Nil {
+		pi.ID, err = uuid.NewV7()
+	}
+	return
+}
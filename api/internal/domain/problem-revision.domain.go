@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Revision changes, recording what produced a problem revision
+const (
+	RevisionChangeBaseline         = "baseline" // state found before the first recorded edit
+	RevisionChangeCreate           = "create"
+	RevisionChangeUpdate           = "update"
+	RevisionChangeAddTestCase      = "add_test_case"
+	RevisionChangeUpdateTestCase   = "update_test_case"
+	RevisionChangeDeleteTestCase   = "delete_test_case"
+	RevisionChangeSetChecker       = "set_checker"
+	RevisionChangeDeleteChecker    = "delete_checker"
+	RevisionChangeSetInteractor    = "set_interactor"
+	RevisionChangeDeleteInteractor = "delete_interactor"
+	RevisionChangeAddTestGroup     = "add_test_group"
+	RevisionChangeUpdateTestGroup  = "update_test_group"
+	RevisionChangeDeleteTestGroup  = "delete_test_group"
+	RevisionChangeSetStatement     = "set_statement"
+	RevisionChangeDeleteStatement  = "delete_statement"
+	RevisionChangeRollback         = "rollback"
+)
+
+// ProblemRevision is an immutable snapshot of a problem's statement and its
+// translations, limits and test set, numbered from 1 per problem.
+type ProblemRevision struct {
+	ID           uuid.UUID `gorm:"primaryKey;type:uuid"`
+	ProblemID    uuid.UUID `gorm:"not null;type:uuid;uniqueIndex:idx_problem_revision"`
+	Number       int       `gorm:"not null;uniqueIndex:idx_problem_revision"`
+	Change       string    `gorm:"not null"` // see RevisionChange*
+	RolledBackTo *int      // revision restored by a rollback
+
+	// Snapshot
+	Title        string `gorm:"not null"`
+	Description  string `gorm:"type:text;not null"`
+	InputFormat  string `gorm:"type:text"`
+	OutputFormat string `gorm:"type:text"`
+	Notes        string `gorm:"type:text"`
+	Difficulty   string
+	TimeLimit    int
+	MemoryLimit  int
+	ScoringMode  string
+	Type         string
+	Tags         string `gorm:"type:text"`
+	TestSetHash  string `gorm:"not null;size:64;index"` // see ProblemTestSet
+	Statements   string `gorm:"type:text"`              // JSON array of StatementSnapshot; empty if not recorded
+
+	// Metadata
+	EditedBy  uuid.UUID `gorm:"not null;type:uuid"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+func (r *ProblemRevision) BeforeCreate(tx *gorm.DB) (err error) {
+	if r.ID == uuid.Nil {
+		r.ID, err = uuid.NewV7()
+	}
+	return
+}
+
+// ProblemTestSet stores a test set snapshot once, keyed by the SHA-256 of its
+// JSON encoding, so revisions that leave the tests alone share it.
+type ProblemTestSet struct {
+	Hash      string    `gorm:"primaryKey;size:64"`
+	Tests     string    `gorm:"type:text;not null"` // JSON array of TestCaseSnapshot
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+// TestCaseSnapshot is a test case as recorded in a ProblemTestSet. IDs are
+// kept so that a rollback restores the rows old test results point at.
+type TestCaseSnapshot struct {
+	ID             uuid.UUID  `json:"id"`
+	Input          string     `json:"input"`
+	ExpectedOutput string     `json:"expected_output"`
+	IsSample       bool       `json:"is_sample"`
+	IsHidden       bool       `json:"is_hidden"`
+	Points         int        `json:"points"`
+	OrderIndex     int        `json:"order_index"`
+	GroupID        *uuid.UUID `json:"group_id,omitempty"`
+}
+
+// StatementSnapshot is a statement translation as recorded in a ProblemRevision.
+type StatementSnapshot struct {
+	Locale       string `json:"locale"`
+	Title        string `json:"title"`
+	Description  string `json:"description"`
+	InputFormat  string `json:"input_format"`
+	OutputFormat string `json:"output_format"`
+	Notes        string `json:"notes"`
+}
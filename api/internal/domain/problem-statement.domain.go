@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ProblemStatement is a translation of a problem statement. The statement in
+// the problem's DefaultLocale lives on the Problem itself; this table only
+// holds the other locales.
+type ProblemStatement struct {
+	ID           uuid.UUID `gorm:"primaryKey;type:uuid"`
+	ProblemID    uuid.UUID `gorm:"not null;type:uuid;uniqueIndex:idx_problem_statement_locale"`
+	Locale       string    `gorm:"not null;size:35;uniqueIndex:idx_problem_statement_locale"` // BCP 47 tag, lower case
+	Title        string    `gorm:"not null"`
+	Description  string    `gorm:"type:text;not null"`
+	InputFormat  string    `gorm:"type:text"`
+	OutputFormat string    `gorm:"type:text"`
+	Notes        string    `gorm:"type:text"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+func (ps *ProblemStatement) BeforeCreate(tx *gorm.DB) (err error) {
+	if ps.ID == uuid.Nil {
+		ps.ID, err = uuid.NewV7()
+	}
+	return
+}
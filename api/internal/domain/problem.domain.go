@@ -15,20 +15,39 @@ type Problem struct {
 	Difficulty  string    `gorm:"default:'easy'"`
 	TimeLimit   int       `gorm:"not null"`
 	MemoryLimit int       `gorm:"not null"`
+	ScoringMode string    `gorm:"default:'icpc'"`     // icpc, ioi
+	Type        string    `gorm:"default:'standard'"` // standard, interactive (see ProblemInteractor)
+
+	// Rest of the statement in DefaultLocale; other locales are in Statements
+	InputFormat   string `gorm:"type:text"`
+	OutputFormat  string `gorm:"type:text"`
+	Notes         string `gorm:"type:text"`
+	DefaultLocale string `gorm:"size:35;default:'en'"`
+
+	// Lifecycle; problems predating statuses are published
+	Status      string     `gorm:"not null;default:'published';index"` // see ProblemStatus*
+	PublishAt   *time.Time `gorm:"index"`                              // scheduled publication of a draft or problem in review
+	PublishedAt *time.Time
 
 	// Statistics
 	AcceptedCount   int `gorm:"default:0"`
 	SubmissionCount int `gorm:"default:0"`
+	Revision        int `gorm:"default:0"` // latest ProblemRevision number; 0 before the first
 
 	// Metadata
-	Tags      string    `gorm:"type:text"`
 	CreatedBy uuid.UUID `gorm:"not null;type:uuid"`
 	CreatedAt time.Time `gorm:"autoCreateTime"`
 	UpdatedAt time.Time `gorm:"autoUpdateTime"`
 
 	// Relationships
-	TestCases   []TestCase   `gorm:"foreignKey:ProblemID"`
-	Submissions []Submission `gorm:"foreignKey:ProblemID"`
+	TestCases     []TestCase            `gorm:"foreignKey:ProblemID"`
+	TestGroups    []TestGroup           `gorm:"foreignKey:ProblemID"`
+	Checker       *ProblemChecker       `gorm:"foreignKey:ProblemID"`
+	Interactor    *ProblemInteractor    `gorm:"foreignKey:ProblemID"`
+	Statements    []ProblemStatement    `gorm:"foreignKey:ProblemID"`
+	Tags          []Tag                 `gorm:"many2many:problem_tags"`
+	Collaborators []ProblemCollaborator `gorm:"foreignKey:ProblemID"`
+	Submissions   []Submission          `gorm:"foreignKey:ProblemID"`
 }
 
 func (p *Problem) BeforeCreate(tx *gorm.DB) (err error) {
@@ -37,3 +56,17 @@ func (p *Problem) BeforeCreate(tx *gorm.DB) (err error) {
 	}
 	return
 }
+
+// IsPublished reports whether the problem is listed publicly.
+func (p *Problem) IsPublished() bool {
+	return p.Status == ProblemStatusPublished
+}
+
+// TagNames returns the names of the problem's tags.
+func (p *Problem) TagNames() []string {
+	names := make([]string, 0, len(p.Tags))
+	for _, t := range p.Tags {
+		names = append(names, t.Name)
+	}
+	return names
+}
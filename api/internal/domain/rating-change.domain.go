@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RatingChange records how a rated contest changed a participant's rating.
+type RatingChange struct {
+	ID        uuid.UUID `gorm:"primaryKey;type:uuid"`
+	ContestID uuid.UUID `gorm:"not null;type:uuid;uniqueIndex:idx_rating_change"`
+	UserID    uuid.UUID `gorm:"not null;type:uuid;index;uniqueIndex:idx_rating_change"`
+	Rank      int       `gorm:"not null"`
+	OldRating int       `gorm:"not null"`
+	NewRating int       `gorm:"not null"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+
+	// Relationships
+	Contest Contest `gorm:"foreignKey:ContestID"`
+	User    User    `gorm:"foreignKey:UserID"`
+}
+
+func (rc *RatingChange) BeforeCreate(tx *gorm.DB) (err error) {
+	if rc.ID == uuid.Nil {
+		rc.ID, err = uuid.NewV7()
+	}
+	return
+}
@@ -8,14 +8,21 @@ import (
 )
 
 type Submission struct {
-	ID        uuid.UUID `gorm:"primaryKey;type:uuid"`
-	UserID    uuid.UUID `gorm:"not null;index;type:uuid"`
-	ProblemID uuid.UUID `gorm:"not null;index;type:uuid"`
-	Code      string    `gorm:"type:text;not null"`
-	Language  string    `gorm:"not null"` // cpp, python, java, rust, go
+	ID        uuid.UUID  `gorm:"primaryKey;type:uuid"`
+	UserID    uuid.UUID  `gorm:"not null;index;type:uuid"`
+	ProblemID uuid.UUID  `gorm:"not null;index;type:uuid"`
+	ContestID *uuid.UUID `gorm:"index;type:uuid"` // set when submitted during a running contest
+	IsVirtual bool       `gorm:"default:false"`   // submitted during a virtual run of ContestID
+	TeamID    *uuid.UUID `gorm:"index;type:uuid"` // team the submission counts for in ContestID
+	Code      string     `gorm:"type:text;not null"`
+	Language  string     `gorm:"not null"` // cpp, python, java, rust, go
+
+	// ProblemRevision is the problem revision current when the submission was
+	// made; 0 if the problem had no recorded revisions yet.
+	ProblemRevision int `gorm:"default:0"`
 
 	// Execution results
-	Verdict       string  `gorm:"default:'QUEUED'"` // QUEUED, JUDGING, AC, WA, TLE, MLE, RE, CE
+	Verdict       string  `gorm:"default:'QUEUED'"` // QUEUED, JUDGING, AC, WA, TLE, MLE, RE, CE, SE
 	ExecutionTime int     `gorm:"default:0"`        // in milliseconds
 	MemoryUsed    int     `gorm:"default:0"`        // in KB
 	Score         float64 `gorm:"default:0"`        // for partial scoring
@@ -37,6 +44,14 @@ type Submission struct {
 	TestResults []TestCaseResult `gorm:"foreignKey:SubmissionID"`
 }
 
+// EntrantID identifies the contest scoreboard row the submission counts for.
+func (s *Submission) EntrantID() uuid.UUID {
+	if s.TeamID != nil {
+		return *s.TeamID
+	}
+	return s.UserID
+}
+
 func (s *Submission) BeforeCreate(tx *gorm.DB) (err error) {
 	if s.ID == uuid.Nil {
 		s.ID, err = uuid.NewV7()
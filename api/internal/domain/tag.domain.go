@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Tag is a problem category such as "dp" or "graphs". Problems and tags are
+// linked through ProblemTag.
+type Tag struct {
+	ID        uuid.UUID `gorm:"primaryKey;type:uuid"`
+	Name      string    `gorm:"uniqueIndex;not null"` // normalized, see NormalizeTagName
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+// ProblemTag is the join table of Problem.Tags.
+type ProblemTag struct {
+	ProblemID uuid.UUID `gorm:"primaryKey;type:uuid"`
+	TagID     uuid.UUID `gorm:"primaryKey;type:uuid;index"`
+}
+
+// TagCount is a tag with the number of problems carrying it.
+type TagCount struct {
+	Tag
+	ProblemCount int64
+}
+
+func (t *Tag) BeforeCreate(tx *gorm.DB) (err error) {
+	if t.ID == uuid.Nil {
+		t.ID, err = uuid.NewV7()
+	}
+	return
+}
+
+// NormalizeTagName lower-cases a tag name and joins its words with hyphens,
+// so "Binary Search" and "binary-search" are the same tag.
+func NormalizeTagName(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), "-")
+}
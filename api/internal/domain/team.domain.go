@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Team is a group of users taking part in one contest as a single participant.
+type Team struct {
+	ID        uuid.UUID `gorm:"primaryKey;type:uuid"`
+	ContestID uuid.UUID `gorm:"not null;type:uuid;uniqueIndex:idx_contest_team_name"`
+	Name      string    `gorm:"not null;size:64;uniqueIndex:idx_contest_team_name"`
+	CaptainID uuid.UUID `gorm:"not null;type:uuid"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+
+	// Relationships
+	Contest Contest      `gorm:"foreignKey:ContestID"`
+	Captain User         `gorm:"foreignKey:CaptainID"`
+	Members []TeamMember `gorm:"foreignKey:TeamID"`
+}
+
+func (t *Team) BeforeCreate(tx *gorm.DB) (err error) {
+	if t.ID == uuid.Nil {
+		t.ID, err = uuid.NewV7()
+	}
+	return
+}
+
+// TeamMember is a user's membership of a team. A user is in at most one team
+// per contest.
+type TeamMember struct {
+	ID        uuid.UUID `gorm:"primaryKey;type:uuid"`
+	TeamID    uuid.UUID `gorm:"not null;index;type:uuid"`
+	ContestID uuid.UUID `gorm:"not null;type:uuid;uniqueIndex:idx_team_member_contest"`
+	UserID    uuid.UUID `gorm:"not null;type:uuid;index;uniqueIndex:idx_team_member_contest"`
+
+	JoinedAt time.Time `gorm:"autoCreateTime"`
+
+	// Relationships
+	User User `gorm:"foreignKey:UserID"`
+}
+
+func (tm *TeamMember) BeforeCreate(tx *gorm.DB) (err error) {
+	if tm.ID == uuid.Nil {
+		tm.ID, err = uuid.NewV7()
+	}
+	return
+}
+
+// TeamInvite is a pending invitation for a user to join a team.
+type TeamInvite struct {
+	ID        uuid.UUID `gorm:"primaryKey;type:uuid"`
+	TeamID    uuid.UUID `gorm:"not null;type:uuid;uniqueIndex:idx_team_invite"`
+	UserID    uuid.UUID `gorm:"not null;type:uuid;index;uniqueIndex:idx_team_invite"`
+	InvitedBy uuid.UUID `gorm:"not null;type:uuid"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+
+	// Relationships
+	Team Team `gorm:"foreignKey:TeamID"`
+	User User `gorm:"foreignKey:UserID"`
+}
+
+func (ti *TeamInvite) BeforeCreate(tx *gorm.DB) (err error) {
+	if ti.ID == uuid.Nil {
+		ti.ID, err = uuid.NewV7()
+	}
+	return
+}
@@ -12,12 +12,16 @@ type TestCaseResult struct {
 	SubmissionID uuid.UUID `gorm:"not null;index;type:uuid"`
 	TestCaseID   uuid.UUID `gorm:"not null;type:uuid"`
 
-	Verdict       string `gorm:"not null"`  // AC, WA, TLE, MLE, RE
+	Verdict       string `gorm:"not null"`  // AC, WA, TLE, MLE, RE, SE
 	ExecutionTime int    `gorm:"default:0"` // in milliseconds
 	MemoryUsed    int    `gorm:"default:0"` // in KB
 	Output        string `gorm:"type:text"` // actual output (truncated if too large)
 	ErrorMessage  string `gorm:"type:text"` // stderr or error details
 
+	// Score is the fraction of the test's points a custom checker awarded;
+	// nil means all or nothing by verdict.
+	Score *float64
+
 	CreatedAt time.Time `gorm:"autoCreateTime"`
 
 	// Relationships
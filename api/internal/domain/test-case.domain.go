@@ -8,14 +8,15 @@ import (
 )
 
 type TestCase struct {
-	ID             uuid.UUID `gorm:"primaryKey;type:uuid"`
-	ProblemID      uuid.UUID `gorm:"not null;index;type:uuid"`
-	Input          string    `gorm:"type:text;not null"`
-	ExpectedOutput string    `gorm:"type:text;not null"`
-	IsSample       bool      `gorm:"default:false"`
-	IsHidden       bool      `gorm:"default:true"`
-	Points         int       `gorm:"default:10"`
-	OrderIndex     int       `gorm:"not null"`
+	ID             uuid.UUID  `gorm:"primaryKey;type:uuid"`
+	ProblemID      uuid.UUID  `gorm:"not null;index;type:uuid"`
+	Input          string     `gorm:"type:text;not null"`
+	ExpectedOutput string     `gorm:"type:text;not null"`
+	IsSample       bool       `gorm:"default:false"`
+	IsHidden       bool       `gorm:"default:true"`
+	Points         int        `gorm:"default:10"`
+	OrderIndex     int        `gorm:"not null"`
+	GroupID        *uuid.UUID `gorm:"type:uuid;index"` // subtask; nil on problems without test groups
 
 	CreatedAt time.Time `gorm:"autoCreateTime"`
 	UpdatedAt time.Time `gorm:"autoUpdateTime"`
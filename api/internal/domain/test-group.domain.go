@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TestGroup is a subtask: a set of a problem's test cases scored together.
+// A group only scores if every group it depends on was solved in full.
+type TestGroup struct {
+	ID          uuid.UUID `gorm:"primaryKey;type:uuid"`
+	ProblemID   uuid.UUID `gorm:"not null;index;type:uuid"`
+	Name        string    `gorm:"not null"`
+	Points      int       `gorm:"not null"`
+	ScoringRule string    `gorm:"default:'all'"` // all, min
+	OrderIndex  int       `gorm:"not null"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+
+	// Relationships
+	Dependencies []TestGroupDependency `gorm:"foreignKey:GroupID"`
+}
+
+// TestGroupDependency records that GroupID only scores once DependsOnID is
+// solved in full.
+type TestGroupDependency struct {
+	GroupID     uuid.UUID `gorm:"primaryKey;type:uuid"`
+	DependsOnID uuid.UUID `gorm:"primaryKey;type:uuid;index"`
+}
+
+func (g *TestGroup) BeforeCreate(tx *gorm.DB) (err error) {
+	if g.ID == uuid.Nil {
+		g.ID, err = uuid.NewV7()
+	}
+	return
+}
+
+// DependsOn returns the IDs of the groups this group depends on.
+func (g *TestGroup) DependsOn() []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(g.Dependencies))
+	for _, d := range g.Dependencies {
+		ids = append(ids, d.DependsOnID)
+	}
+	return ids
+}
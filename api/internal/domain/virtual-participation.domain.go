@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// VirtualParticipation is a user's replay of a finished contest, running for
+// the contest's original duration from the moment they started it.
+type VirtualParticipation struct {
+	ID        uuid.UUID `gorm:"primaryKey;type:uuid"`
+	ContestID uuid.UUID `gorm:"not null;type:uuid;uniqueIndex:idx_virtual_participation"`
+	UserID    uuid.UUID `gorm:"not null;type:uuid;index;uniqueIndex:idx_virtual_participation"`
+	StartedAt time.Time `gorm:"not null"`
+	EndsAt    time.Time `gorm:"not null;index"`
+
+	// Relationships
+	Contest Contest `gorm:"foreignKey:ContestID"`
+	User    User    `gorm:"foreignKey:UserID"`
+}
+
+func (vp *VirtualParticipation) BeforeCreate(tx *gorm.DB) (err error) {
+	if vp.ID == uuid.Nil {
+		vp.ID, err = uuid.NewV7()
+	}
+	return
+}
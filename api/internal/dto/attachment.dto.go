@@ -0,0 +1,37 @@
+package dto
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+)
+
+type AttachmentResponse struct {
+	ID          uuid.UUID `json:"id"`
+	ProblemID   uuid.UUID `json:"problem_id"`
+	FileName    string    `json:"file_name"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	URL         string    `json:"url"` // stable link to use in problem descriptions
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// AttachmentURL is the public download path of an attachment. It only depends
+// on the attachment itself, so it survives problem renames.
+func AttachmentURL(a *domain.ProblemAttachment) string {
+	return "/api/v1/attachments/" + a.ID.String() + "/" + url.PathEscape(a.FileName)
+}
+
+func AttachmentResponseFromDomain(a *domain.ProblemAttachment) AttachmentResponse {
+	return AttachmentResponse{
+		ID:          a.ID,
+		ProblemID:   a.ProblemID,
+		FileName:    a.FileName,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		URL:         AttachmentURL(a),
+		CreatedAt:   a.CreatedAt,
+	}
+}
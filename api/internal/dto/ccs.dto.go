@@ -0,0 +1,108 @@
+package dto
+
+// Objects of the ICPC CCS Contest API (2023-06). Times are ISO 8601 strings
+// and contest-relative times use the RELTIME format (h:mm:ss.uuu).
+
+type CCSContest struct {
+	ID                       string  `json:"id"`
+	Name                     string  `json:"name"`
+	FormalName               string  `json:"formal_name"`
+	StartTime                *string `json:"start_time"`
+	Duration                 string  `json:"duration"`
+	ScoreboardFreezeDuration *string `json:"scoreboard_freeze_duration"`
+	ScoreboardType           string  `json:"scoreboard_type"` // pass-fail or score
+	PenaltyTime              int     `json:"penalty_time"`    // minutes
+}
+
+type CCSState struct {
+	Started      *string `json:"started"`
+	Frozen       *string `json:"frozen"`
+	Ended        *string `json:"ended"`
+	Thawed       *string `json:"thawed"`
+	Finalized    *string `json:"finalized"`
+	EndOfUpdates *string `json:"end_of_updates"`
+}
+
+type CCSJudgementType struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Penalty bool   `json:"penalty"`
+	Solved  bool   `json:"solved"`
+}
+
+type CCSLanguage struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type CCSProblem struct {
+	ID        string  `json:"id"`
+	Label     string  `json:"label"`
+	Name      string  `json:"name"`
+	Ordinal   int     `json:"ordinal"`
+	TimeLimit float64 `json:"time_limit"` // seconds
+}
+
+type CCSTeam struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+}
+
+type CCSSubmission struct {
+	ID          string `json:"id"`
+	LanguageID  string `json:"language_id"`
+	ProblemID   string `json:"problem_id"`
+	TeamID      string `json:"team_id"`
+	Time        string `json:"time"`
+	ContestTime string `json:"contest_time"`
+}
+
+type CCSJudgement struct {
+	ID               string   `json:"id"`
+	SubmissionID     string   `json:"submission_id"`
+	JudgementTypeID  *string  `json:"judgement_type_id"`
+	StartTime        string   `json:"start_time"`
+	StartContestTime string   `json:"start_contest_time"`
+	EndTime          *string  `json:"end_time"`
+	EndContestTime   *string  `json:"end_contest_time"`
+	MaxRunTime       *float64 `json:"max_run_time"` // seconds
+}
+
+type CCSScoreboard struct {
+	Time        string             `json:"time"`
+	ContestTime string             `json:"contest_time"`
+	State       CCSState           `json:"state"`
+	Rows        []CCSScoreboardRow `json:"rows"`
+}
+
+type CCSScoreboardRow struct {
+	Rank     int                    `json:"rank"`
+	TeamID   string                 `json:"team_id"`
+	Score    CCSScore               `json:"score"`
+	Problems []CCSScoreboardProblem `json:"problems"`
+}
+
+type CCSScore struct {
+	NumSolved int      `json:"num_solved"`
+	TotalTime int      `json:"total_time"` // minutes
+	Score     *float64 `json:"score,omitempty"`
+}
+
+type CCSScoreboardProblem struct {
+	ProblemID    string   `json:"problem_id"`
+	NumJudged    int      `json:"num_judged"`
+	NumPending   int      `json:"num_pending"`
+	Solved       bool     `json:"solved"`
+	Time         *int     `json:"time,omitempty"` // minutes
+	FirstToSolve bool     `json:"first_to_solve,omitempty"`
+	Score        *float64 `json:"score,omitempty"`
+}
+
+// CCSEvent is one line of the NDJSON event feed.
+type CCSEvent struct {
+	Type  string      `json:"type"`
+	ID    *string     `json:"id"`
+	Data  interface{} `json:"data"`
+	Token string      `json:"token"`
+}
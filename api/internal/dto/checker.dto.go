@@ -0,0 +1,31 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+)
+
+type SetCheckerRequest struct {
+	Language string `json:"language" binding:"required"`
+	Code     string `json:"code" binding:"required"`
+}
+
+type CheckerResponse struct {
+	ID        uuid.UUID `json:"id"`
+	ProblemID uuid.UUID `json:"problem_id"`
+	Language  string    `json:"language"`
+	Code      string    `json:"code"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func CheckerResponseFromDomain(c *domain.ProblemChecker) *CheckerResponse {
+	return &CheckerResponse{
+		ID:        c.ID,
+		ProblemID: c.ProblemID,
+		Language:  c.Language,
+		Code:      c.Code,
+		UpdatedAt: c.UpdatedAt,
+	}
+}
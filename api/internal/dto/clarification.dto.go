@@ -0,0 +1,57 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+)
+
+type AskClarificationRequest struct {
+	ProblemLabel string `json:"problem_label"` // empty for general questions
+	Question     string `json:"question" binding:"required"`
+}
+
+type AnswerClarificationRequest struct {
+	Answer    string `json:"answer" binding:"required"`
+	Broadcast bool   `json:"broadcast"` // send the answer to every participant
+}
+
+type ClarificationResponse struct {
+	ID           uuid.UUID  `json:"id"`
+	ContestID    uuid.UUID  `json:"contest_id"`
+	ProblemLabel string     `json:"problem_label,omitempty"`
+	Question     string     `json:"question"`
+	Answer       string     `json:"answer,omitempty"`
+	IsBroadcast  bool       `json:"is_broadcast"`
+	AskedBy      *uuid.UUID `json:"asked_by,omitempty"`
+	AskerName    string     `json:"asker_username,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	AnsweredAt   *time.Time `json:"answered_at,omitempty"`
+}
+
+type ClarificationListResponse struct {
+	Clarifications []ClarificationResponse `json:"clarifications"`
+}
+
+// ClarificationResponseFromDomain builds a response. The asker is only
+// disclosed to judges and to the asker themselves.
+func ClarificationResponseFromDomain(c *domain.Clarification, showAsker bool) *ClarificationResponse {
+	resp := &ClarificationResponse{
+		ID:          c.ID,
+		ContestID:   c.ContestID,
+		Question:    c.Question,
+		Answer:      c.Answer,
+		IsBroadcast: c.IsBroadcast,
+		CreatedAt:   c.CreatedAt,
+		AnsweredAt:  c.AnsweredAt,
+	}
+	if c.ContestProblem != nil {
+		resp.ProblemLabel = c.ContestProblem.Label
+	}
+	if showAsker {
+		resp.AskedBy = &c.AskedBy
+		resp.AskerName = c.Asker.Username
+	}
+	return resp
+}
@@ -0,0 +1,35 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+)
+
+type AddCollaboratorRequest struct {
+	Username string `json:"username" binding:"required"`
+	Role     string `json:"role" binding:"required,oneof=owner editor tester"`
+}
+
+type UpdateCollaboratorRequest struct {
+	Role string `json:"role" binding:"required,oneof=owner editor tester"`
+}
+
+type CollaboratorDTO struct {
+	UserID   uuid.UUID `json:"user_id"`
+	Username string    `json:"username"`
+	FullName string    `json:"full_name"`
+	Role     string    `json:"role"`
+	AddedAt  time.Time `json:"added_at"`
+}
+
+func CollaboratorDTOFromDomain(pc *domain.ProblemCollaborator) CollaboratorDTO {
+	return CollaboratorDTO{
+		UserID:   pc.UserID,
+		Username: pc.User.Username,
+		FullName: pc.User.FullName,
+		Role:     pc.Role,
+		AddedAt:  pc.AddedAt,
+	}
+}
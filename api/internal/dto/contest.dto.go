@@ -0,0 +1,199 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+)
+
+type CreateContestRequest struct {
+	Title       string    `json:"title" binding:"required"`
+	Description string    `json:"description"`
+	StartTime   time.Time `json:"start_time" binding:"required"`
+	EndTime     time.Time `json:"end_time" binding:"required"`
+	IsPublic    *bool     `json:"is_public"`
+	Password    string    `json:"password"` // optional entry password for private contests
+	IsRated     bool      `json:"is_rated"`
+	MaxTeamSize int       `json:"max_team_size" binding:"min=0"` // 0: individual contest
+	// PublicCodeAfterEnd lets everyone read contest submissions once the contest is over.
+	PublicCodeAfterEnd bool   `json:"public_code_after_end"`
+	ScoringMode        string `json:"scoring_mode" binding:"omitempty,oneof=icpc ioi"`
+	// FreezeMinutes freezes the public scoreboard this many minutes before the end.
+	FreezeMinutes int `json:"freeze_minutes" binding:"min=0"`
+}
+
+type UpdateContestRequest struct {
+	Title              string     `json:"title"`
+	Description        string     `json:"description"`
+	StartTime          *time.Time `json:"start_time"`
+	EndTime            *time.Time `json:"end_time"`
+	IsPublic           *bool      `json:"is_public"`
+	Password           *string    `json:"password"` // empty string removes the password
+	IsRated            *bool      `json:"is_rated"`
+	MaxTeamSize        *int       `json:"max_team_size" binding:"omitempty,min=0"`
+	PublicCodeAfterEnd *bool      `json:"public_code_after_end"`
+	ScoringMode        string     `json:"scoring_mode" binding:"omitempty,oneof=icpc ioi"`
+	FreezeMinutes      *int       `json:"freeze_minutes" binding:"omitempty,min=0"`
+}
+
+type ContestResponse struct {
+	ID                 uuid.UUID `json:"id"`
+	Title              string    `json:"title"`
+	Description        string    `json:"description"`
+	StartTime          time.Time `json:"start_time"`
+	EndTime            time.Time `json:"end_time"`
+	Status             string    `json:"status"`
+	IsPublic           bool      `json:"is_public"`
+	HasPassword        bool      `json:"has_password"`
+	IsRated            bool      `json:"is_rated"`
+	RatingsDone        bool      `json:"ratings_applied"`
+	MaxTeamSize        int       `json:"max_team_size"`
+	PublicCodeAfterEnd bool      `json:"public_code_after_end"`
+	ScoringMode        string    `json:"scoring_mode"`
+	FreezeMinutes      int       `json:"freeze_minutes"`
+	Frozen             bool      `json:"frozen"`
+	CreatedBy          uuid.UUID `json:"created_by"`
+	CreatedAt          time.Time `json:"created_at"`
+}
+
+type ContestListResponse struct {
+	Contests []ContestResponse `json:"contests"`
+	Total    int64             `json:"total"`
+	Page     int               `json:"page"`
+	Limit    int               `json:"limit"`
+}
+
+type ContestFilters struct {
+	Status string `form:"status"`
+}
+
+func ParseContestFilters(c *gin.Context) *ContestFilters {
+	return &ContestFilters{
+		Status: c.Query("status"),
+	}
+}
+
+func ContestResponseFromDomain(contest *domain.Contest) *ContestResponse {
+	return &ContestResponse{
+		ID:                 contest.ID,
+		Title:              contest.Title,
+		Description:        contest.Description,
+		StartTime:          contest.StartTime,
+		EndTime:            contest.EndTime,
+		Status:             contest.Status(time.Now()),
+		IsPublic:           contest.IsPublic,
+		HasPassword:        contest.PasswordHash != "",
+		IsRated:            contest.IsRated,
+		RatingsDone:        contest.RatingsApplied,
+		MaxTeamSize:        contest.MaxTeamSize,
+		PublicCodeAfterEnd: contest.PublicCodeAfterEnd,
+		ScoringMode:        contest.ScoringMode,
+		FreezeMinutes:      contest.FreezeMinutes,
+		Frozen:             contest.IsFrozen(time.Now()),
+		CreatedBy:          contest.CreatedBy,
+		CreatedAt:          contest.CreatedAt,
+	}
+}
+
+type AddContestProblemRequest struct {
+	ProblemSlug string `json:"problem_slug" binding:"required"`
+	Label       string `json:"label"`
+	Points      int    `json:"points"`
+}
+
+type UpdateContestProblemRequest struct {
+	Label  string `json:"label"`
+	Points int    `json:"points"`
+}
+
+type ContestProblemDTO struct {
+	Label      string    `json:"label"`
+	Points     int       `json:"points"`
+	ProblemID  uuid.UUID `json:"problem_id"`
+	Slug       string    `json:"slug"`
+	Title      string    `json:"title"`
+	Difficulty string    `json:"difficulty"`
+}
+
+func ContestProblemDTOFromDomain(cp *domain.ContestProblem) *ContestProblemDTO {
+	return &ContestProblemDTO{
+		Label:      cp.Label,
+		Points:     cp.Points,
+		ProblemID:  cp.ProblemID,
+		Slug:       cp.Problem.Slug,
+		Title:      cp.Problem.Title,
+		Difficulty: cp.Problem.Difficulty,
+	}
+}
+
+type ContestParticipantDTO struct {
+	UserID       uuid.UUID  `json:"user_id"`
+	Username     string     `json:"username"`
+	FullName     string     `json:"full_name"`
+	Email        string     `json:"email"`
+	TeamID       *uuid.UUID `json:"team_id,omitempty"`
+	TeamName     string     `json:"team_name,omitempty"`
+	RegisteredAt time.Time  `json:"registered_at"`
+}
+
+type ContestRosterResponse struct {
+	Participants []ContestParticipantDTO `json:"participants"`
+	Total        int                     `json:"total"`
+}
+
+type VirtualParticipationResponse struct {
+	ContestID uuid.UUID `json:"contest_id"`
+	StartedAt time.Time `json:"started_at"`
+	EndsAt    time.Time `json:"ends_at"`
+}
+
+// RegisterContestRequest carries the credentials needed to enter a private
+// contest. Public contests need neither.
+type RegisterContestRequest struct {
+	InviteCode string `json:"invite_code"`
+	Password   string `json:"password"`
+}
+
+type JoinContestRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type CreateContestInviteRequest struct {
+	MaxUses   int        `json:"max_uses" binding:"min=0"` // 0: unlimited
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+type ContestInviteDTO struct {
+	ID        uuid.UUID  `json:"id"`
+	Code      string     `json:"code"`
+	MaxUses   int        `json:"max_uses"`
+	Uses      int        `json:"uses"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Usable    bool       `json:"usable"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func ContestInviteDTOFromDomain(invite *domain.ContestInvite) *ContestInviteDTO {
+	return &ContestInviteDTO{
+		ID:        invite.ID,
+		Code:      invite.Code,
+		MaxUses:   invite.MaxUses,
+		Uses:      invite.Uses,
+		ExpiresAt: invite.ExpiresAt,
+		Usable:    invite.Usable(time.Now()),
+		CreatedAt: invite.CreatedAt,
+	}
+}
+
+type AddAllowedUserRequest struct {
+	Username string `json:"username" binding:"required"`
+}
+
+type ContestAllowedUserDTO struct {
+	UserID   uuid.UUID `json:"user_id"`
+	Username string    `json:"username"`
+	FullName string    `json:"full_name"`
+	AddedAt  time.Time `json:"added_at"`
+}
@@ -0,0 +1,49 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+)
+
+type EditorialRequest struct {
+	Content   string                 `json:"content" binding:"required"` // Markdown
+	RevealAt  *time.Time             `json:"reveal_at"`                  // unlocks the editorial for everyone
+	Solutions []EditorialSolutionDTO `json:"solutions" binding:"dive"`
+}
+
+type EditorialSolutionDTO struct {
+	Language string `json:"language" binding:"required"`
+	Code     string `json:"code" binding:"required"`
+}
+
+type EditorialResponse struct {
+	ProblemID uuid.UUID              `json:"problem_id"`
+	Content   string                 `json:"content"`
+	RevealAt  *time.Time             `json:"reveal_at,omitempty"`
+	Solutions []EditorialSolutionDTO `json:"solutions"`
+	AuthorID  uuid.UUID              `json:"author_id"`
+	CreatedAt time.Time              `json:"created_at"`
+	UpdatedAt time.Time              `json:"updated_at"`
+}
+
+func EditorialResponseFromDomain(e *domain.ProblemEditorial) *EditorialResponse {
+	solutions := make([]EditorialSolutionDTO, 0, len(e.Solutions))
+	for _, s := range e.Solutions {
+		solutions = append(solutions, EditorialSolutionDTO{
+			Language: s.Language,
+			Code:     s.Code,
+		})
+	}
+
+	return &EditorialResponse{
+		ProblemID: e.ProblemID,
+		Content:   e.Content,
+		RevealAt:  e.RevealAt,
+		Solutions: solutions,
+		AuthorID:  e.AuthorID,
+		CreatedAt: e.CreatedAt,
+		UpdatedAt: e.UpdatedAt,
+	}
+}
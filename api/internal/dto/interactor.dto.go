@@ -0,0 +1,31 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+)
+
+type SetInteractorRequest struct {
+	Language string `json:"language" binding:"required"`
+	Code     string `json:"code" binding:"required"`
+}
+
+type InteractorResponse struct {
+	ID        uuid.UUID `json:"id"`
+	ProblemID uuid.UUID `json:"problem_id"`
+	Language  string    `json:"language"`
+	Code      string    `json:"code"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func InteractorResponseFromDomain(i *domain.ProblemInteractor) *InteractorResponse {
+	return &InteractorResponse{
+		ID:        i.ID,
+		ProblemID: i.ProblemID,
+		Language:  i.Language,
+		Code:      i.Code,
+		UpdatedAt: i.UpdatedAt,
+	}
+}
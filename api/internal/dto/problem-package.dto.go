@@ -0,0 +1,67 @@
+package dto
+
+// ProblemPackageFormat is the manifest format version written by exports.
+const ProblemPackageFormat = 1
+
+// ProblemPackageManifest is the problem.json at the root of a problem package.
+// The statement description lives next to it in statement.md and every test
+// case in tests/NN.in and tests/NN.out; a custom checker is stored as
+// checker/<file> and an interactor as interactor/<file>.
+type ProblemPackageManifest struct {
+	Format        int                  `json:"format"`
+	Title         string               `json:"title"`
+	InputFormat   string               `json:"input_format,omitempty"`
+	OutputFormat  string               `json:"output_format,omitempty"`
+	Notes         string               `json:"notes,omitempty"`
+	DefaultLocale string               `json:"default_locale,omitempty"`
+	Slug          string               `json:"slug"`
+	Difficulty    string               `json:"difficulty"`
+	TimeLimit     int                  `json:"time_limit"`
+	MemoryLimit   int                  `json:"memory_limit"`
+	ScoringMode   string               `json:"scoring_mode"`
+	Type          string               `json:"type"`
+	Tags          []string             `json:"tags"`
+	Tests         []ProblemPackageTest `json:"tests"`
+	// Groups are the problem's subtasks; tests refer to them by name.
+	Groups []ProblemPackageGroup `json:"groups,omitempty"`
+	// Checker is set for problems judged by a custom checker.
+	Checker *ProblemPackageProgram `json:"checker,omitempty"`
+	// Interactor is set for interactive problems.
+	Interactor *ProblemPackageProgram `json:"interactor,omitempty"`
+	// Translations holds the statement in locales other than DefaultLocale.
+	Translations []ProblemPackageTranslation `json:"translations,omitempty"`
+}
+
+// ProblemPackageProgram names the source file of a checker or interactor in
+// the package.
+type ProblemPackageProgram struct {
+	Language string `json:"language"`
+	File     string `json:"file"`
+}
+
+// ProblemPackageTranslation is the statement of the problem in another locale.
+type ProblemPackageTranslation struct {
+	Locale       string `json:"locale"`
+	Title        string `json:"title"`
+	Description  string `json:"description"`
+	InputFormat  string `json:"input_format,omitempty"`
+	OutputFormat string `json:"output_format,omitempty"`
+	Notes        string `json:"notes,omitempty"`
+}
+
+// ProblemPackageTest describes one test case; Name is the NN of its files.
+type ProblemPackageTest struct {
+	Name     string `json:"name"`
+	IsSample bool   `json:"is_sample"`
+	IsHidden bool   `json:"is_hidden"`
+	Points   int    `json:"points"`
+	Group    string `json:"group,omitempty"`
+}
+
+// ProblemPackageGroup describes one test group; DependsOn holds group names.
+type ProblemPackageGroup struct {
+	Name        string   `json:"name"`
+	Points      int      `json:"points"`
+	ScoringRule string   `json:"scoring_rule"`
+	DependsOn   []string `json:"depends_on,omitempty"`
+}
@@ -0,0 +1,119 @@
+package dto
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+)
+
+// ProblemRevisionSummaryDTO is a revision as listed in a problem's history.
+type ProblemRevisionSummaryDTO struct {
+	Number       int       `json:"number"`
+	Change       string    `json:"change"`
+	RolledBackTo *int      `json:"rolled_back_to,omitempty"`
+	Title        string    `json:"title"`
+	TestSetHash  string    `json:"test_set_hash"`
+	EditedBy     uuid.UUID `json:"edited_by"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// ProblemRevisionDTO is a revision with its full snapshot.
+type ProblemRevisionDTO struct {
+	Number       int           `json:"number"`
+	Change       string        `json:"change"`
+	RolledBackTo *int          `json:"rolled_back_to,omitempty"`
+	Title        string        `json:"title"`
+	Description  string        `json:"description"`
+	InputFormat  string        `json:"input_format"`
+	OutputFormat string        `json:"output_format"`
+	Notes        string        `json:"notes"`
+	Difficulty   string        `json:"difficulty"`
+	TimeLimit    int           `json:"time_limit"`
+	MemoryLimit  int           `json:"memory_limit"`
+	ScoringMode  string        `json:"scoring_mode"`
+	Type         string        `json:"type"`
+	Tags         []string      `json:"tags"`
+	TestSetHash  string        `json:"test_set_hash"`
+	TestCases    []TestCaseDTO `json:"test_cases"`
+	EditedBy     uuid.UUID     `json:"edited_by"`
+	CreatedAt    time.Time     `json:"created_at"`
+}
+
+// RevisionFieldChange is a problem field that differs between two revisions.
+type RevisionFieldChange struct {
+	Field string `json:"field"`
+	From  any    `json:"from"`
+	To    any    `json:"to"`
+}
+
+// StatementDiffLine is one line of a statement diff.
+type StatementDiffLine struct {
+	Op   string `json:"op"` // keep, add, remove
+	Text string `json:"text"`
+}
+
+// ProblemRevisionDiffResponse describes what changed from one revision to another.
+type ProblemRevisionDiffResponse struct {
+	From           int                   `json:"from"`
+	To             int                   `json:"to"`
+	Fields         []RevisionFieldChange `json:"fields"`
+	Statement      []StatementDiffLine   `json:"statement,omitempty"` // only when the statement changed
+	TestSetChanged bool                  `json:"test_set_changed"`
+	TestsAdded     []uuid.UUID           `json:"tests_added"`
+	TestsRemoved   []uuid.UUID           `json:"tests_removed"`
+	TestsChanged   []uuid.UUID           `json:"tests_changed"`
+}
+
+func ProblemRevisionSummaryDTOFromDomain(r *domain.ProblemRevision) ProblemRevisionSummaryDTO {
+	return ProblemRevisionSummaryDTO{
+		Number:       r.Number,
+		Change:       r.Change,
+		RolledBackTo: r.RolledBackTo,
+		Title:        r.Title,
+		TestSetHash:  r.TestSetHash,
+		EditedBy:     r.EditedBy,
+		CreatedAt:    r.CreatedAt,
+	}
+}
+
+func ProblemRevisionDTOFromDomain(r *domain.ProblemRevision, tests []domain.TestCaseSnapshot) *ProblemRevisionDTO {
+	tags := []string{}
+	if r.Tags != "" {
+		tags = strings.Split(r.Tags, ",")
+	}
+
+	testCases := make([]TestCaseDTO, 0, len(tests))
+	for _, tc := range tests {
+		testCases = append(testCases, TestCaseDTO{
+			ID:             tc.ID,
+			Input:          tc.Input,
+			ExpectedOutput: tc.ExpectedOutput,
+			IsSample:       tc.IsSample,
+			Points:         tc.Points,
+			GroupID:        tc.GroupID,
+		})
+	}
+
+	return &ProblemRevisionDTO{
+		Number:       r.Number,
+		Change:       r.Change,
+		RolledBackTo: r.RolledBackTo,
+		Title:        r.Title,
+		Description:  r.Description,
+		InputFormat:  r.InputFormat,
+		OutputFormat: r.OutputFormat,
+		Notes:        r.Notes,
+		Difficulty:   r.Difficulty,
+		TimeLimit:    r.TimeLimit,
+		MemoryLimit:  r.MemoryLimit,
+		ScoringMode:  r.ScoringMode,
+		Type:         r.Type,
+		Tags:         tags,
+		TestSetHash:  r.TestSetHash,
+		TestCases:    testCases,
+		EditedBy:     r.EditedBy,
+		CreatedAt:    r.CreatedAt,
+	}
+}
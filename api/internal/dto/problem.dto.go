@@ -3,6 +3,7 @@ package dto
 import (
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -10,36 +11,65 @@ import (
 )
 
 type CreateProblemRequest struct {
-	Title       string        `json:"title" binding:"required"`
-	Description string        `json:"description" binding:"required"`
-	Difficulty  string        `json:"difficulty" binding:"required"`
-	TimeLimit   int           `json:"time_limit" binding:"required"`
-	MemoryLimit int           `json:"memory_limit" binding:"required"`
-	Tags        []string      `json:"tags"`
-	TestCases   []TestCaseDTO `json:"test_cases"`
+	Title         string        `json:"title" binding:"required"`
+	Description   string        `json:"description" binding:"required"`
+	InputFormat   string        `json:"input_format"`
+	OutputFormat  string        `json:"output_format"`
+	Notes         string        `json:"notes"`
+	DefaultLocale string        `json:"default_locale"` // locale of the statement above, "en" if empty
+	Difficulty    string        `json:"difficulty" binding:"required"`
+	TimeLimit     int           `json:"time_limit" binding:"required"`
+	MemoryLimit   int           `json:"memory_limit" binding:"required"`
+	ScoringMode   string        `json:"scoring_mode" binding:"omitempty,oneof=icpc ioi"`
+	Type          string        `json:"type" binding:"omitempty,oneof=standard interactive"`
+	Tags          []string      `json:"tags"`
+	TestCases     []TestCaseDTO `json:"test_cases"`
+	PublishAt     *time.Time    `json:"publish_at"` // schedules publication; problems start as drafts
+}
+
+type ProblemStatusRequest struct {
+	Status    string     `json:"status" binding:"required,oneof=draft review published archived"`
+	PublishAt *time.Time `json:"publish_at"` // with draft or review, publish automatically at this time
 }
 
 type UpdateProblemRequest struct {
-	Title       string   `json:"title"`
-	Description string   `json:"description"`
-	Difficulty  string   `json:"difficulty"`
-	TimeLimit   int      `json:"time_limit"`
-	MemoryLimit int      `json:"memory_limit"`
-	Tags        []string `json:"tags"`
+	Title         string   `json:"title"`
+	Description   string   `json:"description"`
+	InputFormat   string   `json:"input_format"`
+	OutputFormat  string   `json:"output_format"`
+	Notes         string   `json:"notes"`
+	DefaultLocale string   `json:"default_locale"`
+	Difficulty    string   `json:"difficulty"`
+	TimeLimit     int      `json:"time_limit"`
+	MemoryLimit   int      `json:"memory_limit"`
+	ScoringMode   string   `json:"scoring_mode" binding:"omitempty,oneof=icpc ioi"`
+	Type          string   `json:"type" binding:"omitempty,oneof=standard interactive"`
+	Tags          []string `json:"tags"`
 }
 
 type ProblemResponse struct {
-	ID              uuid.UUID     `json:"id"`
-	Title           string        `json:"title"`
-	Slug            string        `json:"slug"`
-	Description     string        `json:"description"`
-	Difficulty      string        `json:"difficulty"`
-	TimeLimit       int           `json:"time_limit"`
-	MemoryLimit     int           `json:"memory_limit"`
-	Tags            []string      `json:"tags"`
-	AcceptedCount   int           `json:"accepted_count"`
-	SubmissionCount int           `json:"submission_count"`
-	TestCases       []TestCaseDTO `json:"test_cases"`
+	ID               uuid.UUID     `json:"id"`
+	Title            string        `json:"title"`
+	Slug             string        `json:"slug"`
+	Description      string        `json:"description"`
+	InputFormat      string        `json:"input_format"`
+	OutputFormat     string        `json:"output_format"`
+	Notes            string        `json:"notes"`
+	Locale           string        `json:"locale"` // locale of the statement fields above
+	AvailableLocales []string      `json:"available_locales"`
+	Difficulty       string        `json:"difficulty"`
+	TimeLimit        int           `json:"time_limit"`
+	MemoryLimit      int           `json:"memory_limit"`
+	ScoringMode      string        `json:"scoring_mode"`
+	Type             string        `json:"type"`
+	Tags             []string      `json:"tags"`
+	AcceptedCount    int           `json:"accepted_count"`
+	SubmissionCount  int           `json:"submission_count"`
+	Revision         int           `json:"revision"`
+	Status           string        `json:"status"`
+	PublishAt        *time.Time    `json:"publish_at,omitempty"`
+	PublishedAt      *time.Time    `json:"published_at,omitempty"`
+	TestCases        []TestCaseDTO `json:"test_cases"`
 }
 
 type ProblemSummaryDTO struct {
@@ -50,6 +80,15 @@ type ProblemSummaryDTO struct {
 	Tags            []string  `json:"tags"`
 	AcceptedCount   int       `json:"accepted_count"`
 	SubmissionCount int       `json:"submission_count"`
+	Status          string    `json:"status"`
+	// Snippet is an HTML-escaped statement excerpt with search matches in
+	// <mark>, set when searching with q= and the statement itself matched.
+	Snippet string `json:"snippet,omitempty"`
+}
+
+type ProblemSuggestionDTO struct {
+	Title string `json:"title"`
+	Slug  string `json:"slug"`
 }
 
 type ProblemListResponse struct {
@@ -60,19 +99,21 @@ type ProblemListResponse struct {
 }
 
 type TestCaseRequest struct {
-	Input          string `json:"input" binding:"required"`
-	ExpectedOutput string `json:"expected_output" binding:"required"`
-	IsSample       bool   `json:"is_sample"`
-	Points         int    `json:"points"`
-	OrderIndex     int    `json:"order_index"`
+	Input          string     `json:"input" binding:"required"`
+	ExpectedOutput string     `json:"expected_output"` // required unless the problem is interactive
+	IsSample       bool       `json:"is_sample"`
+	Points         int        `json:"points"`
+	OrderIndex     int        `json:"order_index"`
+	GroupID        *uuid.UUID `json:"group_id"` // test group (subtask) of the problem
 }
 
 type TestCaseDTO struct {
-	ID             uuid.UUID `json:"id"`
-	Input          string    `json:"input"`
-	ExpectedOutput string    `json:"expected_output"`
-	IsSample       bool      `json:"is_sample"`
-	Points         int       `json:"points"`
+	ID             uuid.UUID  `json:"id"`
+	Input          string     `json:"input"`
+	ExpectedOutput string     `json:"expected_output"`
+	IsSample       bool       `json:"is_sample"`
+	Points         int        `json:"points"`
+	GroupID        *uuid.UUID `json:"group_id,omitempty"`
 }
 
 type PaginationRequest struct {
@@ -81,8 +122,11 @@ type PaginationRequest struct {
 }
 
 type ProblemFilters struct {
-	Difficulty string `form:"difficulty"`
-	Tags       string `form:"tags"`
+	Difficulty   string   `form:"difficulty"`
+	Tags         []string `form:"tags"`      // comma-separated in the query
+	MatchAllTags bool     `form:"tag_match"` // tag_match=all; any tag matches by default
+	Query        string   `form:"q"`         // full-text search
+	Status       string   `form:"status"`
 }
 
 func ParsePagination(c *gin.Context) *PaginationRequest {
@@ -95,31 +139,48 @@ func ParsePagination(c *gin.Context) *PaginationRequest {
 }
 
 func ParseProblemFilters(c *gin.Context) *ProblemFilters {
-	return &ProblemFilters{
-		Difficulty: c.Query("difficulty"),
-		Tags:       c.Query("tags"),
+	filters := &ProblemFilters{
+		Difficulty:   c.Query("difficulty"),
+		MatchAllTags: c.Query("tag_match") == "all",
+		Query:        strings.TrimSpace(c.Query("q")),
+		Status:       c.Query("status"),
+	}
+	for _, name := range strings.Split(c.Query("tags"), ",") {
+		if name = domain.NormalizeTagName(name); name != "" {
+			filters.Tags = append(filters.Tags, name)
+		}
 	}
+	return filters
 }
 
 func ProblemResponseFromDomain(p *domain.Problem) *ProblemResponse {
-	var tags []string
-	if p.Tags != "" {
-		tags = strings.Split(p.Tags, ",")
-	} else {
-		tags = []string{}
+	locales := []string{p.DefaultLocale}
+	for _, st := range p.Statements {
+		locales = append(locales, st.Locale)
 	}
 
 	return &ProblemResponse{
-		ID:              p.ID,
-		Title:           p.Title,
-		Slug:            p.Slug,
-		Description:     p.Description,
-		Difficulty:      p.Difficulty,
-		TimeLimit:       p.TimeLimit,
-		MemoryLimit:     p.MemoryLimit,
-		Tags:            tags,
-		AcceptedCount:   p.AcceptedCount,
-		SubmissionCount: p.SubmissionCount,
-		TestCases:       []TestCaseDTO{}, // Test cases are usually fetched separately or need more context
+		ID:               p.ID,
+		Title:            p.Title,
+		Slug:             p.Slug,
+		Description:      p.Description,
+		InputFormat:      p.InputFormat,
+		OutputFormat:     p.OutputFormat,
+		Notes:            p.Notes,
+		Locale:           p.DefaultLocale,
+		AvailableLocales: locales,
+		Difficulty:       p.Difficulty,
+		TimeLimit:        p.TimeLimit,
+		MemoryLimit:      p.MemoryLimit,
+		ScoringMode:      p.ScoringMode,
+		Type:             p.Type,
+		Tags:             p.TagNames(),
+		AcceptedCount:    p.AcceptedCount,
+		SubmissionCount:  p.SubmissionCount,
+		Revision:         p.Revision,
+		Status:           p.Status,
+		PublishAt:        p.PublishAt,
+		PublishedAt:      p.PublishedAt,
+		TestCases:        []TestCaseDTO{}, // Test cases are usually fetched separately or need more context
 	}
 }
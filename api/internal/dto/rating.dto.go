@@ -0,0 +1,25 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RatingChangeDTO is one point of a user's rating graph.
+type RatingChangeDTO struct {
+	ContestID    uuid.UUID `json:"contest_id"`
+	ContestTitle string    `json:"contest_title"`
+	Rank         int       `json:"rank"`
+	OldRating    int       `json:"old_rating"`
+	NewRating    int       `json:"new_rating"`
+	Delta        int       `json:"delta"`
+	RatedAt      time.Time `json:"rated_at"` // contest end time
+}
+
+type RatingHistoryResponse struct {
+	UserID   uuid.UUID         `json:"user_id"`
+	Username string            `json:"username"`
+	Rating   int               `json:"rating"`
+	History  []RatingChangeDTO `json:"history"`
+}
@@ -0,0 +1,72 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ScoreboardResponse struct {
+	ContestID   uuid.UUID              `json:"contest_id"`
+	ScoringMode string                 `json:"scoring_mode"`
+	Frozen      bool                   `json:"frozen"`
+	Problems    []ScoreboardProblemDTO `json:"problems"`
+	Rows        []ScoreboardRowDTO     `json:"rows"`
+	UpdatedAt   time.Time              `json:"updated_at"`
+}
+
+type ScoreboardProblemDTO struct {
+	Label     string    `json:"label"`
+	ProblemID uuid.UUID `json:"problem_id"`
+	Solved    int       `json:"solved"`
+	Attempts  int       `json:"attempts"`
+}
+
+type ScoreboardRowDTO struct {
+	Rank     int                 `json:"rank"`
+	UserID   uuid.UUID           `json:"user_id"`
+	Username string              `json:"username"`
+	TeamID   *uuid.UUID          `json:"team_id,omitempty"`
+	TeamName string              `json:"team_name,omitempty"`
+	Solved   int                 `json:"solved"`
+	Penalty  int                 `json:"penalty"` // in minutes
+	Score    float64             `json:"score"`   // sum of best scores (IOI)
+	Problems []ScoreboardCellDTO `json:"problems"`
+	Virtual  bool                `json:"virtual,omitempty"`
+}
+
+type ScoreboardCellDTO struct {
+	Label      string  `json:"label"`
+	Attempts   int     `json:"attempts"`
+	Pending    int     `json:"pending"`
+	Frozen     bool    `json:"frozen"` // has attempts hidden by the freeze
+	Solved     bool    `json:"solved"`
+	SolvedAt   int     `json:"solved_at,omitempty"` // minutes since contest start
+	Penalty    int     `json:"penalty"`
+	Score      float64 `json:"score"` // best score (IOI)
+	FirstSolve bool    `json:"first_solve"`
+}
+
+type ResolverStepResponse struct {
+	Revealed   *ResolverRevealDTO  `json:"revealed,omitempty"`
+	Remaining  int                 `json:"remaining"`
+	Scoreboard *ScoreboardResponse `json:"scoreboard"`
+}
+
+type ResolverRevealDTO struct {
+	UserID   uuid.UUID         `json:"user_id"`
+	Username string            `json:"username"`
+	Rank     int               `json:"rank"` // rank after the reveal
+	Cell     ScoreboardCellDTO `json:"cell"`
+}
+
+type VirtualStandingResponse struct {
+	ContestID      uuid.UUID           `json:"contest_id"`
+	StartedAt      time.Time           `json:"started_at"`
+	EndsAt         time.Time           `json:"ends_at"`
+	ElapsedMinutes int                 `json:"elapsed_minutes"`
+	Finished       bool                `json:"finished"`
+	Rank           int                 `json:"rank"`
+	Row            ScoreboardRowDTO    `json:"row"`
+	Scoreboard     *ScoreboardResponse `json:"scoreboard"`
+}
@@ -0,0 +1,99 @@
+package dto
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+)
+
+type StatementRequest struct {
+	Title        string `json:"title" binding:"required"`
+	Description  string `json:"description" binding:"required"`
+	InputFormat  string `json:"input_format"`
+	OutputFormat string `json:"output_format"`
+	Notes        string `json:"notes"`
+}
+
+type StatementDTO struct {
+	Locale       string     `json:"locale"`
+	IsDefault    bool       `json:"is_default"` // stored on the problem itself
+	Title        string     `json:"title"`
+	Description  string     `json:"description"`
+	InputFormat  string     `json:"input_format"`
+	OutputFormat string     `json:"output_format"`
+	Notes        string     `json:"notes"`
+	ID           *uuid.UUID `json:"id,omitempty"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+func StatementDTOFromDomain(st *domain.ProblemStatement) StatementDTO {
+	return StatementDTO{
+		Locale:       st.Locale,
+		Title:        st.Title,
+		Description:  st.Description,
+		InputFormat:  st.InputFormat,
+		OutputFormat: st.OutputFormat,
+		Notes:        st.Notes,
+		ID:           &st.ID,
+		UpdatedAt:    st.UpdatedAt,
+	}
+}
+
+// DefaultStatementDTO is the statement a problem holds in its default locale.
+func DefaultStatementDTO(p *domain.Problem) StatementDTO {
+	return StatementDTO{
+		Locale:       p.DefaultLocale,
+		IsDefault:    true,
+		Title:        p.Title,
+		Description:  p.Description,
+		InputFormat:  p.InputFormat,
+		OutputFormat: p.OutputFormat,
+		Notes:        p.Notes,
+		UpdatedAt:    p.UpdatedAt,
+	}
+}
+
+// ParseLocalePreferences returns the locales the client asked for, most
+// preferred first: the ?lang= query, then the Accept-Language header ordered
+// by quality. Wildcards and locales with q=0 are dropped.
+func ParseLocalePreferences(c *gin.Context) []string {
+	var locales []string
+	if lang := strings.TrimSpace(c.Query("lang")); lang != "" {
+		locales = append(locales, lang)
+	}
+
+	type weighted struct {
+		locale  string
+		quality float64
+	}
+	var accepted []weighted
+	for _, part := range strings.Split(c.GetHeader("Accept-Language"), ",") {
+		locale, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		locale = strings.TrimSpace(locale)
+		if locale == "" || locale == "*" {
+			continue
+		}
+		quality := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		if quality > 0 {
+			accepted = append(accepted, weighted{locale: locale, quality: quality})
+		}
+	}
+	sort.SliceStable(accepted, func(i, j int) bool { return accepted[i].quality > accepted[j].quality })
+
+	for _, a := range accepted {
+		locales = append(locales, a.locale)
+	}
+	return locales
+}
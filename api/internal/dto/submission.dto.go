@@ -11,30 +11,41 @@ type SubmitRequest struct {
 	Slug     string `json:"slug" binding:"required"`
 	Code     string `json:"code" binding:"required"`
 	Language string `json:"language" binding:"required"`
+	// ContestID picks the contest when the problem is in several running
+	// contests the user takes part in.
+	ContestID *uuid.UUID `json:"contest_id"`
 }
 
 type SubmissionResponse struct {
-	ID            uuid.UUID           `json:"id"`
-	Verdict       string              `json:"verdict"`
-	ExecutionTime int                 `json:"execution_time"`
-	MemoryUsed    int                 `json:"memory_used"`
-	Score         float64             `json:"score"`
-	TestsPassed   int                 `json:"tests_passed"`
-	TestsFailed   int                 `json:"tests_failed"`
-	Code          string              `json:"code"`
-	Language      string              `json:"language"`
-	SubmittedAt   time.Time           `json:"submitted_at"`
-	JudgedAt      *time.Time          `json:"judged_at"`
-	TestResults   []TestCaseResultDTO `json:"test_results"`
+	ID            uuid.UUID            `json:"id"`
+	ContestID     *uuid.UUID           `json:"contest_id,omitempty"`
+	IsVirtual     bool                 `json:"is_virtual,omitempty"`
+	TeamID        *uuid.UUID           `json:"team_id,omitempty"`
+	Verdict       string               `json:"verdict"`
+	ExecutionTime int                  `json:"execution_time"`
+	MemoryUsed    int                  `json:"memory_used"`
+	Score         float64              `json:"score"`
+	TestsPassed   int                  `json:"tests_passed"`
+	TestsFailed   int                  `json:"tests_failed"`
+	Revision      int                  `json:"problem_revision"` // problem revision judged against
+	Code          string               `json:"code,omitempty"`
+	Language      string               `json:"language"`
+	SubmittedAt   time.Time            `json:"submitted_at"`
+	JudgedAt      *time.Time           `json:"judged_at"`
+	TestResults   []TestCaseResultDTO  `json:"test_results,omitempty"`
+	Groups        []TestGroupResultDTO `json:"groups,omitempty"` // per test group (subtask) results
 }
 
 type SubmissionSummaryDTO struct {
-	ID          uuid.UUID `json:"id"`
-	UserID      uuid.UUID `json:"user_id,omitempty"`
-	ProblemID   uuid.UUID `json:"problem_id,omitempty"`
-	ProblemSlug string    `json:"problem_slug"`
-	Verdict     string    `json:"verdict"`
-	SubmittedAt time.Time `json:"submitted_at"`
+	ID          uuid.UUID  `json:"id"`
+	UserID      uuid.UUID  `json:"user_id,omitempty"`
+	ProblemID   uuid.UUID  `json:"problem_id,omitempty"`
+	ProblemSlug string     `json:"problem_slug"`
+	ContestID   *uuid.UUID `json:"contest_id,omitempty"`
+	IsVirtual   bool       `json:"is_virtual,omitempty"`
+	TeamID      *uuid.UUID `json:"team_id,omitempty"`
+	Verdict     string     `json:"verdict"`
+	SubmittedAt time.Time  `json:"submitted_at"`
 }
 
 type SubmissionListResponse struct {
@@ -51,6 +62,7 @@ type TestCaseResultDTO struct {
 	MemoryUsed    int       `json:"memory_used"`
 	Output        string    `json:"output"`
 	ErrorMessage  string    `json:"error_message"`
+	Score         *float64  `json:"score,omitempty"` // share of the points awarded by a checker
 }
 
 type SubmissionFilters struct {
@@ -73,3 +85,28 @@ func ParseSubmissionFilters(c *gin.Context) *SubmissionFilters {
 		Verdict:   c.Query("verdict"),
 	}
 }
+
+// JudgeReport is the outcome of judging a submission as a worker reports it
+// on the judge results queue.
+type JudgeReport struct {
+	SubmissionID  uuid.UUID         `json:"submission_id"`
+	Verdict       string            `json:"verdict"`
+	ExecutionTime int               `json:"execution_time"` // in milliseconds
+	MemoryUsed    int               `json:"memory_used"`    // in KB
+	CompileError  string            `json:"compile_error,omitempty"`
+	Tests         []JudgeTestReport `json:"tests"` // tests in the order they ran; skipped tests are missing
+
+	// Attempts counts the failed tries to record the report; set by the API
+	// when it puts the report back on the queue.
+	Attempts int `json:"attempts,omitempty"`
+}
+
+type JudgeTestReport struct {
+	TestCaseID    uuid.UUID `json:"test_case_id"`
+	Verdict       string    `json:"verdict"`
+	ExecutionTime int       `json:"execution_time"`
+	MemoryUsed    int       `json:"memory_used"`
+	Output        string    `json:"output,omitempty"`
+	ErrorMessage  string    `json:"error_message,omitempty"`
+	Score         *float64  `json:"score,omitempty"` // share of the points awarded by a checker
+}
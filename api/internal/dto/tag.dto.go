@@ -0,0 +1,35 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+)
+
+type TagDTO struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+}
+
+type TagCountDTO struct {
+	TagDTO
+	ProblemCount int64 `json:"problem_count"`
+}
+
+type RenameTagRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+type MergeTagRequest struct {
+	IntoID uuid.UUID `json:"into_id" binding:"required"`
+}
+
+func TagDTOFromDomain(t *domain.Tag) TagDTO {
+	return TagDTO{ID: t.ID, Name: t.Name}
+}
+
+func TagCountDTOFromDomain(t *domain.TagCount) TagCountDTO {
+	return TagCountDTO{
+		TagDTO:       TagDTOFromDomain(&t.Tag),
+		ProblemCount: t.ProblemCount,
+	}
+}
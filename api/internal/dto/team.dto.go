@@ -0,0 +1,67 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+)
+
+type CreateTeamRequest struct {
+	Name string `json:"name" binding:"required,max=64"`
+}
+
+type InviteTeamMemberRequest struct {
+	Username string `json:"username" binding:"required"`
+}
+
+type TeamMemberDTO struct {
+	UserID   uuid.UUID `json:"user_id"`
+	Username string    `json:"username"`
+	Captain  bool      `json:"captain"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+type TeamResponse struct {
+	ID         uuid.UUID       `json:"id"`
+	ContestID  uuid.UUID       `json:"contest_id"`
+	Name       string          `json:"name"`
+	CaptainID  uuid.UUID       `json:"captain_id"`
+	Members    []TeamMemberDTO `json:"members"`
+	Registered bool            `json:"registered"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+type TeamInviteDTO struct {
+	TeamID    uuid.UUID `json:"team_id"`
+	TeamName  string    `json:"team_name"`
+	InvitedAt time.Time `json:"invited_at"`
+}
+
+// MyTeamResponse is the current user's team in a contest, if any, and the
+// invitations they have not answered yet.
+type MyTeamResponse struct {
+	Team    *TeamResponse   `json:"team"`
+	Invites []TeamInviteDTO `json:"invites"`
+}
+
+func TeamResponseFromDomain(team *domain.Team, registered bool) *TeamResponse {
+	members := make([]TeamMemberDTO, 0, len(team.Members))
+	for _, m := range team.Members {
+		members = append(members, TeamMemberDTO{
+			UserID:   m.UserID,
+			Username: m.User.Username,
+			Captain:  m.UserID == team.CaptainID,
+			JoinedAt: m.JoinedAt,
+		})
+	}
+	return &TeamResponse{
+		ID:         team.ID,
+		ContestID:  team.ContestID,
+		Name:       team.Name,
+		CaptainID:  team.CaptainID,
+		Members:    members,
+		Registered: registered,
+		CreatedAt:  team.CreatedAt,
+	}
+}
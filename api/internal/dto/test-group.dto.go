@@ -0,0 +1,44 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+)
+
+type TestGroupRequest struct {
+	Name        string      `json:"name" binding:"required"`
+	Points      int         `json:"points" binding:"min=0"`
+	ScoringRule string      `json:"scoring_rule" binding:"omitempty,oneof=all min"`
+	OrderIndex  int         `json:"order_index"`
+	DependsOn   []uuid.UUID `json:"depends_on"`
+}
+
+type TestGroupDTO struct {
+	ID          uuid.UUID   `json:"id"`
+	Name        string      `json:"name"`
+	Points      int         `json:"points"`
+	ScoringRule string      `json:"scoring_rule"`
+	OrderIndex  int         `json:"order_index"`
+	DependsOn   []uuid.UUID `json:"depends_on"`
+}
+
+// TestGroupResultDTO is how a submission did on one test group.
+type TestGroupResultDTO struct {
+	GroupID         uuid.UUID `json:"group_id"`
+	Name            string    `json:"name"`
+	Points          int       `json:"points"`
+	Score           float64   `json:"score"`
+	Passed          bool      `json:"passed"`           // every test passed and every dependency is met
+	DependenciesMet bool      `json:"dependencies_met"` // false: the group scores nothing
+}
+
+func TestGroupDTOFromDomain(g *domain.TestGroup) TestGroupDTO {
+	return TestGroupDTO{
+		ID:          g.ID,
+		Name:        g.Name,
+		Points:      g.Points,
+		ScoringRule: g.ScoringRule,
+		OrderIndex:  g.OrderIndex,
+		DependsOn:   g.DependsOn(),
+	}
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+)
+
+// ClarificationRepository defines the interface for contest clarification operations.
+type ClarificationRepository interface {
+	Create(clarification *domain.Clarification) error
+	FindByID(id uuid.UUID) (*domain.Clarification, error)
+	FindByContestID(contestID uuid.UUID, filters *domain.ClarificationFilters) ([]*domain.Clarification, error)
+	Update(clarification *domain.Clarification) error
+}
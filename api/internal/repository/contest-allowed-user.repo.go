@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+)
+
+// ContestAllowedUserRepository defines the interface for private contest allowlists.
+type ContestAllowedUserRepository interface {
+	Create(allowed *domain.ContestAllowedUser) error
+	FindByContestAndUser(contestID uuid.UUID, userID uuid.UUID) (*domain.ContestAllowedUser, error)
+	FindByContestID(contestID uuid.UUID) ([]*domain.ContestAllowedUser, error)
+	Delete(id uuid.UUID) error
+}
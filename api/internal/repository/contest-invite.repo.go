@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+)
+
+// ContestInviteRepository defines the interface for private contest invite codes.
+type ContestInviteRepository interface {
+	Create(invite *domain.ContestInvite) error
+	FindByID(id uuid.UUID) (*domain.ContestInvite, error)
+	FindByCode(code string) (*domain.ContestInvite, error)
+	FindByContestID(contestID uuid.UUID) ([]*domain.ContestInvite, error)
+	// Consume records one use of the invite, reporting false if it has no uses left.
+	Consume(id uuid.UUID) (bool, error)
+	Delete(id uuid.UUID) error
+}
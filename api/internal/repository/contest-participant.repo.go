@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+)
+
+// ContestParticipantRepository defines the interface for contest registration operations.
+type ContestParticipantRepository interface {
+	Create(participant *domain.ContestParticipant) error
+	FindByContestAndUser(contestID uuid.UUID, userID uuid.UUID) (*domain.ContestParticipant, error)
+	// FindByContestAndMember finds the registration a user takes part under,
+	// either their own or that of their team.
+	FindByContestAndMember(contestID uuid.UUID, userID uuid.UUID) (*domain.ContestParticipant, error)
+	FindByTeamID(teamID uuid.UUID) (*domain.ContestParticipant, error)
+	FindByContestID(contestID uuid.UUID) ([]*domain.ContestParticipant, error)
+	Update(participant *domain.ContestParticipant) error
+	Delete(id uuid.UUID) error
+}
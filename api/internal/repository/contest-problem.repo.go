@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+)
+
+// ContestProblemRepository defines the interface for contest problem set operations.
+type ContestProblemRepository interface {
+	Create(contestProblem *domain.ContestProblem) error
+	FindByContestID(contestID uuid.UUID) ([]*domain.ContestProblem, error)
+	FindByContestAndLabel(contestID uuid.UUID, label string) (*domain.ContestProblem, error)
+	FindByContestAndProblem(contestID uuid.UUID, problemID uuid.UUID) (*domain.ContestProblem, error)
+	FindRunningByProblemID(problemID uuid.UUID, at time.Time) ([]*domain.ContestProblem, error)
+	FindUnfinishedByProblemID(problemID uuid.UUID, at time.Time) ([]*domain.ContestProblem, error)
+	EarliestContestStart(problemID uuid.UUID) (*time.Time, error)
+	LatestContestEnd(problemID uuid.UUID) (*time.Time, error)
+	Update(contestProblem *domain.ContestProblem) error
+	Delete(id uuid.UUID) error
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+)
+
+// ContestRepository defines the interface for contest data operations.
+type ContestRepository interface {
+	Create(contest *domain.Contest) error
+	FindByID(id uuid.UUID) (*domain.Contest, error)
+	FindAll(pagination *domain.Pagination, filters *domain.ContestFilters) ([]*domain.Contest, int64, error)
+	FindRatingsDue(at time.Time) ([]*domain.Contest, error)
+	Update(contest *domain.Contest) error
+	Delete(id uuid.UUID) error
+}
@@ -0,0 +1,15 @@
+package repository
+
+import "errors"
+
+var (
+	// ErrNotFound is returned by lookups that match no record, so that callers can
+	// tell a missing record from a failing database.
+	ErrNotFound = errors.New("record not found")
+	// ErrTestCaseJudged is returned when removing a test case that submissions
+	// have results for.
+	ErrTestCaseJudged = errors.New("test case has judged results")
+	// ErrProblemInUse is returned when deleting a problem that has
+	// submissions or is part of a contest.
+	ErrProblemInUse = errors.New("problem has submissions or is part of a contest")
+)
@@ -0,0 +1,58 @@
+package gorm
+
+import (
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+	"gorm.io/gorm"
+)
+
+// ClarificationRepository implements the ClarificationRepository interface using GORM.
+type ClarificationRepository struct {
+	db *gorm.DB
+}
+
+// NewClarificationRepository creates a new GORM-based clarification repository.
+func NewClarificationRepository(db *gorm.DB) *ClarificationRepository {
+	return &ClarificationRepository{db: db}
+}
+
+// Create inserts a new clarification.
+func (r *ClarificationRepository) Create(clarification *domain.Clarification) error {
+	return r.db.Create(clarification).Error
+}
+
+// FindByID retrieves a clarification by ID.
+func (r *ClarificationRepository) FindByID(id uuid.UUID) (*domain.Clarification, error) {
+	var clarification domain.Clarification
+	err := r.db.Preload("ContestProblem").Preload("Asker").First(&clarification, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &clarification, nil
+}
+
+// FindByContestID retrieves the clarifications of a contest, newest first.
+func (r *ClarificationRepository) FindByContestID(contestID uuid.UUID, filters *domain.ClarificationFilters) ([]*domain.Clarification, error) {
+	var clarifications []*domain.Clarification
+
+	query := r.db.Preload("ContestProblem").Preload("Asker").Where("contest_id = ?", contestID)
+	if filters != nil {
+		if filters.VisibleTo != uuid.Nil {
+			query = query.Where("asked_by = ? OR (is_broadcast = ? AND answered_at IS NOT NULL)", filters.VisibleTo, true)
+		}
+		if filters.UnansweredOnly {
+			query = query.Where("answered_at IS NULL")
+		}
+	}
+
+	err := query.Order("created_at DESC").Find(&clarifications).Error
+	if err != nil {
+		return nil, err
+	}
+	return clarifications, nil
+}
+
+// Update updates a clarification.
+func (r *ClarificationRepository) Update(clarification *domain.Clarification) error {
+	return r.db.Save(clarification).Error
+}
@@ -0,0 +1,47 @@
+package gorm
+
+import (
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+	"gorm.io/gorm"
+)
+
+// ContestAllowedUserRepository implements the ContestAllowedUserRepository interface using GORM.
+type ContestAllowedUserRepository struct {
+	db *gorm.DB
+}
+
+// NewContestAllowedUserRepository creates a new GORM-based contest allowlist repository.
+func NewContestAllowedUserRepository(db *gorm.DB) *ContestAllowedUserRepository {
+	return &ContestAllowedUserRepository{db: db}
+}
+
+// Create adds a user to a contest allowlist.
+func (r *ContestAllowedUserRepository) Create(allowed *domain.ContestAllowedUser) error {
+	return r.db.Create(allowed).Error
+}
+
+// FindByContestAndUser retrieves a user's allowlist entry for a contest.
+func (r *ContestAllowedUserRepository) FindByContestAndUser(contestID uuid.UUID, userID uuid.UUID) (*domain.ContestAllowedUser, error) {
+	var allowed domain.ContestAllowedUser
+	err := r.db.Where("contest_id = ? AND user_id = ?", contestID, userID).First(&allowed).Error
+	if err != nil {
+		return nil, err
+	}
+	return &allowed, nil
+}
+
+// FindByContestID retrieves the allowlist of a contest ordered by when users were added.
+func (r *ContestAllowedUserRepository) FindByContestID(contestID uuid.UUID) ([]*domain.ContestAllowedUser, error) {
+	var allowed []*domain.ContestAllowedUser
+	err := r.db.Preload("User").Where("contest_id = ?", contestID).Order("added_at ASC").Find(&allowed).Error
+	if err != nil {
+		return nil, err
+	}
+	return allowed, nil
+}
+
+// Delete removes an allowlist entry by ID.
+func (r *ContestAllowedUserRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&domain.ContestAllowedUser{}, "id = ?", id).Error
+}
@@ -0,0 +1,126 @@
+package gorm
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+	"gorm.io/gorm"
+)
+
+// ContestRepository implements the ContestRepository interface using GORM.
+type ContestRepository struct {
+	db *gorm.DB
+}
+
+// NewContestRepository creates a new GORM-based contest repository.
+func NewContestRepository(db *gorm.DB) *ContestRepository {
+	return &ContestRepository{db: db}
+}
+
+// Create inserts a new contest into the database.
+func (r *ContestRepository) Create(contest *domain.Contest) error {
+	return r.db.Create(contest).Error
+}
+
+// FindByID retrieves a contest by ID.
+func (r *ContestRepository) FindByID(id uuid.UUID) (*domain.Contest, error) {
+	var contest domain.Contest
+	err := r.db.First(&contest, "id = ?", id).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &contest, nil
+}
+
+// FindAll retrieves contests with pagination, optionally filtered by status.
+func (r *ContestRepository) FindAll(pagination *domain.Pagination, filters *domain.ContestFilters) ([]*domain.Contest, int64, error) {
+	var contests []*domain.Contest
+	var total int64
+
+	now := time.Now()
+	query := r.db.Model(&domain.Contest{})
+	order := "start_time DESC"
+	if filters != nil {
+		if !filters.IncludePrivate {
+			query = query.Where("is_public = ? OR id IN (?) OR id IN (?)", true,
+				r.db.Model(&domain.ContestParticipant{}).Select("contest_id").Where("user_id = ? OR team_id IN (?)", filters.ViewerID,
+					r.db.Model(&domain.TeamMember{}).Select("team_id").Where("user_id = ?", filters.ViewerID)),
+				r.db.Model(&domain.ContestAllowedUser{}).Select("contest_id").Where("user_id = ?", filters.ViewerID))
+		}
+		switch filters.Status {
+		case domain.ContestStatusUpcoming:
+			query = query.Where("start_time > ?", now)
+			order = "start_time ASC"
+		case domain.ContestStatusRunning:
+			query = query.Where("start_time <= ? AND end_time > ?", now, now)
+			order = "end_time ASC"
+		case domain.ContestStatusPast:
+			query = query.Where("end_time <= ?", now)
+			order = "end_time DESC"
+		}
+	}
+
+	err := query.Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = query.Limit(pagination.Limit).Offset(pagination.Offset).Order(order).Find(&contests).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return contests, total, nil
+}
+
+// FindRatingsDue retrieves rated contests that have ended but whose rating
+// changes have not been applied yet.
+func (r *ContestRepository) FindRatingsDue(at time.Time) ([]*domain.Contest, error) {
+	var contests []*domain.Contest
+	err := r.db.Where("is_rated = ? AND ratings_applied = ? AND end_time <= ?", true, false, at).
+		Order("end_time ASC").Find(&contests).Error
+	if err != nil {
+		return nil, err
+	}
+	return contests, nil
+}
+
+// Update updates an existing contest.
+func (r *ContestRepository) Update(contest *domain.Contest) error {
+	return r.db.Save(contest).Error
+}
+
+// Delete removes a contest by ID together with everything that belongs to it.
+// Its submissions are kept as practice submissions.
+func (r *ContestRepository) Delete(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		teams := tx.Model(&domain.Team{}).Select("id").Where("contest_id = ?", id)
+		if err := tx.Where("team_id IN (?)", teams).Delete(&domain.TeamInvite{}).Error; err != nil {
+			return err
+		}
+		// Clarifications point at contest problems and registrations at teams,
+		// so they go first.
+		children := []interface{}{
+			&domain.Clarification{},
+			&domain.ContestParticipant{},
+			&domain.TeamMember{},
+			&domain.Team{},
+			&domain.ContestProblem{},
+			&domain.ContestInvite{},
+			&domain.ContestAllowedUser{},
+			&domain.VirtualParticipation{},
+			&domain.RatingChange{},
+		}
+		for _, child := range children {
+			if err := tx.Where("contest_id = ?", id).Delete(child).Error; err != nil {
+				return err
+			}
+		}
+		err := tx.Model(&domain.Submission{}).Where("contest_id = ?", id).
+			Updates(map[string]interface{}{"contest_id": nil, "team_id": nil, "is_virtual": false}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Delete(&domain.Contest{}, "id = ?", id).Error
+	})
+}
@@ -0,0 +1,66 @@
+package gorm
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+	"github.com/klaus-creations/klaus-judge/api/internal/repository"
+)
+
+func TestDeleteContestWithChildren(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewContestRepository(db)
+
+	admin := &domain.User{Username: "admin", Email: "admin@example.com", PasswordHash: "x"}
+	member := &domain.User{Username: "member", Email: "member@example.com", PasswordHash: "x"}
+	mustCreate(t, db, admin)
+	mustCreate(t, db, member)
+	problem := &domain.Problem{Title: "Sum", Slug: "sum", Description: "Add two numbers.", CreatedBy: admin.ID}
+	mustCreate(t, db, problem)
+
+	now := time.Now()
+	contest := &domain.Contest{Title: "Round 1", StartTime: now.Add(-2 * time.Hour), EndTime: now.Add(-time.Hour), MaxTeamSize: 2, CreatedBy: admin.ID}
+	mustCreate(t, db, contest)
+	contestProblem := &domain.ContestProblem{ContestID: contest.ID, ProblemID: problem.ID, Label: "A"}
+	mustCreate(t, db, contestProblem)
+	team := &domain.Team{ContestID: contest.ID, Name: "Adders", CaptainID: admin.ID,
+		Members: []domain.TeamMember{{ContestID: contest.ID, UserID: admin.ID}}}
+	mustCreate(t, db, team)
+	mustCreate(t, db, &domain.TeamInvite{TeamID: team.ID, UserID: member.ID, InvitedBy: admin.ID})
+	mustCreate(t, db, &domain.ContestParticipant{ContestID: contest.ID, UserID: admin.ID, TeamID: &team.ID})
+	mustCreate(t, db, &domain.ContestInvite{ContestID: contest.ID, Code: "ROUND1", CreatedBy: admin.ID})
+	mustCreate(t, db, &domain.ContestAllowedUser{ContestID: contest.ID, UserID: member.ID})
+	mustCreate(t, db, &domain.Clarification{ContestID: contest.ID, ContestProblemID: &contestProblem.ID, AskedBy: admin.ID, Question: "Overflow?"})
+	mustCreate(t, db, &domain.VirtualParticipation{ContestID: contest.ID, UserID: member.ID, StartedAt: now, EndsAt: now.Add(time.Hour)})
+	mustCreate(t, db, &domain.RatingChange{ContestID: contest.ID, UserID: admin.ID, Rank: 1, OldRating: 1500, NewRating: 1550})
+	submission := &domain.Submission{UserID: admin.ID, ProblemID: problem.ID, ContestID: &contest.ID, TeamID: &team.ID, Code: "x", Language: "cpp"}
+	mustCreate(t, db, submission)
+
+	if err := repo.Delete(contest.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	if _, err := repo.FindByID(contest.ID); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("FindByID after delete: got %v, want ErrNotFound", err)
+	}
+	for _, model := range []interface{}{
+		&domain.ContestProblem{}, &domain.Team{}, &domain.TeamMember{}, &domain.TeamInvite{},
+		&domain.ContestParticipant{}, &domain.ContestInvite{}, &domain.ContestAllowedUser{},
+		&domain.Clarification{}, &domain.VirtualParticipation{}, &domain.RatingChange{},
+	} {
+		var count int64
+		db.Model(model).Count(&count)
+		if count != 0 {
+			t.Errorf("%T rows after delete = %d, want 0", model, count)
+		}
+	}
+	var stored domain.Submission
+	if err := db.First(&stored, "id = ?", submission.ID).Error; err != nil {
+		t.Fatalf("submission after delete: %v", err)
+	}
+	if stored.ContestID != nil || stored.TeamID != nil {
+		t.Errorf("submission after delete = contest %v, team %v, want a practice submission", stored.ContestID, stored.TeamID)
+	}
+}
@@ -0,0 +1,68 @@
+package gorm
+
+import (
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+	"gorm.io/gorm"
+)
+
+// ContestInviteRepository implements the ContestInviteRepository interface using GORM.
+type ContestInviteRepository struct {
+	db *gorm.DB
+}
+
+// NewContestInviteRepository creates a new GORM-based contest invite repository.
+func NewContestInviteRepository(db *gorm.DB) *ContestInviteRepository {
+	return &ContestInviteRepository{db: db}
+}
+
+// Create inserts a new invite.
+func (r *ContestInviteRepository) Create(invite *domain.ContestInvite) error {
+	return r.db.Create(invite).Error
+}
+
+// FindByID retrieves an invite by ID.
+func (r *ContestInviteRepository) FindByID(id uuid.UUID) (*domain.ContestInvite, error) {
+	var invite domain.ContestInvite
+	err := r.db.First(&invite, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &invite, nil
+}
+
+// FindByCode retrieves an invite by its code.
+func (r *ContestInviteRepository) FindByCode(code string) (*domain.ContestInvite, error) {
+	var invite domain.ContestInvite
+	err := r.db.First(&invite, "code = ?", code).Error
+	if err != nil {
+		return nil, err
+	}
+	return &invite, nil
+}
+
+// FindByContestID retrieves the invites of a contest, newest first.
+func (r *ContestInviteRepository) FindByContestID(contestID uuid.UUID) ([]*domain.ContestInvite, error) {
+	var invites []*domain.ContestInvite
+	err := r.db.Where("contest_id = ?", contestID).Order("created_at DESC").Find(&invites).Error
+	if err != nil {
+		return nil, err
+	}
+	return invites, nil
+}
+
+// Consume atomically increments the use count while uses remain.
+func (r *ContestInviteRepository) Consume(id uuid.UUID) (bool, error) {
+	result := r.db.Model(&domain.ContestInvite{}).
+		Where("id = ? AND (max_uses = 0 OR uses < max_uses)", id).
+		UpdateColumn("uses", gorm.Expr("uses + 1"))
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// Delete removes an invite by ID.
+func (r *ContestInviteRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&domain.ContestInvite{}, "id = ?", id).Error
+}
@@ -0,0 +1,75 @@
+package gorm
+
+import (
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+	"gorm.io/gorm"
+)
+
+// ContestParticipantRepository implements the ContestParticipantRepository interface using GORM.
+type ContestParticipantRepository struct {
+	db *gorm.DB
+}
+
+// NewContestParticipantRepository creates a new GORM-based contest participant repository.
+func NewContestParticipantRepository(db *gorm.DB) *ContestParticipantRepository {
+	return &ContestParticipantRepository{db: db}
+}
+
+// Create registers a user for a contest.
+func (r *ContestParticipantRepository) Create(participant *domain.ContestParticipant) error {
+	return r.db.Create(participant).Error
+}
+
+// FindByContestAndUser retrieves a user's registration for a contest.
+func (r *ContestParticipantRepository) FindByContestAndUser(contestID uuid.UUID, userID uuid.UUID) (*domain.ContestParticipant, error) {
+	var participant domain.ContestParticipant
+	err := r.db.Where("contest_id = ? AND user_id = ?", contestID, userID).First(&participant).Error
+	if err != nil {
+		return nil, err
+	}
+	return &participant, nil
+}
+
+// FindByContestAndMember retrieves the registration a user takes part in a
+// contest under: their own, or their team's.
+func (r *ContestParticipantRepository) FindByContestAndMember(contestID uuid.UUID, userID uuid.UUID) (*domain.ContestParticipant, error) {
+	var participant domain.ContestParticipant
+	err := r.db.Where("contest_id = ? AND (user_id = ? OR team_id IN (?))", contestID, userID,
+		r.db.Model(&domain.TeamMember{}).Select("team_id").Where("contest_id = ? AND user_id = ?", contestID, userID)).
+		First(&participant).Error
+	if err != nil {
+		return nil, err
+	}
+	return &participant, nil
+}
+
+// FindByTeamID retrieves a team's registration.
+func (r *ContestParticipantRepository) FindByTeamID(teamID uuid.UUID) (*domain.ContestParticipant, error) {
+	var participant domain.ContestParticipant
+	err := r.db.Where("team_id = ?", teamID).First(&participant).Error
+	if err != nil {
+		return nil, err
+	}
+	return &participant, nil
+}
+
+// FindByContestID retrieves the roster of a contest ordered by registration time.
+func (r *ContestParticipantRepository) FindByContestID(contestID uuid.UUID) ([]*domain.ContestParticipant, error) {
+	var participants []*domain.ContestParticipant
+	err := r.db.Preload("User").Preload("Team").Where("contest_id = ?", contestID).Order("registered_at ASC").Find(&participants).Error
+	if err != nil {
+		return nil, err
+	}
+	return participants, nil
+}
+
+// Update updates a registration.
+func (r *ContestParticipantRepository) Update(participant *domain.ContestParticipant) error {
+	return r.db.Save(participant).Error
+}
+
+// Delete removes a registration by ID.
+func (r *ContestParticipantRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&domain.ContestParticipant{}, "id = ?", id).Error
+}
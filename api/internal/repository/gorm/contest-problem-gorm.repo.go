@@ -0,0 +1,123 @@
+package gorm
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+	"gorm.io/gorm"
+)
+
+// ContestProblemRepository implements the ContestProblemRepository interface using GORM.
+type ContestProblemRepository struct {
+	db *gorm.DB
+}
+
+// NewContestProblemRepository creates a new GORM-based contest problem repository.
+func NewContestProblemRepository(db *gorm.DB) *ContestProblemRepository {
+	return &ContestProblemRepository{db: db}
+}
+
+// Create assigns a problem to a contest.
+func (r *ContestProblemRepository) Create(contestProblem *domain.ContestProblem) error {
+	return r.db.Create(contestProblem).Error
+}
+
+// FindByContestID retrieves the problem set of a contest in label order, so
+// that Z comes before AA.
+func (r *ContestProblemRepository) FindByContestID(contestID uuid.UUID) ([]*domain.ContestProblem, error) {
+	var contestProblems []*domain.ContestProblem
+	err := r.db.Preload("Problem").Where("contest_id = ?", contestID).Order("length(label), label").Find(&contestProblems).Error
+	if err != nil {
+		return nil, err
+	}
+	return contestProblems, nil
+}
+
+// FindByContestAndLabel retrieves a contest problem by its label.
+func (r *ContestProblemRepository) FindByContestAndLabel(contestID uuid.UUID, label string) (*domain.ContestProblem, error) {
+	var contestProblem domain.ContestProblem
+	err := r.db.Preload("Problem").Where("contest_id = ? AND label = ?", contestID, label).First(&contestProblem).Error
+	if err != nil {
+		return nil, err
+	}
+	return &contestProblem, nil
+}
+
+// FindByContestAndProblem retrieves the entry of a problem in a contest.
+func (r *ContestProblemRepository) FindByContestAndProblem(contestID uuid.UUID, problemID uuid.UUID) (*domain.ContestProblem, error) {
+	var contestProblem domain.ContestProblem
+	err := r.db.Where("contest_id = ? AND problem_id = ?", contestID, problemID).First(&contestProblem).Error
+	if err != nil {
+		return nil, err
+	}
+	return &contestProblem, nil
+}
+
+// FindRunningByProblemID retrieves the entries of a problem in contests running at the given time.
+func (r *ContestProblemRepository) FindRunningByProblemID(problemID uuid.UUID, at time.Time) ([]*domain.ContestProblem, error) {
+	var contestProblems []*domain.ContestProblem
+	err := r.db.Preload("Contest").
+		Joins("JOIN contests ON contests.id = contest_problems.contest_id").
+		Where("contest_problems.problem_id = ? AND contests.start_time <= ? AND contests.end_time > ?", problemID, at, at).
+		Order("contests.start_time ASC, contests.id ASC").
+		Find(&contestProblems).Error
+	if err != nil {
+		return nil, err
+	}
+	return contestProblems, nil
+}
+
+// FindUnfinishedByProblemID retrieves the entries of a problem in contests that have not ended at the given time.
+func (r *ContestProblemRepository) FindUnfinishedByProblemID(problemID uuid.UUID, at time.Time) ([]*domain.ContestProblem, error) {
+	var contestProblems []*domain.ContestProblem
+	err := r.db.Preload("Contest").
+		Joins("JOIN contests ON contests.id = contest_problems.contest_id").
+		Where("contest_problems.problem_id = ? AND contests.end_time > ?", problemID, at).
+		Order("contests.start_time ASC").
+		Find(&contestProblems).Error
+	if err != nil {
+		return nil, err
+	}
+	return contestProblems, nil
+}
+
+// EarliestContestStart returns the earliest start time among the contests a problem belongs to,
+// or nil if the problem is not part of any contest.
+func (r *ContestProblemRepository) EarliestContestStart(problemID uuid.UUID) (*time.Time, error) {
+	var start *time.Time
+	err := r.db.Model(&domain.ContestProblem{}).
+		Select("MIN(contests.start_time)").
+		Joins("JOIN contests ON contests.id = contest_problems.contest_id").
+		Where("contest_problems.problem_id = ?", problemID).
+		Scan(&start).Error
+	if err != nil {
+		return nil, err
+	}
+	return start, nil
+}
+
+// LatestContestEnd returns the latest end time among the contests a problem belongs to,
+// or nil if the problem is not part of any contest.
+func (r *ContestProblemRepository) LatestContestEnd(problemID uuid.UUID) (*time.Time, error) {
+	var end *time.Time
+	err := r.db.Model(&domain.ContestProblem{}).
+		Select("MAX(contests.end_time)").
+		Joins("JOIN contests ON contests.id = contest_problems.contest_id").
+		Where("contest_problems.problem_id = ?", problemID).
+		Scan(&end).Error
+	if err != nil {
+		return nil, err
+	}
+	return end, nil
+}
+
+// Update updates a contest problem.
+func (r *ContestProblemRepository) Update(contestProblem *domain.ContestProblem) error {
+	return r.db.Save(contestProblem).Error
+}
+
+// Delete removes a problem from a contest.
+func (r *ContestProblemRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&domain.ContestProblem{}, "id = ?", id).Error
+}
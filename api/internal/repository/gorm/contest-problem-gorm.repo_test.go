@@ -0,0 +1,38 @@
+package gorm
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+)
+
+func TestFindByContestIDOrdersLabelsLikeColumns(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewContestProblemRepository(db)
+
+	admin := &domain.User{Username: "admin", Email: "admin@example.com", PasswordHash: "x"}
+	mustCreate(t, db, admin)
+	now := time.Now()
+	contest := &domain.Contest{Title: "Marathon", StartTime: now, EndTime: now.Add(time.Hour), CreatedBy: admin.ID}
+	mustCreate(t, db, contest)
+	for i, label := range []string{"AB", "B", "AA", "Z", "A"} {
+		problem := &domain.Problem{Title: label, Slug: fmt.Sprintf("p%d", i), Description: "-", CreatedBy: admin.ID}
+		mustCreate(t, db, problem)
+		mustCreate(t, db, &domain.ContestProblem{ContestID: contest.ID, ProblemID: problem.ID, Label: label})
+	}
+
+	contestProblems, err := repo.FindByContestID(contest.ID)
+	if err != nil {
+		t.Fatalf("FindByContestID: %v", err)
+	}
+	labels := make([]string, len(contestProblems))
+	for i, cp := range contestProblems {
+		labels[i] = cp.Label
+	}
+	if got, want := strings.Join(labels, " "), "A B Z AA AB"; got != want {
+		t.Errorf("labels = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,16 @@
+package gorm
+
+import (
+	"errors"
+
+	"github.com/klaus-creations/klaus-judge/api/internal/repository"
+	"gorm.io/gorm"
+)
+
+// translateError turns gorm's not-found error into repository.ErrNotFound.
+func translateError(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return repository.ErrNotFound
+	}
+	return err
+}
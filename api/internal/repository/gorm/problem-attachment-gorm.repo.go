@@ -0,0 +1,47 @@
+package gorm
+
+import (
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+	"gorm.io/gorm"
+)
+
+// ProblemAttachmentRepository implements the ProblemAttachmentRepository interface using GORM.
+type ProblemAttachmentRepository struct {
+	db *gorm.DB
+}
+
+// NewProblemAttachmentRepository creates a new GORM-based problem attachment repository.
+func NewProblemAttachmentRepository(db *gorm.DB) *ProblemAttachmentRepository {
+	return &ProblemAttachmentRepository{db: db}
+}
+
+// Create inserts a new attachment.
+func (r *ProblemAttachmentRepository) Create(attachment *domain.ProblemAttachment) error {
+	return r.db.Create(attachment).Error
+}
+
+// FindByID retrieves an attachment by ID.
+func (r *ProblemAttachmentRepository) FindByID(id uuid.UUID) (*domain.ProblemAttachment, error) {
+	var attachment domain.ProblemAttachment
+	err := r.db.First(&attachment, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+// FindByProblemID retrieves the attachments of a problem, oldest first.
+func (r *ProblemAttachmentRepository) FindByProblemID(problemID uuid.UUID) ([]*domain.ProblemAttachment, error) {
+	var attachments []*domain.ProblemAttachment
+	err := r.db.Where("problem_id = ?", problemID).Order("created_at ASC").Find(&attachments).Error
+	if err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+// Delete removes an attachment by ID.
+func (r *ProblemAttachmentRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&domain.ProblemAttachment{}, "id = ?", id).Error
+}
@@ -0,0 +1,42 @@
+package gorm
+
+import (
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+	"gorm.io/gorm"
+)
+
+// ProblemCheckerRepository implements the ProblemCheckerRepository interface using GORM.
+type ProblemCheckerRepository struct {
+	db *gorm.DB
+}
+
+// NewProblemCheckerRepository creates a new GORM-based problem checker repository.
+func NewProblemCheckerRepository(db *gorm.DB) *ProblemCheckerRepository {
+	return &ProblemCheckerRepository{db: db}
+}
+
+// Create inserts a new checker.
+func (r *ProblemCheckerRepository) Create(checker *domain.ProblemChecker) error {
+	return r.db.Create(checker).Error
+}
+
+// FindByProblemID retrieves the checker of a problem.
+func (r *ProblemCheckerRepository) FindByProblemID(problemID uuid.UUID) (*domain.ProblemChecker, error) {
+	var checker domain.ProblemChecker
+	err := r.db.Where("problem_id = ?", problemID).First(&checker).Error
+	if err != nil {
+		return nil, err
+	}
+	return &checker, nil
+}
+
+// Update updates an existing checker.
+func (r *ProblemCheckerRepository) Update(checker *domain.ProblemChecker) error {
+	return r.db.Save(checker).Error
+}
+
+// DeleteByProblemID removes the checker of a problem.
+func (r *ProblemCheckerRepository) DeleteByProblemID(problemID uuid.UUID) error {
+	return r.db.Delete(&domain.ProblemChecker{}, "problem_id = ?", problemID).Error
+}
@@ -0,0 +1,52 @@
+package gorm
+
+import (
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+	"gorm.io/gorm"
+)
+
+// ProblemCollaboratorRepository implements the ProblemCollaboratorRepository interface using GORM.
+type ProblemCollaboratorRepository struct {
+	db *gorm.DB
+}
+
+// NewProblemCollaboratorRepository creates a new GORM-based problem collaborator repository.
+func NewProblemCollaboratorRepository(db *gorm.DB) *ProblemCollaboratorRepository {
+	return &ProblemCollaboratorRepository{db: db}
+}
+
+// Create adds a collaborator to a problem.
+func (r *ProblemCollaboratorRepository) Create(collaborator *domain.ProblemCollaborator) error {
+	return r.db.Create(collaborator).Error
+}
+
+// FindByProblemAndUser retrieves a user's role on a problem.
+func (r *ProblemCollaboratorRepository) FindByProblemAndUser(problemID uuid.UUID, userID uuid.UUID) (*domain.ProblemCollaborator, error) {
+	var collaborator domain.ProblemCollaborator
+	err := r.db.Where("problem_id = ? AND user_id = ?", problemID, userID).First(&collaborator).Error
+	if err != nil {
+		return nil, err
+	}
+	return &collaborator, nil
+}
+
+// FindByProblemID retrieves the collaborators of a problem ordered by when they were added.
+func (r *ProblemCollaboratorRepository) FindByProblemID(problemID uuid.UUID) ([]*domain.ProblemCollaborator, error) {
+	var collaborators []*domain.ProblemCollaborator
+	err := r.db.Preload("User").Where("problem_id = ?", problemID).Order("added_at ASC").Find(&collaborators).Error
+	if err != nil {
+		return nil, err
+	}
+	return collaborators, nil
+}
+
+// Update updates a collaborator's role.
+func (r *ProblemCollaboratorRepository) Update(collaborator *domain.ProblemCollaborator) error {
+	return r.db.Model(collaborator).Update("role", collaborator.Role).Error
+}
+
+// Delete removes a collaborator by ID.
+func (r *ProblemCollaboratorRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&domain.ProblemCollaborator{}, "id = ?", id).Error
+}
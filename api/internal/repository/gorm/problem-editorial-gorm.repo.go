@@ -0,0 +1,60 @@
+package gorm
+
+import (
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ProblemEditorialRepository implements the ProblemEditorialRepository interface using GORM.
+type ProblemEditorialRepository struct {
+	db *gorm.DB
+}
+
+// NewProblemEditorialRepository creates a new GORM-based problem editorial repository.
+func NewProblemEditorialRepository(db *gorm.DB) *ProblemEditorialRepository {
+	return &ProblemEditorialRepository{db: db}
+}
+
+// Save creates or updates an editorial and replaces its reference solutions.
+func (r *ProblemEditorialRepository) Save(editorial *domain.ProblemEditorial) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(editorial).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&domain.EditorialSolution{}, "editorial_id = ?", editorial.ID).Error; err != nil {
+			return err
+		}
+		for i := range editorial.Solutions {
+			editorial.Solutions[i].ID = uuid.Nil
+			editorial.Solutions[i].EditorialID = editorial.ID
+		}
+		if len(editorial.Solutions) == 0 {
+			return nil
+		}
+		return tx.Create(&editorial.Solutions).Error
+	})
+}
+
+// FindByProblemID retrieves the editorial of a problem with its solutions ordered by language.
+func (r *ProblemEditorialRepository) FindByProblemID(problemID uuid.UUID) (*domain.ProblemEditorial, error) {
+	var editorial domain.ProblemEditorial
+	err := r.db.Preload("Solutions", func(db *gorm.DB) *gorm.DB {
+		return db.Order("language ASC")
+	}).Where("problem_id = ?", problemID).First(&editorial).Error
+	if err != nil {
+		return nil, err
+	}
+	return &editorial, nil
+}
+
+// Delete removes an editorial and its solutions by ID.
+func (r *ProblemEditorialRepository) Delete(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&domain.EditorialSolution{}, "editorial_id = ?", id).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.ProblemEditorial{}, "id = ?", id).Error
+	})
+}
@@ -1,9 +1,26 @@
 package gorm
 
 import (
+	"html"
+	"strings"
+	"time"
+	"unicode"
+
 	"github.com/google/uuid"
 	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+	"github.com/klaus-creations/klaus-judge/api/internal/repository"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Full-text search markers: ts_headline wraps matches in these control
+// characters, which statements never contain, so the rest of a snippet can
+// be HTML-escaped before they become <mark> tags.
+const (
+	snippetStart   = "\x02"
+	snippetStop    = "\x03"
+	snippetOptions = "StartSel=" + snippetStart + ", StopSel=" + snippetStop +
+		", MaxWords=30, MinWords=10, MaxFragments=2, FragmentDelimiter=\" … \""
 )
 
 // ProblemRepository implements the ProblemRepository interface using GORM.
@@ -18,15 +35,45 @@ func NewProblemRepository(db *gorm.DB) *ProblemRepository {
 
 // Create inserts a new problem into the database.
 func (r *ProblemRepository) Create(problem *domain.Problem) error {
-	return r.db.Create(problem).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(problem).Error; err != nil {
+			return err
+		}
+		return refreshSearchTags(tx, []uuid.UUID{problem.ID})
+	})
+}
+
+// CreateWithTestCases inserts a problem and its test cases in one transaction.
+func (r *ProblemRepository) CreateWithTestCases(problem *domain.Problem, testCases []*domain.TestCase) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(problem).Error; err != nil {
+			return err
+		}
+		if err := refreshSearchTags(tx, []uuid.UUID{problem.ID}); err != nil {
+			return err
+		}
+		for _, tc := range testCases {
+			tc.ProblemID = problem.ID
+			if err := tx.Create(tc).Error; err != nil {
+				return err
+			}
+			// GORM leaves zero values to the column defaults on insert.
+			if !tc.IsHidden || tc.Points == 0 {
+				if err := tx.Model(tc).Select("IsHidden", "Points").Updates(tc).Error; err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
 }
 
 // FindByID retrieves a problem by ID.
 func (r *ProblemRepository) FindByID(id uuid.UUID) (*domain.Problem, error) {
 	var problem domain.Problem
-	err := r.db.First(&problem, "id = ?", id).Error
+	err := r.db.Preload("Tags", orderTagsByName).First(&problem, "id = ?", id).Error
 	if err != nil {
-		return nil, err
+		return nil, translateError(err)
 	}
 	return &problem, nil
 }
@@ -34,9 +81,9 @@ func (r *ProblemRepository) FindByID(id uuid.UUID) (*domain.Problem, error) {
 // FindBySlug retrieves a problem by slug.
 func (r *ProblemRepository) FindBySlug(slug string) (*domain.Problem, error) {
 	var problem domain.Problem
-	err := r.db.Where("slug = ?", slug).First(&problem).Error
+	err := r.db.Preload("Tags", orderTagsByName).Where("slug = ?", slug).First(&problem).Error
 	if err != nil {
-		return nil, err
+		return nil, translateError(err)
 	}
 	return &problem, nil
 }
@@ -51,8 +98,31 @@ func (r *ProblemRepository) FindAll(pagination *domain.Pagination, filters *doma
 		if filters.Difficulty != "" {
 			query = query.Where("difficulty = ?", filters.Difficulty)
 		}
-		if filters.Tags != "" {
-			query = query.Where("tags LIKE ?", "%"+filters.Tags+"%")
+		if len(filters.Tags) > 0 {
+			tagged := r.db.Model(&domain.ProblemTag{}).
+				Select("problem_tags.problem_id").
+				Joins("JOIN tags ON tags.id = problem_tags.tag_id").
+				Where("tags.name IN ?", filters.Tags)
+			if filters.MatchAllTags {
+				tagged = tagged.Group("problem_tags.problem_id").
+					Having("COUNT(DISTINCT tags.id) = ?", len(filters.Tags))
+			}
+			query = query.Where("id IN (?)", tagged)
+		}
+		if filters.Query != "" {
+			query = query.Where("search_vector @@ websearch_to_tsquery('english', ?)", filters.Query)
+		}
+		if filters.Status != "" {
+			query = query.Where("status = ?", filters.Status)
+		}
+		if filters.VisibleAt != nil {
+			listed := problemListed(r.db, *filters.VisibleAt)
+			if filters.CollaboratorID != uuid.Nil {
+				listed = listed.Or("id IN (?)", r.db.Model(&domain.ProblemCollaborator{}).
+					Select("problem_id").
+					Where("user_id = ?", filters.CollaboratorID))
+			}
+			query = query.Where(listed)
 		}
 	}
 
@@ -61,21 +131,149 @@ func (r *ProblemRepository) FindAll(pagination *domain.Pagination, filters *doma
 		return nil, 0, err
 	}
 
-	err = query.Limit(pagination.Limit).Offset(pagination.Offset).Order("created_at DESC").Find(&problems).Error
+	if filters != nil && filters.Query != "" {
+		query = query.Order(clause.OrderBy{Expression: clause.Expr{
+			SQL:  "ts_rank(search_vector, websearch_to_tsquery('english', ?)) DESC, created_at DESC",
+			Vars: []interface{}{filters.Query},
+		}})
+	} else {
+		query = query.Order("created_at DESC")
+	}
+	err = query.Preload("Tags", orderTagsByName).Limit(pagination.Limit).Offset(pagination.Offset).Find(&problems).Error
 	if err != nil {
 		return nil, 0, err
 	}
 	return problems, total, nil
 }
 
-// Update updates an existing problem.
+// Update updates an existing problem and replaces its tags with problem.Tags.
+// The revision counter is left alone: only ProblemRevisionRepository.Create
+// advances it.
 func (r *ProblemRepository) Update(problem *domain.Problem) error {
-	return r.db.Save(problem).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations, "revision").Save(problem).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(problem).Association("Tags").Replace(problem.Tags); err != nil {
+			return err
+		}
+		return refreshSearchTags(tx, []uuid.UUID{problem.ID})
+	})
 }
 
-// Delete removes a problem by ID.
+// Restore saves the problem, except for its revision counter, and brings its
+// test cases to the given set in one transaction. Test cases are updated in
+// place by ID so that the results pointing at them stay valid.
+func (r *ProblemRepository) Restore(problem *domain.Problem, testCases []*domain.TestCase) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations, "revision").Save(problem).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(problem).Association("Tags").Replace(problem.Tags); err != nil {
+			return err
+		}
+		if err := refreshSearchTags(tx, []uuid.UUID{problem.ID}); err != nil {
+			return err
+		}
+
+		var existing []uuid.UUID
+		if err := tx.Model(&domain.TestCase{}).Where("problem_id = ?", problem.ID).Pluck("id", &existing).Error; err != nil {
+			return err
+		}
+		keep := make(map[uuid.UUID]bool, len(testCases))
+		for _, tc := range testCases {
+			keep[tc.ID] = true
+		}
+		var removed []uuid.UUID
+		for _, id := range existing {
+			if !keep[id] {
+				removed = append(removed, id)
+			}
+		}
+		if len(removed) > 0 {
+			var judged int64
+			if err := tx.Model(&domain.TestCaseResult{}).Where("test_case_id IN ?", removed).Count(&judged).Error; err != nil {
+				return err
+			}
+			if judged > 0 {
+				return repository.ErrTestCaseJudged
+			}
+			if err := tx.Delete(&domain.TestCase{}, "id IN ?", removed).Error; err != nil {
+				return err
+			}
+		}
+
+		stored := make(map[uuid.UUID]bool, len(existing))
+		for _, id := range existing {
+			stored[id] = true
+		}
+		for _, tc := range testCases {
+			tc.ProblemID = problem.ID
+			if stored[tc.ID] {
+				// Select every column so that zero values are written too.
+				if err := tx.Model(tc).Select("*").Omit("CreatedAt", clause.Associations).Updates(tc).Error; err != nil {
+					return err
+				}
+				continue
+			}
+			if err := tx.Create(tc).Error; err != nil {
+				return err
+			}
+			// GORM leaves zero values to the column defaults on insert.
+			if !tc.IsHidden || tc.Points == 0 {
+				if err := tx.Model(tc).Select("IsHidden", "Points").Updates(tc).Error; err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// Delete removes a problem by ID along with everything that belongs to it:
+// tag links, collaborators, statements, checker, interactor, test groups and
+// cases, revisions, attachments and the editorial. Problems with submissions
+// or in a contest are kept and ErrProblemInUse is returned.
 func (r *ProblemRepository) Delete(id uuid.UUID) error {
-	return r.db.Delete(&domain.Problem{}, "id = ?", id).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, use := range []interface{}{&domain.Submission{}, &domain.ContestProblem{}} {
+			var count int64
+			if err := tx.Model(use).Where("problem_id = ?", id).Count(&count).Error; err != nil {
+				return err
+			}
+			if count > 0 {
+				return repository.ErrProblemInUse
+			}
+		}
+
+		groups := tx.Model(&domain.TestGroup{}).Select("id").Where("problem_id = ?", id)
+		if err := tx.Where("group_id IN (?)", groups).Delete(&domain.TestGroupDependency{}).Error; err != nil {
+			return err
+		}
+		editorials := tx.Model(&domain.ProblemEditorial{}).Select("id").Where("problem_id = ?", id)
+		if err := tx.Where("editorial_id IN (?)", editorials).Delete(&domain.EditorialSolution{}).Error; err != nil {
+			return err
+		}
+		// Test cases point at their groups, so they go first.
+		children := []interface{}{
+			&domain.TestCase{},
+			&domain.TestGroup{},
+			&domain.ProblemChecker{},
+			&domain.ProblemInteractor{},
+			&domain.ProblemStatement{},
+			&domain.ProblemRevision{},
+			&domain.ProblemAttachment{},
+			&domain.ProblemEditorial{},
+			&domain.ProblemTag{},
+			&domain.ProblemCollaborator{},
+		}
+		for _, child := range children {
+			if err := tx.Where("problem_id = ?", id).Delete(child).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Delete(&domain.Problem{}, "id = ?", id).Error
+	})
 }
 
 // IncrementAcceptedCount increments the accepted count for a problem.
@@ -87,3 +285,119 @@ func (r *ProblemRepository) IncrementAcceptedCount(id uuid.UUID) error {
 func (r *ProblemRepository) IncrementSubmissionCount(id uuid.UUID) error {
 	return r.db.Model(&domain.Problem{}).Where("id = ?", id).Update("submission_count", gorm.Expr("submission_count + 1")).Error
 }
+
+// Snippets returns, for each of the given problems whose statement matches a
+// full-text query, an HTML excerpt of the statement with the matches in <mark>.
+func (r *ProblemRepository) Snippets(ids []uuid.UUID, query string) (map[uuid.UUID]string, error) {
+	snippets := make(map[uuid.UUID]string, len(ids))
+	if len(ids) == 0 || query == "" {
+		return snippets, nil
+	}
+
+	var rows []struct {
+		ID      uuid.UUID
+		Snippet string
+	}
+	err := r.db.Model(&domain.Problem{}).
+		Select("id, ts_headline('english', description, websearch_to_tsquery('english', ?), ?) AS snippet", query, snippetOptions).
+		Where("id IN ?", ids).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		if !strings.Contains(row.Snippet, snippetStart) {
+			continue // matched on the title or tags only
+		}
+		snippet := html.EscapeString(row.Snippet)
+		snippet = strings.ReplaceAll(snippet, snippetStart, "<mark>")
+		snippet = strings.ReplaceAll(snippet, snippetStop, "</mark>")
+		snippets[row.ID] = snippet
+	}
+	return snippets, nil
+}
+
+// SuggestByTitle returns up to limit problems whose titles contain words
+// starting with each word of prefix, best matches first.
+func (r *ProblemRepository) SuggestByTitle(prefix string, limit int, visibleAt *time.Time) ([]*domain.Problem, error) {
+	problems := []*domain.Problem{}
+
+	// Only letters and digits reach to_tsquery, so user input cannot break
+	// its syntax.
+	words := strings.FieldsFunc(strings.ToLower(prefix), func(c rune) bool {
+		return !unicode.IsLetter(c) && !unicode.IsDigit(c)
+	})
+	if len(words) == 0 {
+		return problems, nil
+	}
+	for i, w := range words {
+		words[i] = w + ":*"
+	}
+	tsquery := strings.Join(words, " & ")
+
+	query := r.db.Model(&domain.Problem{}).
+		Select("id, title, slug").
+		Where("to_tsvector('simple'::regconfig, title) @@ to_tsquery('simple'::regconfig, ?)", tsquery)
+	if visibleAt != nil {
+		query = query.Where(problemListed(r.db, *visibleAt))
+	}
+
+	err := query.
+		Order(clause.OrderBy{Expression: clause.Expr{
+			SQL:  "ts_rank(to_tsvector('simple'::regconfig, title), to_tsquery('simple'::regconfig, ?)) DESC, title ASC",
+			Vars: []interface{}{tsquery},
+		}}).
+		Limit(limit).
+		Find(&problems).Error
+	if err != nil {
+		return nil, err
+	}
+	return problems, nil
+}
+
+// refreshSearchTags copies the tag names of the given problems into
+// problems.search_tags, which feeds their full-text search vector.
+func refreshSearchTags(tx *gorm.DB, problemIDs interface{}) error {
+	return tx.Exec(`UPDATE problems SET search_tags = COALESCE((
+		SELECT string_agg(tags.name, ' ' ORDER BY tags.name)
+		FROM problem_tags JOIN tags ON tags.id = problem_tags.tag_id
+		WHERE problem_tags.problem_id = problems.id), '')
+		WHERE id IN (?)`, problemIDs).Error
+}
+
+// PublishDue publishes the drafts and problems in review whose PublishAt has
+// passed, returning how many were published.
+func (r *ProblemRepository) PublishDue(now time.Time) (int64, error) {
+	result := r.db.Model(&domain.Problem{}).
+		Where("status IN ? AND publish_at <= ?", []string{domain.ProblemStatusDraft, domain.ProblemStatusReview}, now).
+		Updates(map[string]interface{}{
+			"status":       domain.ProblemStatusPublished,
+			"published_at": gorm.Expr("publish_at"),
+			"publish_at":   nil,
+		})
+	return result.RowsAffected, result.Error
+}
+
+// problemListed is the condition for a problem to be listed publicly at the
+// given time: it is published, contest-only problems stay hidden until their
+// first contest starts, and problems of private contests stay unlisted until
+// those end.
+func problemListed(db *gorm.DB, at time.Time) *gorm.DB {
+	return db.
+		Where("status = ?", domain.ProblemStatusPublished).
+		Where("id NOT IN (?)", db.Model(&domain.ContestProblem{}).
+			Select("contest_problems.problem_id").
+			Joins("JOIN contests ON contests.id = contest_problems.contest_id").
+			Group("contest_problems.problem_id").
+			Having("MIN(contests.start_time) > ?", at)).
+		Where("id NOT IN (?)", db.Model(&domain.ContestProblem{}).
+			Select("contest_problems.problem_id").
+			Joins("JOIN contests ON contests.id = contest_problems.contest_id").
+			Where("contests.is_public = ? AND contests.end_time > ?", false, at))
+}
+
+// orderTagsByName sorts preloaded tags.
+func orderTagsByName(db *gorm.DB) *gorm.DB {
+	return db.Order("tags.name ASC")
+}
@@ -0,0 +1,183 @@
+package gorm
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+	"github.com/klaus-creations/klaus-judge/api/internal/repository"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestRestoreKeepsJudgedTestCases(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewProblemRepository(db)
+
+	user := &domain.User{Username: "setter", Email: "setter@example.com", PasswordHash: "x"}
+	mustCreate(t, db, user)
+	problem := &domain.Problem{Title: "Sum", Slug: "sum", Description: "Add two numbers.", CreatedBy: user.ID}
+	mustCreate(t, db, problem)
+	judged := &domain.TestCase{ProblemID: problem.ID, Input: "1 2", ExpectedOutput: "3", OrderIndex: 1}
+	unjudged := &domain.TestCase{ProblemID: problem.ID, Input: "2 2", ExpectedOutput: "4", OrderIndex: 2}
+	mustCreate(t, db, judged)
+	mustCreate(t, db, unjudged)
+	submission := &domain.Submission{UserID: user.ID, ProblemID: problem.ID, Code: "x", Language: "cpp", Verdict: domain.VerdictAC}
+	mustCreate(t, db, submission)
+	mustCreate(t, db, &domain.TestCaseResult{SubmissionID: submission.ID, TestCaseID: judged.ID, Verdict: domain.VerdictAC})
+
+	// Roll back to a revision where the judged test differed and the other
+	// one did not exist yet, but a third one did.
+	problem.Title = "Sum of two"
+	restoredID := uuid.New()
+	err := repo.Restore(problem, []*domain.TestCase{
+		{ID: judged.ID, Input: "1 1", ExpectedOutput: "2", OrderIndex: 1, IsHidden: false, Points: 0},
+		{ID: restoredID, Input: "5 5", ExpectedOutput: "10", OrderIndex: 2, IsHidden: true, Points: 5},
+	})
+	if err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+
+	var tests []domain.TestCase
+	if err := db.Order("order_index").Find(&tests, "problem_id = ?", problem.ID).Error; err != nil {
+		t.Fatalf("load test cases: %v", err)
+	}
+	if len(tests) != 2 || tests[0].ID != judged.ID || tests[1].ID != restoredID {
+		t.Fatalf("test cases after restore = %+v, want the judged and the restored one", tests)
+	}
+	if tests[0].Input != "1 1" || tests[0].IsHidden || tests[0].Points != 0 {
+		t.Errorf("judged test case = %+v, want it updated in place, zero values included", tests[0])
+	}
+	if !tests[1].IsHidden || tests[1].Points != 5 {
+		t.Errorf("restored test case = %+v, want hidden and worth 5 points", tests[1])
+	}
+	var results int64
+	db.Model(&domain.TestCaseResult{}).Where("test_case_id = ?", judged.ID).Count(&results)
+	if results != 1 {
+		t.Errorf("results of the judged test case = %d, want 1", results)
+	}
+
+	// A revision without the judged test case cannot drop it.
+	problem.Title = "Sum"
+	err = repo.Restore(problem, []*domain.TestCase{{ID: restoredID, Input: "5 5", ExpectedOutput: "10", OrderIndex: 1}})
+	if !errors.Is(err, repository.ErrTestCaseJudged) {
+		t.Fatalf("Restore without the judged test case: got %v, want ErrTestCaseJudged", err)
+	}
+	var stored domain.Problem
+	db.First(&stored, "id = ?", problem.ID)
+	if stored.Title != "Sum of two" {
+		t.Errorf("title after the failed restore = %q, want the change rolled back", stored.Title)
+	}
+}
+
+func TestDeleteProblemWithChildren(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewProblemRepository(db)
+
+	user := &domain.User{Username: "setter", Email: "setter@example.com", PasswordHash: "x"}
+	mustCreate(t, db, user)
+	problem := &domain.Problem{Title: "Sum", Slug: "sum", Description: "Add two numbers.", CreatedBy: user.ID}
+	mustCreate(t, db, problem)
+	first := &domain.TestGroup{ProblemID: problem.ID, Name: "small", Points: 40, OrderIndex: 1}
+	second := &domain.TestGroup{ProblemID: problem.ID, Name: "large", Points: 60, OrderIndex: 2}
+	mustCreate(t, db, first)
+	mustCreate(t, db, second)
+	mustCreate(t, db, &domain.TestGroupDependency{GroupID: second.ID, DependsOnID: first.ID})
+	mustCreate(t, db, &domain.TestCase{ProblemID: problem.ID, GroupID: &first.ID, Input: "1 2", ExpectedOutput: "3", OrderIndex: 1})
+	mustCreate(t, db, &domain.ProblemChecker{ProblemID: problem.ID, Language: "cpp", Code: "x", CreatedBy: user.ID})
+	mustCreate(t, db, &domain.ProblemInteractor{ProblemID: problem.ID, Language: "cpp", Code: "x", CreatedBy: user.ID})
+	mustCreate(t, db, &domain.ProblemStatement{ProblemID: problem.ID, Locale: "fr", Title: "Somme", Description: "x"})
+	mustCreate(t, db, &domain.ProblemCollaborator{ProblemID: problem.ID, UserID: user.ID})
+	editorial := &domain.ProblemEditorial{ProblemID: problem.ID, Content: "x", AuthorID: user.ID}
+	mustCreate(t, db, editorial)
+	mustCreate(t, db, &domain.EditorialSolution{EditorialID: editorial.ID, Language: "cpp", Code: "x"})
+
+	if err := repo.Delete(problem.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	for _, model := range []interface{}{
+		&domain.Problem{}, &domain.TestGroup{}, &domain.TestGroupDependency{}, &domain.TestCase{},
+		&domain.ProblemChecker{}, &domain.ProblemInteractor{}, &domain.ProblemStatement{},
+		&domain.ProblemCollaborator{}, &domain.ProblemEditorial{}, &domain.EditorialSolution{},
+	} {
+		var count int64
+		db.Model(model).Count(&count)
+		if count != 0 {
+			t.Errorf("%T rows after delete = %d, want 0", model, count)
+		}
+	}
+
+	// A problem with submissions stays.
+	solved := &domain.Problem{Title: "Product", Slug: "product", Description: "Multiply two numbers.", CreatedBy: user.ID}
+	mustCreate(t, db, solved)
+	mustCreate(t, db, &domain.Submission{UserID: user.ID, ProblemID: solved.ID, Code: "x", Language: "cpp"})
+	if err := repo.Delete(solved.ID); !errors.Is(err, repository.ErrProblemInUse) {
+		t.Fatalf("Delete of a problem with submissions: got %v, want ErrProblemInUse", err)
+	}
+}
+
+// newTestDB opens an in-memory SQLite database with foreign keys enforced
+// and the tables of problems, contests and their submissions.
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open("file::memory:?_foreign_keys=on"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("sql db: %v", err)
+	}
+	// Every connection would get its own in-memory database.
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	if err := db.SetupJoinTable(&domain.Problem{}, "Tags", &domain.ProblemTag{}); err != nil {
+		t.Fatalf("join table: %v", err)
+	}
+	err = db.AutoMigrate(
+		&domain.User{},
+		&domain.Problem{},
+		&domain.TestGroup{},
+		&domain.TestGroupDependency{},
+		&domain.TestCase{},
+		&domain.ProblemChecker{},
+		&domain.ProblemInteractor{},
+		&domain.ProblemStatement{},
+		&domain.ProblemCollaborator{},
+		&domain.ProblemRevision{},
+		&domain.ProblemAttachment{},
+		&domain.ProblemEditorial{},
+		&domain.EditorialSolution{},
+		&domain.Submission{},
+		&domain.TestCaseResult{},
+		&domain.Contest{},
+		&domain.ContestProblem{},
+		&domain.Team{},
+		&domain.TeamMember{},
+		&domain.TeamInvite{},
+		&domain.ContestParticipant{},
+		&domain.ContestInvite{},
+		&domain.ContestAllowedUser{},
+		&domain.Clarification{},
+		&domain.VirtualParticipation{},
+		&domain.RatingChange{},
+	)
+	if err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	// Added by config.Migrate on Postgres, next to the search vector.
+	if err := db.Exec(`ALTER TABLE problems ADD COLUMN search_tags text NOT NULL DEFAULT ''`).Error; err != nil {
+		t.Fatalf("search tags column: %v", err)
+	}
+	return db
+}
+
+func mustCreate(t *testing.T, db *gorm.DB, value interface{}) {
+	t.Helper()
+	if err := db.Create(value).Error; err != nil {
+		t.Fatalf("create %T: %v", value, err)
+	}
+}
@@ -0,0 +1,42 @@
+package gorm
+
+import (
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+	"gorm.io/gorm"
+)
+
+// ProblemInteractorRepository implements the ProblemInteractorRepository interface using GORM.
+type ProblemInteractorRepository struct {
+	db *gorm.DB
+}
+
+// NewProblemInteractorRepository creates a new GORM-based problem interactor repository.
+func NewProblemInteractorRepository(db *gorm.DB) *ProblemInteractorRepository {
+	return &ProblemInteractorRepository{db: db}
+}
+
+// Create inserts a new interactor.
+func (r *ProblemInteractorRepository) Create(interactor *domain.ProblemInteractor) error {
+	return r.db.Create(interactor).Error
+}
+
+// FindByProblemID retrieves the interactor of a problem.
+func (r *ProblemInteractorRepository) FindByProblemID(problemID uuid.UUID) (*domain.ProblemInteractor, error) {
+	var interactor domain.ProblemInteractor
+	err := r.db.Where("problem_id = ?", problemID).First(&interactor).Error
+	if err != nil {
+		return nil, err
+	}
+	return &interactor, nil
+}
+
+// Update updates an existing interactor.
+func (r *ProblemInteractorRepository) Update(interactor *domain.ProblemInteractor) error {
+	return r.db.Save(interactor).Error
+}
+
+// DeleteByProblemID removes the interactor of a problem.
+func (r *ProblemInteractorRepository) DeleteByProblemID(problemID uuid.UUID) error {
+	return r.db.Delete(&domain.ProblemInteractor{}, "problem_id = ?", problemID).Error
+}
@@ -0,0 +1,68 @@
+package gorm
+
+import (
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ProblemRevisionRepository implements the ProblemRevisionRepository interface using GORM.
+type ProblemRevisionRepository struct {
+	db *gorm.DB
+}
+
+// NewProblemRevisionRepository creates a new GORM-based problem revision repository.
+func NewProblemRevisionRepository(db *gorm.DB) *ProblemRevisionRepository {
+	return &ProblemRevisionRepository{db: db}
+}
+
+// Create stores the test set unless it is already known, numbers the revision
+// after the problem's latest one and inserts it. Bumping problems.revision
+// locks the problem row, so concurrent edits get consecutive numbers.
+func (r *ProblemRevisionRepository) Create(revision *domain.ProblemRevision, testSet *domain.ProblemTestSet) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(testSet).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&domain.Problem{}).Where("id = ?", revision.ProblemID).
+			UpdateColumn("revision", gorm.Expr("revision + 1")).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&domain.Problem{}).Where("id = ?", revision.ProblemID).
+			Select("revision").Scan(&revision.Number).Error; err != nil {
+			return err
+		}
+		return tx.Create(revision).Error
+	})
+}
+
+// FindByProblemID retrieves the revisions of a problem, newest first.
+func (r *ProblemRevisionRepository) FindByProblemID(problemID uuid.UUID) ([]*domain.ProblemRevision, error) {
+	var revisions []*domain.ProblemRevision
+	err := r.db.Where("problem_id = ?", problemID).Order("number DESC").Find(&revisions).Error
+	if err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+// FindByNumber retrieves one revision of a problem.
+func (r *ProblemRevisionRepository) FindByNumber(problemID uuid.UUID, number int) (*domain.ProblemRevision, error) {
+	var revision domain.ProblemRevision
+	err := r.db.Where("problem_id = ? AND number = ?", problemID, number).First(&revision).Error
+	if err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
+
+// FindTestSet retrieves a test set snapshot by hash.
+func (r *ProblemRevisionRepository) FindTestSet(hash string) (*domain.ProblemTestSet, error) {
+	var testSet domain.ProblemTestSet
+	err := r.db.First(&testSet, "hash = ?", hash).Error
+	if err != nil {
+		return nil, err
+	}
+	return &testSet, nil
+}
@@ -0,0 +1,65 @@
+package gorm
+
+import (
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+	"gorm.io/gorm"
+)
+
+// ProblemStatementRepository implements the ProblemStatementRepository interface using GORM.
+type ProblemStatementRepository struct {
+	db *gorm.DB
+}
+
+// NewProblemStatementRepository creates a new GORM-based problem statement repository.
+func NewProblemStatementRepository(db *gorm.DB) *ProblemStatementRepository {
+	return &ProblemStatementRepository{db: db}
+}
+
+// Create inserts a new translation.
+func (r *ProblemStatementRepository) Create(statement *domain.ProblemStatement) error {
+	return r.db.Create(statement).Error
+}
+
+// FindByProblemID retrieves all translations of a problem ordered by locale.
+func (r *ProblemStatementRepository) FindByProblemID(problemID uuid.UUID) ([]*domain.ProblemStatement, error) {
+	var statements []*domain.ProblemStatement
+	err := r.db.Where("problem_id = ?", problemID).Order("locale ASC").Find(&statements).Error
+	if err != nil {
+		return nil, err
+	}
+	return statements, nil
+}
+
+// FindByProblemAndLocale retrieves the translation of a problem into one locale.
+func (r *ProblemStatementRepository) FindByProblemAndLocale(problemID uuid.UUID, locale string) (*domain.ProblemStatement, error) {
+	var statement domain.ProblemStatement
+	err := r.db.Where("problem_id = ? AND locale = ?", problemID, locale).First(&statement).Error
+	if err != nil {
+		return nil, err
+	}
+	return &statement, nil
+}
+
+// FindTitles retrieves the translated titles of several problems at once.
+func (r *ProblemStatementRepository) FindTitles(problemIDs []uuid.UUID) ([]*domain.ProblemStatement, error) {
+	var statements []*domain.ProblemStatement
+	if len(problemIDs) == 0 {
+		return statements, nil
+	}
+	err := r.db.Select("problem_id", "locale", "title").Where("problem_id IN ?", problemIDs).Find(&statements).Error
+	if err != nil {
+		return nil, err
+	}
+	return statements, nil
+}
+
+// Update updates a translation.
+func (r *ProblemStatementRepository) Update(statement *domain.ProblemStatement) error {
+	return r.db.Save(statement).Error
+}
+
+// Delete removes a translation by ID.
+func (r *ProblemStatementRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&domain.ProblemStatement{}, "id = ?", id).Error
+}
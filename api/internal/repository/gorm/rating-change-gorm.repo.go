@@ -0,0 +1,51 @@
+package gorm
+
+import (
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+	"gorm.io/gorm"
+)
+
+// RatingChangeRepository implements the RatingChangeRepository interface using GORM.
+type RatingChangeRepository struct {
+	db *gorm.DB
+}
+
+// NewRatingChangeRepository creates a new GORM-based rating change repository.
+func NewRatingChangeRepository(db *gorm.DB) *RatingChangeRepository {
+	return &RatingChangeRepository{db: db}
+}
+
+// ApplyContest stores the rating changes of a contest and updates user ratings.
+func (r *RatingChangeRepository) ApplyContest(contestID uuid.UUID, changes []*domain.RatingChange) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if len(changes) > 0 {
+			if err := tx.Create(&changes).Error; err != nil {
+				return err
+			}
+		}
+		for _, change := range changes {
+			err := tx.Model(&domain.User{}).Where("id = ?", change.UserID).
+				UpdateColumn("rating", change.NewRating).Error
+			if err != nil {
+				return err
+			}
+		}
+		return tx.Model(&domain.Contest{}).Where("id = ?", contestID).
+			UpdateColumn("ratings_applied", true).Error
+	})
+}
+
+// FindByUserID retrieves a user's rating history in contest order.
+func (r *RatingChangeRepository) FindByUserID(userID uuid.UUID) ([]*domain.RatingChange, error) {
+	var changes []*domain.RatingChange
+	err := r.db.Preload("Contest").
+		Joins("JOIN contests ON contests.id = rating_changes.contest_id").
+		Where("rating_changes.user_id = ?", userID).
+		Order("contests.end_time ASC").
+		Find(&changes).Error
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/klaus-creations/klaus-judge/api/internal/domain"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // SubmissionRepository implements the SubmissionRepository interface using GORM.
@@ -26,7 +27,7 @@ func (r *SubmissionRepository) FindByID(id uuid.UUID) (*domain.Submission, error
 	var submission domain.Submission
 	err := r.db.Preload("TestResults").First(&submission, "id = ?", id).Error
 	if err != nil {
-		return nil, err
+		return nil, translateError(err)
 	}
 	return &submission, nil
 }
@@ -56,12 +57,24 @@ func (r *SubmissionRepository) FindAll(pagination *domain.Pagination, filters *d
 
 	query := r.db.Model(&domain.Submission{})
 	if filters != nil {
+		if filters.UserID != uuid.Nil {
+			query = query.Where("user_id = ?", filters.UserID)
+		}
+		if filters.TeamID != uuid.Nil {
+			query = query.Where("team_id = ?", filters.TeamID)
+		}
 		if filters.ProblemID != uuid.Nil {
 			query = query.Where("problem_id = ?", filters.ProblemID)
 		}
 		if filters.Verdict != "" {
 			query = query.Where("verdict = ?", filters.Verdict)
 		}
+		if filters.ContestID != uuid.Nil {
+			query = query.Where("contest_id = ? AND is_virtual = ?", filters.ContestID, false)
+		}
+		if filters.SubmittedBefore != nil {
+			query = query.Where("submitted_at < ?", *filters.SubmittedBefore)
+		}
 	}
 
 	err := query.Count(&total).Error
@@ -76,7 +89,67 @@ func (r *SubmissionRepository) FindAll(pagination *domain.Pagination, filters *d
 	return submissions, total, nil
 }
 
+// FindByContestID retrieves the submissions tagged with a contest in submission order.
+// Virtual submissions are only returned when filters.IsVirtual is set.
+func (r *SubmissionRepository) FindByContestID(contestID uuid.UUID, filters *domain.SubmissionFilters) ([]*domain.Submission, error) {
+	var submissions []*domain.Submission
+
+	query := r.db.Where("contest_id = ?", contestID)
+	if filters == nil {
+		query = query.Where("is_virtual = ?", false)
+	} else {
+		query = query.Where("is_virtual = ?", filters.IsVirtual)
+		if filters.UserID != uuid.Nil {
+			query = query.Where("user_id = ?", filters.UserID)
+		}
+		if filters.TeamID != uuid.Nil {
+			query = query.Where("team_id = ?", filters.TeamID)
+		}
+		if filters.ProblemID != uuid.Nil {
+			query = query.Where("problem_id = ?", filters.ProblemID)
+		}
+		if filters.Verdict != "" {
+			query = query.Where("verdict = ?", filters.Verdict)
+		}
+	}
+
+	err := query.Order("submitted_at ASC").Find(&submissions).Error
+	if err != nil {
+		return nil, err
+	}
+	return submissions, nil
+}
+
+// HasAccepted reports whether a user has an accepted submission for a problem.
+func (r *SubmissionRepository) HasAccepted(userID uuid.UUID, problemID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.Model(&domain.Submission{}).
+		Where("user_id = ? AND problem_id = ? AND verdict = ?", userID, problemID, domain.VerdictAC).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
 // Update updates a submission.
 func (r *SubmissionRepository) Update(submission *domain.Submission) error {
 	return r.db.Save(submission).Error
 }
+
+// SaveJudgement stores the outcome of judging a submission, replacing any
+// test results of an earlier judging.
+func (r *SubmissionRepository) SaveJudgement(submission *domain.Submission, results []*domain.TestCaseResult) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(submission).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("submission_id = ?", submission.ID).Delete(&domain.TestCaseResult{}).Error; err != nil {
+			return err
+		}
+		if len(results) == 0 {
+			return nil
+		}
+		return tx.Create(&results).Error
+	})
+}
@@ -0,0 +1,124 @@
+package gorm
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TagRepository implements the TagRepository interface using GORM.
+type TagRepository struct {
+	db *gorm.DB
+}
+
+// NewTagRepository creates a new GORM-based tag repository.
+func NewTagRepository(db *gorm.DB) *TagRepository {
+	return &TagRepository{db: db}
+}
+
+// FindOrCreate returns the tags with the given names ordered by name, creating
+// the missing ones.
+func (r *TagRepository) FindOrCreate(names []string) ([]domain.Tag, error) {
+	tags := []domain.Tag{}
+	if len(names) == 0 {
+		return tags, nil
+	}
+
+	missing := make([]domain.Tag, 0, len(names))
+	for _, name := range names {
+		missing = append(missing, domain.Tag{Name: name})
+	}
+	err := r.db.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "name"}}, DoNothing: true}).
+		Create(&missing).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.db.Where("name IN ?", names).Order("name ASC").Find(&tags).Error
+	if err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// FindByID retrieves a tag by ID.
+func (r *TagRepository) FindByID(id uuid.UUID) (*domain.Tag, error) {
+	var tag domain.Tag
+	err := r.db.First(&tag, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// FindByName retrieves a tag by its normalized name.
+func (r *TagRepository) FindByName(name string) (*domain.Tag, error) {
+	var tag domain.Tag
+	err := r.db.Where("name = ?", name).First(&tag).Error
+	if err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// FindAllWithCounts lists tags by name with their number of problems.
+func (r *TagRepository) FindAllWithCounts(visibleAt *time.Time) ([]*domain.TagCount, error) {
+	var counts []*domain.TagCount
+
+	join := r.db.Table("problem_tags").Select("problem_tags.tag_id, problem_tags.problem_id")
+	if visibleAt != nil {
+		join = join.Where("problem_tags.problem_id IN (?)",
+			r.db.Model(&domain.Problem{}).Select("id").Where(problemListed(r.db, *visibleAt)))
+	}
+
+	err := r.db.Model(&domain.Tag{}).
+		Select("tags.*, COUNT(pt.problem_id) AS problem_count").
+		Joins("LEFT JOIN (?) AS pt ON pt.tag_id = tags.id", join).
+		Group("tags.id").
+		Order("tags.name ASC").
+		Scan(&counts).Error
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// Update updates a tag and the search text of its problems.
+func (r *TagRepository) Update(tag *domain.Tag) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(tag).Error; err != nil {
+			return err
+		}
+		return refreshSearchTags(tx, tx.Model(&domain.ProblemTag{}).Select("problem_id").Where("tag_id = ?", tag.ID))
+	})
+}
+
+// Merge moves every problem of one tag to another and deletes the first.
+func (r *TagRepository) Merge(fromID, intoID uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var problemIDs []uuid.UUID
+		if err := tx.Model(&domain.ProblemTag{}).Where("tag_id = ?", fromID).Pluck("problem_id", &problemIDs).Error; err != nil {
+			return err
+		}
+		if len(problemIDs) > 0 {
+			links := make([]domain.ProblemTag, 0, len(problemIDs))
+			for _, id := range problemIDs {
+				links = append(links, domain.ProblemTag{ProblemID: id, TagID: intoID})
+			}
+			// Problems that already carry both tags keep a single link.
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&links).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Delete(&domain.ProblemTag{}, "tag_id = ?", fromID).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&domain.Tag{}, "id = ?", fromID).Error; err != nil {
+			return err
+		}
+		return refreshSearchTags(tx, problemIDs)
+	})
+}
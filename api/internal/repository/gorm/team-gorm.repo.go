@@ -0,0 +1,91 @@
+package gorm
+
+import (
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+	"gorm.io/gorm"
+)
+
+// TeamRepository implements the TeamRepository interface using GORM.
+type TeamRepository struct {
+	db *gorm.DB
+}
+
+// NewTeamRepository creates a new GORM-based team repository.
+func NewTeamRepository(db *gorm.DB) *TeamRepository {
+	return &TeamRepository{db: db}
+}
+
+// Create inserts a team and its members.
+func (r *TeamRepository) Create(team *domain.Team) error {
+	return r.db.Create(team).Error
+}
+
+// FindByID retrieves a team with its members.
+func (r *TeamRepository) FindByID(id uuid.UUID) (*domain.Team, error) {
+	var team domain.Team
+	err := r.db.Preload("Members", func(db *gorm.DB) *gorm.DB {
+		return db.Order("joined_at ASC")
+	}).Preload("Members.User").First(&team, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &team, nil
+}
+
+// FindByContestID retrieves the teams of a contest ordered by name.
+func (r *TeamRepository) FindByContestID(contestID uuid.UUID) ([]*domain.Team, error) {
+	var teams []*domain.Team
+	err := r.db.Preload("Members", func(db *gorm.DB) *gorm.DB {
+		return db.Order("joined_at ASC")
+	}).Preload("Members.User").Where("contest_id = ?", contestID).Order("name ASC").Find(&teams).Error
+	if err != nil {
+		return nil, err
+	}
+	return teams, nil
+}
+
+// FindByContestAndMember retrieves the team a user belongs to in a contest.
+func (r *TeamRepository) FindByContestAndMember(contestID uuid.UUID, userID uuid.UUID) (*domain.Team, error) {
+	var member domain.TeamMember
+	err := r.db.Where("contest_id = ? AND user_id = ?", contestID, userID).First(&member).Error
+	if err != nil {
+		return nil, err
+	}
+	return r.FindByID(member.TeamID)
+}
+
+// Update updates a team.
+func (r *TeamRepository) Update(team *domain.Team) error {
+	return r.db.Omit("Members").Save(team).Error
+}
+
+// Delete removes a team, its members and its pending invites.
+func (r *TeamRepository) Delete(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&domain.TeamInvite{}, "team_id = ?", id).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&domain.TeamMember{}, "team_id = ?", id).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.Team{}, "id = ?", id).Error
+	})
+}
+
+// AddMember adds a user to a team.
+func (r *TeamRepository) AddMember(member *domain.TeamMember) error {
+	return r.db.Create(member).Error
+}
+
+// RemoveMember removes a user from a team.
+func (r *TeamRepository) RemoveMember(teamID uuid.UUID, userID uuid.UUID) error {
+	return r.db.Delete(&domain.TeamMember{}, "team_id = ? AND user_id = ?", teamID, userID).Error
+}
+
+// CountMembers counts the members of a team.
+func (r *TeamRepository) CountMembers(teamID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.Model(&domain.TeamMember{}).Where("team_id = ?", teamID).Count(&count).Error
+	return count, err
+}
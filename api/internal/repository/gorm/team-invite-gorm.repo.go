@@ -0,0 +1,51 @@
+package gorm
+
+import (
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+	"gorm.io/gorm"
+)
+
+// TeamInviteRepository implements the TeamInviteRepository interface using GORM.
+type TeamInviteRepository struct {
+	db *gorm.DB
+}
+
+// NewTeamInviteRepository creates a new GORM-based team invite repository.
+func NewTeamInviteRepository(db *gorm.DB) *TeamInviteRepository {
+	return &TeamInviteRepository{db: db}
+}
+
+// Create inserts a new invite.
+func (r *TeamInviteRepository) Create(invite *domain.TeamInvite) error {
+	return r.db.Create(invite).Error
+}
+
+// FindByTeamAndUser retrieves a user's pending invite to a team.
+func (r *TeamInviteRepository) FindByTeamAndUser(teamID uuid.UUID, userID uuid.UUID) (*domain.TeamInvite, error) {
+	var invite domain.TeamInvite
+	err := r.db.Where("team_id = ? AND user_id = ?", teamID, userID).First(&invite).Error
+	if err != nil {
+		return nil, err
+	}
+	return &invite, nil
+}
+
+// FindByContestAndUser retrieves a user's pending invites for a contest.
+func (r *TeamInviteRepository) FindByContestAndUser(contestID uuid.UUID, userID uuid.UUID) ([]*domain.TeamInvite, error) {
+	var invites []*domain.TeamInvite
+	err := r.db.Preload("Team").
+		Joins("JOIN teams ON teams.id = team_invites.team_id").
+		Where("teams.contest_id = ? AND team_invites.user_id = ?", contestID, userID).
+		Order("team_invites.created_at DESC").
+		Find(&invites).Error
+	if err != nil {
+		return nil, err
+	}
+	return invites, nil
+}
+
+// Delete removes an invite by ID.
+func (r *TeamInviteRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&domain.TeamInvite{}, "id = ?", id).Error
+}
@@ -0,0 +1,74 @@
+package gorm
+
+import (
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+	"gorm.io/gorm"
+)
+
+// TestGroupRepository implements the TestGroupRepository interface using GORM.
+type TestGroupRepository struct {
+	db *gorm.DB
+}
+
+// NewTestGroupRepository creates a new GORM-based test group repository.
+func NewTestGroupRepository(db *gorm.DB) *TestGroupRepository {
+	return &TestGroupRepository{db: db}
+}
+
+// Create inserts a new group together with its dependencies.
+func (r *TestGroupRepository) Create(group *domain.TestGroup) error {
+	return r.db.Create(group).Error
+}
+
+// FindByID retrieves a group by ID.
+func (r *TestGroupRepository) FindByID(id uuid.UUID) (*domain.TestGroup, error) {
+	var group domain.TestGroup
+	err := r.db.Preload("Dependencies").First(&group, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &group, nil
+}
+
+// FindByProblemID retrieves the groups of a problem in order.
+func (r *TestGroupRepository) FindByProblemID(problemID uuid.UUID) ([]*domain.TestGroup, error) {
+	var groups []*domain.TestGroup
+	err := r.db.Preload("Dependencies").Where("problem_id = ?", problemID).Order("order_index ASC").Find(&groups).Error
+	if err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+// Update saves the group and replaces its dependencies.
+func (r *TestGroupRepository) Update(group *domain.TestGroup) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Dependencies").Save(group).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&domain.TestGroupDependency{}, "group_id = ?", group.ID).Error; err != nil {
+			return err
+		}
+		for i := range group.Dependencies {
+			group.Dependencies[i].GroupID = group.ID
+		}
+		if len(group.Dependencies) > 0 {
+			return tx.Create(&group.Dependencies).Error
+		}
+		return nil
+	})
+}
+
+// Delete removes the group, its dependency edges and its test case assignments.
+func (r *TestGroupRepository) Delete(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&domain.TestCase{}).Where("group_id = ?", id).Update("group_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&domain.TestGroupDependency{}, "group_id = ? OR depends_on_id = ?", id, id).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.TestGroup{}, "id = ?", id).Error
+	})
+}
@@ -0,0 +1,55 @@
+package gorm
+
+import (
+	"github.com/klaus-creations/klaus-judge/api/internal/repository"
+	"gorm.io/gorm"
+)
+
+// ProblemEditTransactor implements the ProblemEditTransactor interface using GORM.
+type ProblemEditTransactor struct {
+	db *gorm.DB
+}
+
+// NewProblemEditTransactor creates a new GORM-based problem edit transactor.
+func NewProblemEditTransactor(db *gorm.DB) *ProblemEditTransactor {
+	return &ProblemEditTransactor{db: db}
+}
+
+// WithinTransaction runs fn with repositories bound to one transaction.
+// Repository methods that open their own transaction nest into it as
+// savepoints.
+func (t *ProblemEditTransactor) WithinTransaction(fn func(repos *repository.ProblemEditRepositories) error) error {
+	return t.db.Transaction(func(tx *gorm.DB) error {
+		return fn(&repository.ProblemEditRepositories{
+			Problems:    NewProblemRepository(tx),
+			TestCases:   NewTestCaseRepository(tx),
+			TestGroups:  NewTestGroupRepository(tx),
+			Checkers:    NewProblemCheckerRepository(tx),
+			Interactors: NewProblemInteractorRepository(tx),
+			Statements:  NewProblemStatementRepository(tx),
+			Revisions:   NewProblemRevisionRepository(tx),
+		})
+	})
+}
+
+// ContestRegistrationTransactor implements the ContestRegistrationTransactor
+// interface using GORM.
+type ContestRegistrationTransactor struct {
+	db *gorm.DB
+}
+
+// NewContestRegistrationTransactor creates a new GORM-based contest
+// registration transactor.
+func NewContestRegistrationTransactor(db *gorm.DB) *ContestRegistrationTransactor {
+	return &ContestRegistrationTransactor{db: db}
+}
+
+// WithinTransaction runs fn with repositories bound to one transaction.
+func (t *ContestRegistrationTransactor) WithinTransaction(fn func(repos *repository.ContestRegistrationRepositories) error) error {
+	return t.db.Transaction(func(tx *gorm.DB) error {
+		return fn(&repository.ContestRegistrationRepositories{
+			Participants: NewContestParticipantRepository(tx),
+			Invites:      NewContestInviteRepository(tx),
+		})
+	})
+}
@@ -0,0 +1,44 @@
+package gorm
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+	"gorm.io/gorm"
+)
+
+// VirtualParticipationRepository implements the VirtualParticipationRepository interface using GORM.
+type VirtualParticipationRepository struct {
+	db *gorm.DB
+}
+
+// NewVirtualParticipationRepository creates a new GORM-based virtual participation repository.
+func NewVirtualParticipationRepository(db *gorm.DB) *VirtualParticipationRepository {
+	return &VirtualParticipationRepository{db: db}
+}
+
+// Create starts a virtual participation.
+func (r *VirtualParticipationRepository) Create(participation *domain.VirtualParticipation) error {
+	return r.db.Create(participation).Error
+}
+
+// FindByContestAndUser retrieves a user's virtual participation in a contest.
+func (r *VirtualParticipationRepository) FindByContestAndUser(contestID uuid.UUID, userID uuid.UUID) (*domain.VirtualParticipation, error) {
+	var participation domain.VirtualParticipation
+	err := r.db.Preload("User").Where("contest_id = ? AND user_id = ?", contestID, userID).First(&participation).Error
+	if err != nil {
+		return nil, err
+	}
+	return &participation, nil
+}
+
+// FindActiveByUserID retrieves the virtual participations of a user running at the given time.
+func (r *VirtualParticipationRepository) FindActiveByUserID(userID uuid.UUID, at time.Time) ([]*domain.VirtualParticipation, error) {
+	var participations []*domain.VirtualParticipation
+	err := r.db.Where("user_id = ? AND started_at <= ? AND ends_at > ?", userID, at, at).Find(&participations).Error
+	if err != nil {
+		return nil, err
+	}
+	return participations, nil
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+)
+
+// ProblemAttachmentRepository defines the interface for problem attachment operations.
+type ProblemAttachmentRepository interface {
+	Create(attachment *domain.ProblemAttachment) error
+	FindByID(id uuid.UUID) (*domain.ProblemAttachment, error)
+	FindByProblemID(problemID uuid.UUID) ([]*domain.ProblemAttachment, error)
+	Delete(id uuid.UUID) error
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+)
+
+// ProblemCheckerRepository defines the interface for problem checker operations.
+type ProblemCheckerRepository interface {
+	Create(checker *domain.ProblemChecker) error
+	FindByProblemID(problemID uuid.UUID) (*domain.ProblemChecker, error)
+	Update(checker *domain.ProblemChecker) error
+	DeleteByProblemID(problemID uuid.UUID) error
+}
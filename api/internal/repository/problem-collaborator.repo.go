@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+)
+
+// ProblemCollaboratorRepository defines the interface for problem collaborator operations.
+type ProblemCollaboratorRepository interface {
+	Create(collaborator *domain.ProblemCollaborator) error
+	FindByProblemAndUser(problemID uuid.UUID, userID uuid.UUID) (*domain.ProblemCollaborator, error)
+	// FindByProblemID lists the collaborators of a problem with their users,
+	// in the order they were added.
+	FindByProblemID(problemID uuid.UUID) ([]*domain.ProblemCollaborator, error)
+	Update(collaborator *domain.ProblemCollaborator) error
+	Delete(id uuid.UUID) error
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+)
+
+// ProblemEditorialRepository defines the interface for problem editorial operations.
+type ProblemEditorialRepository interface {
+	// Save creates or replaces the editorial of a problem together with its
+	// reference solutions.
+	Save(editorial *domain.ProblemEditorial) error
+	// FindByProblemID retrieves the editorial of a problem with its solutions.
+	FindByProblemID(problemID uuid.UUID) (*domain.ProblemEditorial, error)
+	Delete(id uuid.UUID) error
+}
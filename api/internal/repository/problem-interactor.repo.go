@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+)
+
+// ProblemInteractorRepository defines the interface for problem interactor operations.
+type ProblemInteractorRepository interface {
+	Create(interactor *domain.ProblemInteractor) error
+	FindByProblemID(problemID uuid.UUID) (*domain.ProblemInteractor, error)
+	Update(interactor *domain.ProblemInteractor) error
+	DeleteByProblemID(problemID uuid.UUID) error
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+)
+
+// ProblemRevisionRepository defines the interface for problem revision operations.
+type ProblemRevisionRepository interface {
+	// Create stores the test set unless it is already known, numbers the
+	// revision after the problem's latest one and inserts it.
+	Create(revision *domain.ProblemRevision, testSet *domain.ProblemTestSet) error
+	FindByProblemID(problemID uuid.UUID) ([]*domain.ProblemRevision, error)
+	FindByNumber(problemID uuid.UUID, number int) (*domain.ProblemRevision, error)
+	FindTestSet(hash string) (*domain.ProblemTestSet, error)
+}
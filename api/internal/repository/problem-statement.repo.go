@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+)
+
+// ProblemStatementRepository defines the interface for problem statement translation operations.
+type ProblemStatementRepository interface {
+	Create(statement *domain.ProblemStatement) error
+	FindByProblemID(problemID uuid.UUID) ([]*domain.ProblemStatement, error)
+	FindByProblemAndLocale(problemID uuid.UUID, locale string) (*domain.ProblemStatement, error)
+	// FindTitles returns the translations of the given problems with only
+	// ProblemID, Locale and Title loaded.
+	FindTitles(problemIDs []uuid.UUID) ([]*domain.ProblemStatement, error)
+	Update(statement *domain.ProblemStatement) error
+	Delete(id uuid.UUID) error
+}
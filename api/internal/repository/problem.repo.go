@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/klaus-creations/klaus-judge/api/internal/domain"
 )
@@ -8,11 +10,29 @@ import (
 // ProblemRepository defines the interface for problem data operations.
 type ProblemRepository interface {
 	Create(problem *domain.Problem) error
+	// CreateWithTestCases inserts a problem and its test cases in one transaction.
+	CreateWithTestCases(problem *domain.Problem, testCases []*domain.TestCase) error
 	FindByID(id uuid.UUID) (*domain.Problem, error)
 	FindBySlug(slug string) (*domain.Problem, error)
 	FindAll(pagination *domain.Pagination, filters *domain.ProblemFilters) ([]*domain.Problem, int64, error)
+	// Snippets returns highlighted statement excerpts of the given problems
+	// matching a full-text query, keyed by problem ID.
+	Snippets(ids []uuid.UUID, query string) (map[uuid.UUID]string, error)
+	// SuggestByTitle returns problems whose title words start with the words
+	// of prefix, selecting only their ID, title and slug.
+	SuggestByTitle(prefix string, limit int, visibleAt *time.Time) ([]*domain.Problem, error)
 	Update(problem *domain.Problem) error
+	// Restore saves the problem and brings its test cases to the given set in
+	// one transaction: listed test cases are updated in place or recreated by
+	// ID, others are removed. Removing a test case with judged results fails
+	// with ErrTestCaseJudged.
+	Restore(problem *domain.Problem, testCases []*domain.TestCase) error
+	// Delete removes a problem and everything that belongs to it; problems
+	// with submissions or in a contest fail with ErrProblemInUse.
 	Delete(id uuid.UUID) error
+	// PublishDue publishes the unpublished problems whose PublishAt has
+	// passed, returning how many were published.
+	PublishDue(now time.Time) (int64, error)
 	IncrementAcceptedCount(id uuid.UUID) error
 	IncrementSubmissionCount(id uuid.UUID) error
 }
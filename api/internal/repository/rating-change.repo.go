@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+)
+
+// RatingChangeRepository defines the interface for rating history operations.
+type RatingChangeRepository interface {
+	// ApplyContest stores the rating changes of a contest, updates the users'
+	// ratings and marks the contest as rated, all in one transaction.
+	ApplyContest(contestID uuid.UUID, changes []*domain.RatingChange) error
+	FindByUserID(userID uuid.UUID) ([]*domain.RatingChange, error)
+}
@@ -11,5 +11,10 @@ type SubmissionRepository interface {
 	FindByID(id uuid.UUID) (*domain.Submission, error)
 	FindByUserID(userID uuid.UUID, pagination *domain.Pagination) ([]*domain.Submission, int64, error)
 	FindAll(pagination *domain.Pagination, filters *domain.SubmissionFilters) ([]*domain.Submission, int64, error)
+	FindByContestID(contestID uuid.UUID, filters *domain.SubmissionFilters) ([]*domain.Submission, error)
+	// HasAccepted reports whether a user has an accepted submission for a problem.
+	HasAccepted(userID uuid.UUID, problemID uuid.UUID) (bool, error)
 	Update(submission *domain.Submission) error
+	// SaveJudgement stores a judged submission and replaces its test results.
+	SaveJudgement(submission *domain.Submission, results []*domain.TestCaseResult) error
 }
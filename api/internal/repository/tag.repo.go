@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+)
+
+// TagRepository defines the interface for problem tag operations.
+type TagRepository interface {
+	// FindOrCreate returns the tags with the given normalized names, creating
+	// the missing ones.
+	FindOrCreate(names []string) ([]domain.Tag, error)
+	FindByID(id uuid.UUID) (*domain.Tag, error)
+	FindByName(name string) (*domain.Tag, error)
+	// FindAllWithCounts lists tags by name with their number of problems,
+	// counting only problems listed at visibleAt when it is set.
+	FindAllWithCounts(visibleAt *time.Time) ([]*domain.TagCount, error)
+	Update(tag *domain.Tag) error
+	// Merge moves every problem of one tag to another and deletes the first.
+	Merge(fromID, intoID uuid.UUID) error
+}
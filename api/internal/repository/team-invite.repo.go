@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+)
+
+// TeamInviteRepository defines the interface for pending team invitations.
+type TeamInviteRepository interface {
+	Create(invite *domain.TeamInvite) error
+	FindByTeamAndUser(teamID uuid.UUID, userID uuid.UUID) (*domain.TeamInvite, error)
+	FindByContestAndUser(contestID uuid.UUID, userID uuid.UUID) ([]*domain.TeamInvite, error)
+	Delete(id uuid.UUID) error
+}
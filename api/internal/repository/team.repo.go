@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+)
+
+// TeamRepository defines the interface for contest teams and their members.
+type TeamRepository interface {
+	// Create inserts a team together with its initial members.
+	Create(team *domain.Team) error
+	FindByID(id uuid.UUID) (*domain.Team, error)
+	FindByContestID(contestID uuid.UUID) ([]*domain.Team, error)
+	FindByContestAndMember(contestID uuid.UUID, userID uuid.UUID) (*domain.Team, error)
+	Update(team *domain.Team) error
+	// Delete removes a team with its members and pending invites.
+	Delete(id uuid.UUID) error
+	AddMember(member *domain.TeamMember) error
+	RemoveMember(teamID uuid.UUID, userID uuid.UUID) error
+	CountMembers(teamID uuid.UUID) (int64, error)
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+)
+
+// TestGroupRepository defines the interface for test group (subtask) operations.
+type TestGroupRepository interface {
+	Create(group *domain.TestGroup) error
+	FindByID(id uuid.UUID) (*domain.TestGroup, error)
+	FindByProblemID(problemID uuid.UUID) ([]*domain.TestGroup, error)
+	// Update saves the group and replaces its dependencies.
+	Update(group *domain.TestGroup) error
+	// Delete removes the group, its dependency edges in both directions and
+	// its test case assignments.
+	Delete(id uuid.UUID) error
+}
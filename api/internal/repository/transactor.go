@@ -0,0 +1,32 @@
+package repository
+
+// ProblemEditRepositories are the repositories a problem edit may write
+// through, bound to one transaction.
+type ProblemEditRepositories struct {
+	Problems    ProblemRepository
+	TestCases   TestCaseRepository
+	TestGroups  TestGroupRepository
+	Checkers    ProblemCheckerRepository
+	Interactors ProblemInteractorRepository
+	Statements  ProblemStatementRepository
+	Revisions   ProblemRevisionRepository
+}
+
+// ProblemEditTransactor runs a problem edit in a database transaction, so that
+// the edit and the revision recording it are committed or rolled back together.
+type ProblemEditTransactor interface {
+	WithinTransaction(fn func(repos *ProblemEditRepositories) error) error
+}
+
+// ContestRegistrationRepositories are the repositories a contest registration
+// writes through, bound to one transaction.
+type ContestRegistrationRepositories struct {
+	Participants ContestParticipantRepository
+	Invites      ContestInviteRepository
+}
+
+// ContestRegistrationTransactor runs a registration in a database transaction,
+// so that an invite code is only used up by a registration that is stored.
+type ContestRegistrationTransactor interface {
+	WithinTransaction(fn func(repos *ContestRegistrationRepositories) error) error
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+)
+
+// VirtualParticipationRepository defines the interface for virtual contest operations.
+type VirtualParticipationRepository interface {
+	Create(participation *domain.VirtualParticipation) error
+	FindByContestAndUser(contestID uuid.UUID, userID uuid.UUID) (*domain.VirtualParticipation, error)
+	FindActiveByUserID(userID uuid.UUID, at time.Time) ([]*domain.VirtualParticipation, error)
+}
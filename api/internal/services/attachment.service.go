@@ -0,0 +1,188 @@
+package services
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+	"github.com/klaus-creations/klaus-judge/api/internal/repository"
+	"github.com/klaus-creations/klaus-judge/api/internal/storage"
+)
+
+var (
+	ErrAttachmentNotFound = errors.New("attachment not found")
+	ErrInvalidAttachment  = errors.New("attachment must have a file name and content")
+)
+
+// maxAttachmentNameLength bounds stored attachment file names.
+const maxAttachmentNameLength = 255
+
+// AttachmentService manages files that problem statements link to.
+type AttachmentService struct {
+	attachmentRepo     repository.ProblemAttachmentRepository
+	problemRepo        repository.ProblemRepository
+	contestProblemRepo repository.ContestProblemRepository
+	participantRepo    repository.ContestParticipantRepository
+	collaboratorRepo   repository.ProblemCollaboratorRepository
+	storage            storage.Storage
+}
+
+// NewAttachmentService creates a new attachment service.
+func NewAttachmentService(
+	attachmentRepo repository.ProblemAttachmentRepository,
+	problemRepo repository.ProblemRepository,
+	contestProblemRepo repository.ContestProblemRepository,
+	participantRepo repository.ContestParticipantRepository,
+	collaboratorRepo repository.ProblemCollaboratorRepository,
+	storage storage.Storage,
+) *AttachmentService {
+	return &AttachmentService{
+		attachmentRepo:     attachmentRepo,
+		problemRepo:        problemRepo,
+		contestProblemRepo: contestProblemRepo,
+		participantRepo:    participantRepo,
+		collaboratorRepo:   collaboratorRepo,
+		storage:            storage,
+	}
+}
+
+// ListAttachments returns the attachments of a problem.
+func (s *AttachmentService) ListAttachments(slug string) ([]*domain.ProblemAttachment, error) {
+	problem, err := s.problemRepo.FindBySlug(slug)
+	if err != nil {
+		return nil, ErrProblemNotFound
+	}
+	return s.attachmentRepo.FindByProblemID(problem.ID)
+}
+
+// UploadAttachment stores a file and attaches it to a problem. The content
+// type comes from the file extension, or from the content if that is unknown.
+func (s *AttachmentService) UploadAttachment(ctx context.Context, slug, fileName string, size int64, body io.Reader, uploadedBy uuid.UUID) (*domain.ProblemAttachment, error) {
+	problem, err := s.problemRepo.FindBySlug(slug)
+	if err != nil {
+		return nil, ErrProblemNotFound
+	}
+
+	fileName = sanitizeFileName(fileName)
+	if fileName == "" || size <= 0 {
+		return nil, ErrInvalidAttachment
+	}
+
+	contentType := mime.TypeByExtension(path.Ext(fileName))
+	if contentType == "" {
+		buffered := bufio.NewReader(body)
+		head, _ := buffered.Peek(512)
+		contentType = http.DetectContentType(head)
+		body = buffered
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, err
+	}
+	attachment := &domain.ProblemAttachment{
+		ID:          id,
+		ProblemID:   problem.ID,
+		FileName:    fileName,
+		ContentType: contentType,
+		Size:        size,
+		StorageKey:  "problems/" + problem.ID.String() + "/attachments/" + id.String(),
+		UploadedBy:  uploadedBy,
+	}
+
+	if err := s.storage.Put(ctx, attachment.StorageKey, body, size, contentType); err != nil {
+		return nil, err
+	}
+	if err := s.attachmentRepo.Create(attachment); err != nil {
+		_ = s.storage.Delete(ctx, attachment.StorageKey)
+		return nil, err
+	}
+	return attachment, nil
+}
+
+// DeleteAttachment removes an attachment of a problem. The record goes first,
+// so a failure to delete the file leaves an unreferenced file, not a broken link.
+func (s *AttachmentService) DeleteAttachment(ctx context.Context, slug string, id uuid.UUID) error {
+	problem, err := s.problemRepo.FindBySlug(slug)
+	if err != nil {
+		return ErrProblemNotFound
+	}
+
+	attachment, err := s.attachmentRepo.FindByID(id)
+	if err != nil || attachment.ProblemID != problem.ID {
+		return ErrAttachmentNotFound
+	}
+
+	if err := s.attachmentRepo.Delete(id); err != nil {
+		return err
+	}
+	return s.storage.Delete(ctx, attachment.StorageKey)
+}
+
+// OpenAttachment returns an attachment and its content for download. Like the
+// problem itself, attachments of unreleased problems stay hidden unless
+// includeUnreleased is set or the viewer collaborates on the problem.
+func (s *AttachmentService) OpenAttachment(ctx context.Context, id uuid.UUID, includeUnreleased bool, viewerID uuid.UUID) (*domain.ProblemAttachment, io.ReadCloser, error) {
+	attachment, err := s.attachmentRepo.FindByID(id)
+	if err != nil {
+		return nil, nil, ErrAttachmentNotFound
+	}
+
+	if !includeUnreleased {
+		problem, err := s.problemRepo.FindByID(attachment.ProblemID)
+		if err != nil {
+			return nil, nil, ErrAttachmentNotFound
+		}
+		if !hasProblemPermission(s.collaboratorRepo, problem.ID, viewerID, ProblemPermissionView) {
+			released, err := problemReleased(s.contestProblemRepo, s.participantRepo, problem, viewerID, time.Now())
+			if err != nil {
+				return nil, nil, err
+			}
+			if !released {
+				return nil, nil, ErrAttachmentNotFound
+			}
+		}
+	}
+
+	content, err := s.storage.Open(ctx, attachment.StorageKey)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, nil, ErrAttachmentNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return attachment, content, nil
+}
+
+// sanitizeFileName keeps the base name of an uploaded file without control
+// characters, so it is safe to echo back in headers and URLs.
+func sanitizeFileName(name string) string {
+	name = path.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == '"' {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+	if name == "." || name == "/" {
+		return ""
+	}
+	if len(name) > maxAttachmentNameLength {
+		ext := path.Ext(name)
+		if len(ext) > 16 {
+			ext = ""
+		}
+		name = strings.ToValidUTF8(name[:maxAttachmentNameLength-len(ext)], "") + ext
+	}
+	return name
+}
@@ -0,0 +1,455 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+	"github.com/klaus-creations/klaus-judge/api/internal/dto"
+	"github.com/klaus-creations/klaus-judge/api/internal/repository"
+)
+
+// ccsTimeLayout is the ISO 8601 layout the CCS Contest API uses for absolute times.
+const ccsTimeLayout = "2006-01-02T15:04:05.000Z07:00"
+
+// ErrInvalidEventToken is returned for a since_token the event feed did not issue.
+var ErrInvalidEventToken = errors.New("invalid since_token")
+
+// ccsTokenPattern matches event feed tokens: see ccsToken.
+var ccsTokenPattern = regexp.MustCompile(`^[0-9]{19}-[0-9]-`)
+
+// ccsJudgementTypes maps our verdicts onto CCS judgement types.
+var ccsJudgementTypes = []struct {
+	verdict string
+	jt      dto.CCSJudgementType
+}{
+	{domain.VerdictAC, dto.CCSJudgementType{ID: "AC", Name: "correct", Penalty: false, Solved: true}},
+	{domain.VerdictWA, dto.CCSJudgementType{ID: "WA", Name: "wrong answer", Penalty: true, Solved: false}},
+	{domain.VerdictTLE, dto.CCSJudgementType{ID: "TLE", Name: "time limit exceeded", Penalty: true, Solved: false}},
+	{domain.VerdictMLE, dto.CCSJudgementType{ID: "MLE", Name: "memory limit exceeded", Penalty: true, Solved: false}},
+	{domain.VerdictRE, dto.CCSJudgementType{ID: "RTE", Name: "run-time error", Penalty: true, Solved: false}},
+	{domain.VerdictCE, dto.CCSJudgementType{ID: "CE", Name: "compiler error", Penalty: false, Solved: false}},
+}
+
+// ccsLanguages lists the languages submissions are accepted in.
+var ccsLanguages = []dto.CCSLanguage{
+	{ID: domain.LangCPP, Name: "C++"},
+	{ID: domain.LangPython, Name: "Python 3"},
+	{ID: domain.LangJava, Name: "Java"},
+	{ID: domain.LangRust, Name: "Rust"},
+	{ID: domain.LangGo, Name: "Go"},
+}
+
+// CCSService exposes contests in the shape of the ICPC CCS Contest API so
+// that standard scoreboard and resolver tools can read them. It always shows
+// the full, unfrozen data and is meant for admins.
+type CCSService struct {
+	contestRepo        repository.ContestRepository
+	contestProblemRepo repository.ContestProblemRepository
+	participantRepo    repository.ContestParticipantRepository
+	submissionRepo     repository.SubmissionRepository
+	scoreboardService  *ScoreboardService
+}
+
+// NewCCSService creates a new CCS Contest API service.
+func NewCCSService(
+	contestRepo repository.ContestRepository,
+	contestProblemRepo repository.ContestProblemRepository,
+	participantRepo repository.ContestParticipantRepository,
+	submissionRepo repository.SubmissionRepository,
+	scoreboardService *ScoreboardService,
+) *CCSService {
+	return &CCSService{
+		contestRepo:        contestRepo,
+		contestProblemRepo: contestProblemRepo,
+		participantRepo:    participantRepo,
+		submissionRepo:     submissionRepo,
+		scoreboardService:  scoreboardService,
+	}
+}
+
+// GetContest returns the contest object.
+func (s *CCSService) GetContest(contestID uuid.UUID) (*dto.CCSContest, error) {
+	contest, err := s.contestRepo.FindByID(contestID)
+	if err != nil {
+		return nil, err
+	}
+	return ccsContest(contest), nil
+}
+
+// GetState returns the contest state at the current time.
+func (s *CCSService) GetState(contestID uuid.UUID) (*dto.CCSState, error) {
+	contest, err := s.contestRepo.FindByID(contestID)
+	if err != nil {
+		return nil, err
+	}
+	state := ccsState(contest, time.Now())
+	return &state, nil
+}
+
+// JudgementTypes returns the judgement types verdicts are reported as.
+func (s *CCSService) JudgementTypes() []dto.CCSJudgementType {
+	types := make([]dto.CCSJudgementType, len(ccsJudgementTypes))
+	for i, t := range ccsJudgementTypes {
+		types[i] = t.jt
+	}
+	return types
+}
+
+// Languages returns the languages submissions may use.
+func (s *CCSService) Languages() []dto.CCSLanguage {
+	return ccsLanguages
+}
+
+// Problems returns the contest problem set in label order.
+func (s *CCSService) Problems(contestID uuid.UUID) ([]dto.CCSProblem, error) {
+	if _, err := s.contestRepo.FindByID(contestID); err != nil {
+		return nil, err
+	}
+	contestProblems, err := s.contestProblemRepo.FindByContestID(contestID)
+	if err != nil {
+		return nil, err
+	}
+
+	problems := make([]dto.CCSProblem, len(contestProblems))
+	for i, cp := range contestProblems {
+		problems[i] = ccsProblem(cp, i)
+	}
+	return problems, nil
+}
+
+// Teams returns the contest participants. Users registered on their own are
+// reported as single-member teams.
+func (s *CCSService) Teams(contestID uuid.UUID) ([]dto.CCSTeam, error) {
+	if _, err := s.contestRepo.FindByID(contestID); err != nil {
+		return nil, err
+	}
+	participants, err := s.participantRepo.FindByContestID(contestID)
+	if err != nil {
+		return nil, err
+	}
+
+	teams := make([]dto.CCSTeam, len(participants))
+	for i, p := range participants {
+		teams[i] = ccsTeam(p)
+	}
+	return teams, nil
+}
+
+// Submissions returns the live (non-virtual) submissions of the contest.
+func (s *CCSService) Submissions(contestID uuid.UUID) ([]dto.CCSSubmission, error) {
+	contest, subs, err := s.contestSubmissions(contestID)
+	if err != nil {
+		return nil, err
+	}
+
+	submissions := make([]dto.CCSSubmission, len(subs))
+	for i, sub := range subs {
+		submissions[i] = ccsSubmission(contest, sub)
+	}
+	return submissions, nil
+}
+
+// Judgements returns one judgement per contest submission. Submissions still
+// being judged have no judgement type or end time yet.
+func (s *CCSService) Judgements(contestID uuid.UUID) ([]dto.CCSJudgement, error) {
+	contest, subs, err := s.contestSubmissions(contestID)
+	if err != nil {
+		return nil, err
+	}
+
+	judgements := make([]dto.CCSJudgement, len(subs))
+	for i, sub := range subs {
+		judgements[i] = ccsJudgement(contest, sub)
+	}
+	return judgements, nil
+}
+
+// Scoreboard returns the current, unfrozen scoreboard.
+func (s *CCSService) Scoreboard(contestID uuid.UUID) (*dto.CCSScoreboard, error) {
+	contest, err := s.contestRepo.FindByID(contestID)
+	if err != nil {
+		return nil, err
+	}
+	board, err := s.scoreboardService.GetScoreboard(contestID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	resp := &dto.CCSScoreboard{
+		Time:        now.Format(ccsTimeLayout),
+		ContestTime: ccsRelTime(now.Sub(contest.StartTime)),
+		State:       ccsState(contest, now),
+		Rows:        make([]dto.CCSScoreboardRow, len(board.Rows)),
+	}
+	isIOI := contest.ScoringMode == domain.ScoringIOI
+	for i, row := range board.Rows {
+		ccsRow := dto.CCSScoreboardRow{
+			Rank:     row.Rank,
+			TeamID:   rowEntrantID(row).String(),
+			Score:    dto.CCSScore{NumSolved: row.Solved, TotalTime: row.Penalty},
+			Problems: make([]dto.CCSScoreboardProblem, len(row.Problems)),
+		}
+		if isIOI {
+			score := row.Score
+			ccsRow.Score.Score = &score
+		}
+		for j, cell := range row.Problems {
+			problem := dto.CCSScoreboardProblem{
+				ProblemID:    board.Problems[j].ProblemID.String(),
+				NumJudged:    cell.Attempts,
+				NumPending:   cell.Pending,
+				Solved:       cell.Solved,
+				FirstToSolve: cell.FirstSolve,
+			}
+			if cell.Solved {
+				solvedAt := cell.SolvedAt
+				problem.Time = &solvedAt
+			}
+			if isIOI {
+				score := cell.Score
+				problem.Score = &score
+			}
+			ccsRow.Problems[j] = problem
+		}
+		resp.Rows[i] = ccsRow
+	}
+	return resp, nil
+}
+
+// EventFeed returns the contest as an event feed: the contest setup, then
+// submissions, judgements and state changes in the order they happened.
+// Each event's token is derived from when its object last changed, so tokens
+// stay the same between requests and later changes always get later tokens;
+// events up to and including sinceToken are skipped.
+func (s *CCSService) EventFeed(contestID uuid.UUID, sinceToken string) ([]dto.CCSEvent, error) {
+	if sinceToken != "" && !ccsTokenPattern.MatchString(sinceToken) {
+		return nil, ErrInvalidEventToken
+	}
+
+	contest, subs, err := s.contestSubmissions(contestID)
+	if err != nil {
+		return nil, err
+	}
+	contestProblems, err := s.contestProblemRepo.FindByContestID(contestID)
+	if err != nil {
+		return nil, err
+	}
+	participants, err := s.participantRepo.FindByContestID(contestID)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []dto.CCSEvent
+	add := func(at time.Time, rank int, eventType string, id string, data interface{}) {
+		event := dto.CCSEvent{Type: eventType, Data: data, Token: ccsToken(at, rank, id)}
+		if id != "" {
+			event.ID = &id
+		}
+		events = append(events, event)
+	}
+
+	add(contest.UpdatedAt, 0, "contest", "", ccsContest(contest))
+	for _, jt := range s.JudgementTypes() {
+		add(contest.CreatedAt, 1, "judgement-types", jt.ID, jt)
+	}
+	for _, l := range ccsLanguages {
+		add(contest.CreatedAt, 2, "languages", l.ID, l)
+	}
+	for i, cp := range contestProblems {
+		at := cp.CreatedAt
+		if cp.Problem.UpdatedAt.After(at) {
+			at = cp.Problem.UpdatedAt
+		}
+		problem := ccsProblem(cp, i)
+		add(at, 3, "problems", problem.ID, problem)
+	}
+	for _, p := range participants {
+		team := ccsTeam(p)
+		add(p.RegisteredAt, 4, "teams", team.ID, team)
+	}
+	for _, sub := range subs {
+		add(sub.SubmittedAt, 5, "submissions", sub.ID.String(), ccsSubmission(contest, sub))
+		if sub.JudgedAt != nil {
+			add(*sub.JudgedAt, 6, "judgements", sub.ID.String(), ccsJudgement(contest, sub))
+		}
+	}
+	state := ccsState(contest, time.Now())
+	add(ccsStateChanged(contest, state), 7, "state", "", state)
+
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Token < events[j].Token })
+
+	feed := []dto.CCSEvent{}
+	for _, event := range events {
+		if event.Token > sinceToken {
+			feed = append(feed, event)
+		}
+	}
+	return feed, nil
+}
+
+// ccsToken builds an event feed token from when the event's object last
+// changed, the rank of its type and its id. Tokens sort in feed order.
+func ccsToken(at time.Time, rank int, id string) string {
+	return fmt.Sprintf("%019d-%d-%s", at.UnixNano(), rank, id)
+}
+
+// ccsStateChanged returns when the contest last reached a milestone of the
+// given state, or when it was created if it has reached none yet.
+func ccsStateChanged(contest *domain.Contest, state dto.CCSState) time.Time {
+	changed := contest.CreatedAt
+	for _, stamp := range []*string{state.Started, state.Frozen, state.Ended, state.Thawed, state.Finalized} {
+		if stamp == nil {
+			continue
+		}
+		if t, err := time.Parse(ccsTimeLayout, *stamp); err == nil && t.After(changed) {
+			changed = t
+		}
+	}
+	return changed
+}
+
+// contestSubmissions loads a contest and its live submissions.
+func (s *CCSService) contestSubmissions(contestID uuid.UUID) (*domain.Contest, []*domain.Submission, error) {
+	contest, err := s.contestRepo.FindByID(contestID)
+	if err != nil {
+		return nil, nil, err
+	}
+	subs, err := s.submissionRepo.FindByContestID(contestID, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	return contest, subs, nil
+}
+
+func ccsContest(contest *domain.Contest) *dto.CCSContest {
+	start := contest.StartTime.Format(ccsTimeLayout)
+	c := &dto.CCSContest{
+		ID:             contest.ID.String(),
+		Name:           contest.Title,
+		FormalName:     contest.Title,
+		StartTime:      &start,
+		Duration:       ccsRelTime(contest.EndTime.Sub(contest.StartTime)),
+		ScoreboardType: "pass-fail",
+		PenaltyTime:    penaltyPerRejection,
+	}
+	if contest.ScoringMode == domain.ScoringIOI {
+		c.ScoreboardType = "score"
+	}
+	if contest.FreezeMinutes > 0 {
+		freeze := ccsRelTime(time.Duration(contest.FreezeMinutes) * time.Minute)
+		c.ScoreboardFreezeDuration = &freeze
+	}
+	return c
+}
+
+// ccsState reports which contest milestones have passed at the given time.
+// Contests without a freeze, or whose freeze has been lifted, are final once
+// they end.
+func ccsState(contest *domain.Contest, now time.Time) dto.CCSState {
+	var state dto.CCSState
+	stamp := func(t time.Time) *string {
+		s := t.Format(ccsTimeLayout)
+		return &s
+	}
+
+	if !now.Before(contest.StartTime) {
+		state.Started = stamp(contest.StartTime)
+	}
+	freeze := contest.FreezeTime()
+	if freeze != nil && !now.Before(*freeze) {
+		state.Frozen = stamp(*freeze)
+	}
+	if !now.Before(contest.EndTime) {
+		state.Ended = stamp(contest.EndTime)
+		if freeze != nil && contest.Unfrozen {
+			state.Thawed = stamp(contest.UpdatedAt)
+		}
+		if freeze == nil || contest.Unfrozen {
+			final := contest.EndTime
+			if state.Thawed != nil {
+				final = contest.UpdatedAt
+			}
+			state.Finalized = stamp(final)
+			state.EndOfUpdates = stamp(final)
+		}
+	}
+	return state
+}
+
+func ccsProblem(cp *domain.ContestProblem, ordinal int) dto.CCSProblem {
+	return dto.CCSProblem{
+		ID:        cp.ProblemID.String(),
+		Label:     cp.Label,
+		Name:      cp.Problem.Title,
+		Ordinal:   ordinal,
+		TimeLimit: float64(cp.Problem.TimeLimit) / 1000,
+	}
+}
+
+func ccsTeam(p *domain.ContestParticipant) dto.CCSTeam {
+	team := dto.CCSTeam{
+		ID:          p.EntrantID().String(),
+		Name:        p.User.Username,
+		DisplayName: p.User.FullName,
+	}
+	if p.Team != nil {
+		team.Name = p.Team.Name
+		team.DisplayName = p.Team.Name
+	}
+	return team
+}
+
+func ccsSubmission(contest *domain.Contest, sub *domain.Submission) dto.CCSSubmission {
+	return dto.CCSSubmission{
+		ID:          sub.ID.String(),
+		LanguageID:  sub.Language,
+		ProblemID:   sub.ProblemID.String(),
+		TeamID:      sub.EntrantID().String(),
+		Time:        sub.SubmittedAt.Format(ccsTimeLayout),
+		ContestTime: ccsRelTime(sub.SubmittedAt.Sub(contest.StartTime)),
+	}
+}
+
+func ccsJudgement(contest *domain.Contest, sub *domain.Submission) dto.CCSJudgement {
+	j := dto.CCSJudgement{
+		ID:               sub.ID.String(),
+		SubmissionID:     sub.ID.String(),
+		StartTime:        sub.SubmittedAt.Format(ccsTimeLayout),
+		StartContestTime: ccsRelTime(sub.SubmittedAt.Sub(contest.StartTime)),
+	}
+	if sub.JudgedAt == nil {
+		return j
+	}
+
+	end := sub.JudgedAt.Format(ccsTimeLayout)
+	endContest := ccsRelTime(sub.JudgedAt.Sub(contest.StartTime))
+	maxRunTime := float64(sub.ExecutionTime) / 1000
+	j.EndTime = &end
+	j.EndContestTime = &endContest
+	j.MaxRunTime = &maxRunTime
+	for _, t := range ccsJudgementTypes {
+		if t.verdict == sub.Verdict {
+			id := t.jt.ID
+			j.JudgementTypeID = &id
+			break
+		}
+	}
+	return j
+}
+
+// ccsRelTime formats a duration as a CCS RELTIME, e.g. "1:05:00.000".
+func ccsRelTime(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+	ms := d.Milliseconds()
+	return fmt.Sprintf("%s%d:%02d:%02d.%03d", sign, ms/3600000, ms/60000%60, ms/1000%60, ms%1000)
+}
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+	"github.com/klaus-creations/klaus-judge/api/internal/dto"
+	"github.com/klaus-creations/klaus-judge/api/internal/repository"
+)
+
+var ErrCheckerNotFound = errors.New("problem has no checker")
+
+// CheckerService manages the custom checkers (special judges) of problems.
+type CheckerService struct {
+	checkerRepo repository.ProblemCheckerRepository
+	problemRepo repository.ProblemRepository
+	transactor  repository.ProblemEditTransactor
+}
+
+// NewCheckerService creates a new checker service.
+func NewCheckerService(
+	checkerRepo repository.ProblemCheckerRepository,
+	problemRepo repository.ProblemRepository,
+	transactor repository.ProblemEditTransactor,
+) *CheckerService {
+	return &CheckerService{
+		checkerRepo: checkerRepo,
+		problemRepo: problemRepo,
+		transactor:  transactor,
+	}
+}
+
+// GetChecker returns the checker of a problem.
+func (s *CheckerService) GetChecker(slug string) (*domain.ProblemChecker, error) {
+	problem, err := s.problemRepo.FindBySlug(slug)
+	if err != nil {
+		return nil, ErrProblemNotFound
+	}
+
+	checker, err := s.checkerRepo.FindByProblemID(problem.ID)
+	if err != nil {
+		return nil, ErrCheckerNotFound
+	}
+	return checker, nil
+}
+
+// SetChecker uploads a checker for a problem, replacing any existing one, and
+// records a revision. From then on the judge compares outputs with the
+// checker instead of comparing them with the expected output verbatim.
+func (s *CheckerService) SetChecker(slug string, req *dto.SetCheckerRequest, userID uuid.UUID) (*domain.ProblemChecker, error) {
+	if !isValidJudgeProgramLanguage(req.Language) {
+		return nil, ErrUnsupportedLanguage
+	}
+
+	problem, err := s.problemRepo.FindBySlug(slug)
+	if err != nil {
+		return nil, ErrProblemNotFound
+	}
+
+	checker, err := s.checkerRepo.FindByProblemID(problem.ID)
+	if err != nil {
+		checker = &domain.ProblemChecker{ProblemID: problem.ID}
+	}
+	checker.Language = req.Language
+	checker.Code = req.Code
+	checker.CreatedBy = userID
+
+	err = editProblem(s.transactor, problem, userID, domain.RevisionChangeSetChecker, func(repos *repository.ProblemEditRepositories) error {
+		if checker.ID == uuid.Nil {
+			return repos.Checkers.Create(checker)
+		}
+		return repos.Checkers.Update(checker)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return checker, nil
+}
+
+// DeleteChecker removes the checker of a problem and records a revision;
+// outputs are compared with the expected output again.
+func (s *CheckerService) DeleteChecker(slug string, editorID uuid.UUID) error {
+	problem, err := s.problemRepo.FindBySlug(slug)
+	if err != nil {
+		return ErrProblemNotFound
+	}
+
+	if _, err := s.checkerRepo.FindByProblemID(problem.ID); err != nil {
+		return ErrCheckerNotFound
+	}
+	return editProblem(s.transactor, problem, editorID, domain.RevisionChangeDeleteChecker, func(repos *repository.ProblemEditRepositories) error {
+		return repos.Checkers.DeleteByProblemID(problem.ID)
+	})
+}
+
+// isValidJudgeProgramLanguage reports whether the judge can run checkers and
+// interactors written in a language; it builds them for C++ and Python only.
+func isValidJudgeProgramLanguage(lang string) bool {
+	return lang == domain.LangCPP || lang == domain.LangPython
+}
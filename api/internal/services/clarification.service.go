@@ -0,0 +1,136 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+	"github.com/klaus-creations/klaus-judge/api/internal/dto"
+	"github.com/klaus-creations/klaus-judge/api/internal/repository"
+)
+
+var (
+	ErrContestEnded          = errors.New("contest has ended")
+	ErrClarificationAnswered = errors.New("clarification already answered")
+	ErrClarificationNotFound = errors.New("clarification not found")
+)
+
+// ClarificationService handles contestant questions and judge answers.
+type ClarificationService struct {
+	clarificationRepo  repository.ClarificationRepository
+	contestRepo        repository.ContestRepository
+	contestProblemRepo repository.ContestProblemRepository
+	participantRepo    repository.ContestParticipantRepository
+}
+
+// NewClarificationService creates a new clarification service.
+func NewClarificationService(
+	clarificationRepo repository.ClarificationRepository,
+	contestRepo repository.ContestRepository,
+	contestProblemRepo repository.ContestProblemRepository,
+	participantRepo repository.ContestParticipantRepository,
+) *ClarificationService {
+	return &ClarificationService{
+		clarificationRepo:  clarificationRepo,
+		contestRepo:        contestRepo,
+		contestProblemRepo: contestProblemRepo,
+		participantRepo:    participantRepo,
+	}
+}
+
+// Ask records a question from a registered participant. Questions may be
+// asked until the contest ends; judges may ask regardless of registration.
+func (s *ClarificationService) Ask(contestID uuid.UUID, userID uuid.UUID, req *dto.AskClarificationRequest, isJudge bool) (*dto.ClarificationResponse, error) {
+	contest, err := s.contestRepo.FindByID(contestID)
+	if err != nil {
+		return nil, err
+	}
+	if !time.Now().Before(contest.EndTime) {
+		return nil, ErrContestEnded
+	}
+	if !isJudge {
+		if _, err := s.participantRepo.FindByContestAndMember(contestID, userID); err != nil {
+			return nil, ErrNotRegistered
+		}
+	}
+
+	clarification := &domain.Clarification{
+		ContestID: contestID,
+		AskedBy:   userID,
+		Question:  req.Question,
+	}
+	if req.ProblemLabel != "" {
+		cp, err := s.contestProblemRepo.FindByContestAndLabel(contestID, req.ProblemLabel)
+		if err != nil {
+			return nil, errors.New("contest problem not found")
+		}
+		clarification.ContestProblemID = &cp.ID
+	}
+
+	if err := s.clarificationRepo.Create(clarification); err != nil {
+		return nil, err
+	}
+
+	created, err := s.clarificationRepo.FindByID(clarification.ID)
+	if err != nil {
+		return nil, err
+	}
+	return dto.ClarificationResponseFromDomain(created, true), nil
+}
+
+// List returns the clarifications a user may see. Judges see every question,
+// optionally only the unanswered ones; participants see their own questions
+// and broadcast answers.
+func (s *ClarificationService) List(contestID uuid.UUID, userID uuid.UUID, isJudge bool, unansweredOnly bool) (*dto.ClarificationListResponse, error) {
+	contest, err := s.contestRepo.FindByID(contestID)
+	if err != nil {
+		return nil, err
+	}
+	// Broadcast answers of private contests are for participants only.
+	if !isJudge && !contest.IsPublic {
+		if _, err := s.participantRepo.FindByContestAndMember(contestID, userID); err != nil {
+			return nil, ErrContestNotFound
+		}
+	}
+
+	filters := &domain.ClarificationFilters{UnansweredOnly: unansweredOnly}
+	if !isJudge {
+		filters = &domain.ClarificationFilters{VisibleTo: userID}
+	}
+
+	clarifications, err := s.clarificationRepo.FindByContestID(contestID, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.ClarificationListResponse{Clarifications: []dto.ClarificationResponse{}}
+	for _, c := range clarifications {
+		showAsker := isJudge || c.AskedBy == userID
+		resp.Clarifications = append(resp.Clarifications, *dto.ClarificationResponseFromDomain(c, showAsker))
+	}
+	return resp, nil
+}
+
+// Answer records a judge's answer, either privately to the asker or
+// broadcast to every participant.
+func (s *ClarificationService) Answer(contestID uuid.UUID, clarificationID uuid.UUID, judgeID uuid.UUID, req *dto.AnswerClarificationRequest) (*dto.ClarificationResponse, error) {
+	clarification, err := s.clarificationRepo.FindByID(clarificationID)
+	if err != nil || clarification.ContestID != contestID {
+		return nil, ErrClarificationNotFound
+	}
+	if clarification.AnsweredAt != nil {
+		return nil, ErrClarificationAnswered
+	}
+
+	now := time.Now()
+	clarification.Answer = req.Answer
+	clarification.AnsweredBy = &judgeID
+	clarification.AnsweredAt = &now
+	clarification.IsBroadcast = req.Broadcast
+
+	if err := s.clarificationRepo.Update(clarification); err != nil {
+		return nil, err
+	}
+	return dto.ClarificationResponseFromDomain(clarification, true), nil
+}
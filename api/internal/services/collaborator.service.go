@@ -0,0 +1,201 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+	"github.com/klaus-creations/klaus-judge/api/internal/dto"
+	"github.com/klaus-creations/klaus-judge/api/internal/repository"
+)
+
+var (
+	ErrProblemAccessDenied      = errors.New("you do not have permission for this problem")
+	ErrCollaboratorNotFound     = errors.New("user is not a collaborator of this problem")
+	ErrCollaboratorUserNotFound = errors.New("user not found")
+	ErrAlreadyCollaborator      = errors.New("user is already a collaborator of this problem")
+	ErrLastOwner                = errors.New("a problem must keep at least one owner")
+)
+
+// ProblemPermission is what a user may do with a problem. Each permission
+// includes the ones before it.
+type ProblemPermission int
+
+const (
+	// ProblemPermissionView shows the unreleased problem, its hidden tests,
+	// judging setup and history.
+	ProblemPermissionView ProblemPermission = iota + 1
+	// ProblemPermissionEdit changes the statement, tests and judging setup.
+	ProblemPermissionEdit
+	// ProblemPermissionManage deletes the problem and manages its collaborators.
+	ProblemPermissionManage
+)
+
+// collaboratorPermissions maps collaborator roles to what they allow.
+var collaboratorPermissions = map[string]ProblemPermission{
+	domain.CollaboratorRoleTester: ProblemPermissionView,
+	domain.CollaboratorRoleEditor: ProblemPermissionEdit,
+	domain.CollaboratorRoleOwner:  ProblemPermissionManage,
+}
+
+// CollaboratorService manages who may work on a problem.
+type CollaboratorService struct {
+	collaboratorRepo repository.ProblemCollaboratorRepository
+	problemRepo      repository.ProblemRepository
+	userRepo         repository.UserRepository
+}
+
+// NewCollaboratorService creates a new collaborator service.
+func NewCollaboratorService(
+	collaboratorRepo repository.ProblemCollaboratorRepository,
+	problemRepo repository.ProblemRepository,
+	userRepo repository.UserRepository,
+) *CollaboratorService {
+	return &CollaboratorService{
+		collaboratorRepo: collaboratorRepo,
+		problemRepo:      problemRepo,
+		userRepo:         userRepo,
+	}
+}
+
+// AuthorizeProblem checks that a user may act on a problem with the given
+// permission. Admins may do anything; other users need a collaborator role
+// granting it.
+func (s *CollaboratorService) AuthorizeProblem(slug string, userID uuid.UUID, role string, permission ProblemPermission) error {
+	if role == domain.RoleAdmin {
+		return nil
+	}
+
+	problem, err := s.problemRepo.FindBySlug(slug)
+	if err != nil {
+		return ErrProblemNotFound
+	}
+	if !hasProblemPermission(s.collaboratorRepo, problem.ID, userID, permission) {
+		return ErrProblemAccessDenied
+	}
+	return nil
+}
+
+// ListCollaborators lists the collaborators of a problem.
+func (s *CollaboratorService) ListCollaborators(slug string) ([]dto.CollaboratorDTO, error) {
+	problem, err := s.problemRepo.FindBySlug(slug)
+	if err != nil {
+		return nil, ErrProblemNotFound
+	}
+
+	collaborators, err := s.collaboratorRepo.FindByProblemID(problem.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]dto.CollaboratorDTO, 0, len(collaborators))
+	for _, pc := range collaborators {
+		result = append(result, dto.CollaboratorDTOFromDomain(pc))
+	}
+	return result, nil
+}
+
+// AddCollaborator gives a user a role on a problem.
+func (s *CollaboratorService) AddCollaborator(slug string, req *dto.AddCollaboratorRequest) (*dto.CollaboratorDTO, error) {
+	problem, err := s.problemRepo.FindBySlug(slug)
+	if err != nil {
+		return nil, ErrProblemNotFound
+	}
+	user, err := s.userRepo.FindByUsername(req.Username)
+	if err != nil {
+		return nil, ErrCollaboratorUserNotFound
+	}
+	if _, err := s.collaboratorRepo.FindByProblemAndUser(problem.ID, user.ID); err == nil {
+		return nil, ErrAlreadyCollaborator
+	}
+
+	collaborator := &domain.ProblemCollaborator{
+		ProblemID: problem.ID,
+		UserID:    user.ID,
+		Role:      req.Role,
+	}
+	if err := s.collaboratorRepo.Create(collaborator); err != nil {
+		return nil, err
+	}
+
+	collaborator.User = *user
+	result := dto.CollaboratorDTOFromDomain(collaborator)
+	return &result, nil
+}
+
+// UpdateCollaborator changes the role of a collaborator.
+func (s *CollaboratorService) UpdateCollaborator(slug string, userID uuid.UUID, req *dto.UpdateCollaboratorRequest) (*dto.CollaboratorDTO, error) {
+	collaborators, collaborator, err := s.findCollaborator(slug, userID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Role != domain.CollaboratorRoleOwner && isLastOwner(collaborators, collaborator) {
+		return nil, ErrLastOwner
+	}
+
+	collaborator.Role = req.Role
+	if err := s.collaboratorRepo.Update(collaborator); err != nil {
+		return nil, err
+	}
+
+	result := dto.CollaboratorDTOFromDomain(collaborator)
+	return &result, nil
+}
+
+// RemoveCollaborator takes away a user's role on a problem.
+func (s *CollaboratorService) RemoveCollaborator(slug string, userID uuid.UUID) error {
+	collaborators, collaborator, err := s.findCollaborator(slug, userID)
+	if err != nil {
+		return err
+	}
+	if isLastOwner(collaborators, collaborator) {
+		return ErrLastOwner
+	}
+	return s.collaboratorRepo.Delete(collaborator.ID)
+}
+
+// findCollaborator loads the collaborators of a problem and the one of them
+// that is the given user.
+func (s *CollaboratorService) findCollaborator(slug string, userID uuid.UUID) ([]*domain.ProblemCollaborator, *domain.ProblemCollaborator, error) {
+	problem, err := s.problemRepo.FindBySlug(slug)
+	if err != nil {
+		return nil, nil, ErrProblemNotFound
+	}
+
+	collaborators, err := s.collaboratorRepo.FindByProblemID(problem.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, pc := range collaborators {
+		if pc.UserID == userID {
+			return collaborators, pc, nil
+		}
+	}
+	return nil, nil, ErrCollaboratorNotFound
+}
+
+// isLastOwner reports whether a collaborator is the only owner of their problem.
+func isLastOwner(collaborators []*domain.ProblemCollaborator, collaborator *domain.ProblemCollaborator) bool {
+	if collaborator.Role != domain.CollaboratorRoleOwner {
+		return false
+	}
+	for _, pc := range collaborators {
+		if pc.Role == domain.CollaboratorRoleOwner && pc.ID != collaborator.ID {
+			return false
+		}
+	}
+	return true
+}
+
+// hasProblemPermission reports whether a user's collaborator role on a
+// problem grants the given permission.
+func hasProblemPermission(collaboratorRepo repository.ProblemCollaboratorRepository, problemID, userID uuid.UUID, permission ProblemPermission) bool {
+	if userID == uuid.Nil {
+		return false
+	}
+	collaborator, err := collaboratorRepo.FindByProblemAndUser(problemID, userID)
+	if err != nil {
+		return false
+	}
+	return collaboratorPermissions[collaborator.Role] >= permission
+}
@@ -0,0 +1,719 @@
+package services
+
+import (
+	"crypto/rand"
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+	"github.com/klaus-creations/klaus-judge/api/internal/dto"
+	"github.com/klaus-creations/klaus-judge/api/internal/repository"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	ErrInvalidContestWindow = errors.New("contest end time must be after start time")
+	ErrInvalidContestStatus = errors.New("status must be one of upcoming, running, past")
+	ErrInvalidContestLabel  = errors.New("label must be 1-8 uppercase letters or digits")
+	ErrContestNotStarted    = errors.New("contest has not started yet")
+	ErrContestStarted       = errors.New("contest has already started")
+	ErrAlreadyRegistered    = errors.New("already registered for this contest")
+	ErrNotRegistered        = errors.New("not registered for this contest")
+	ErrAlreadyParticipated  = errors.New("you took part in this contest and cannot replay it virtually")
+	ErrVirtualStarted       = errors.New("virtual participation already started")
+	ErrContestNotFound      = errors.New("contest not found")
+	ErrContestAccessDenied  = errors.New("an invite code, password or allowlist entry is required for this contest")
+	ErrInvalidInviteCode    = errors.New("invite code is invalid, expired or used up")
+	ErrAlreadyAllowed       = errors.New("user is already on the allowlist")
+	ErrTeamContest          = errors.New("this contest requires registering as a team")
+	ErrNotTeamContest       = errors.New("this contest does not allow teams")
+)
+
+var contestLabelPattern = regexp.MustCompile(`^[A-Z][A-Z0-9]{0,7}$`)
+
+// inviteCodeAlphabet leaves out characters that are easily confused (0/O, 1/I/L).
+const (
+	inviteCodeAlphabet = "ABCDEFGHJKMNPQRSTUVWXYZ23456789"
+	inviteCodeLength   = 10
+)
+
+// ContestService handles contest-related business logic.
+type ContestService struct {
+	contestRepo        repository.ContestRepository
+	contestProblemRepo repository.ContestProblemRepository
+	problemRepo        repository.ProblemRepository
+	participantRepo    repository.ContestParticipantRepository
+	virtualRepo        repository.VirtualParticipationRepository
+	inviteRepo         repository.ContestInviteRepository
+	allowedUserRepo    repository.ContestAllowedUserRepository
+	userRepo           repository.UserRepository
+	registrations      repository.ContestRegistrationTransactor
+}
+
+// NewContestService creates a new contest service.
+func NewContestService(
+	contestRepo repository.ContestRepository,
+	contestProblemRepo repository.ContestProblemRepository,
+	problemRepo repository.ProblemRepository,
+	participantRepo repository.ContestParticipantRepository,
+	virtualRepo repository.VirtualParticipationRepository,
+	inviteRepo repository.ContestInviteRepository,
+	allowedUserRepo repository.ContestAllowedUserRepository,
+	userRepo repository.UserRepository,
+	registrations repository.ContestRegistrationTransactor,
+) *ContestService {
+	return &ContestService{
+		contestRepo:        contestRepo,
+		contestProblemRepo: contestProblemRepo,
+		problemRepo:        problemRepo,
+		participantRepo:    participantRepo,
+		virtualRepo:        virtualRepo,
+		inviteRepo:         inviteRepo,
+		allowedUserRepo:    allowedUserRepo,
+		userRepo:           userRepo,
+		registrations:      registrations,
+	}
+}
+
+// CreateContest creates a new contest.
+func (s *ContestService) CreateContest(req *dto.CreateContestRequest, createdBy uuid.UUID) (*domain.Contest, error) {
+	if !req.EndTime.After(req.StartTime) {
+		return nil, ErrInvalidContestWindow
+	}
+
+	contest := &domain.Contest{
+		Title:              req.Title,
+		Description:        req.Description,
+		StartTime:          req.StartTime,
+		EndTime:            req.EndTime,
+		IsPublic:           true,
+		ScoringMode:        domain.ScoringICPC,
+		FreezeMinutes:      req.FreezeMinutes,
+		MaxTeamSize:        req.MaxTeamSize,
+		PublicCodeAfterEnd: req.PublicCodeAfterEnd,
+		IsRated:            req.IsRated,
+		CreatedBy:          createdBy,
+	}
+	if req.IsPublic != nil {
+		contest.IsPublic = *req.IsPublic
+	}
+	if req.ScoringMode != "" {
+		contest.ScoringMode = req.ScoringMode
+	}
+	if req.Password != "" {
+		hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+		if err != nil {
+			return nil, err
+		}
+		contest.PasswordHash = string(hash)
+	}
+
+	if err := s.contestRepo.Create(contest); err != nil {
+		return nil, err
+	}
+	return contest, nil
+}
+
+// GetContest retrieves a contest by ID. Private contests are reported as not
+// found to users who have no access to them.
+func (s *ContestService) GetContest(id uuid.UUID, viewerID uuid.UUID, isAdmin bool) (*dto.ContestResponse, error) {
+	contest, err := s.findVisibleContest(id, viewerID, isAdmin)
+	if err != nil {
+		return nil, err
+	}
+	return dto.ContestResponseFromDomain(contest), nil
+}
+
+// CheckAccess reports ErrContestNotFound if the viewer may not see the contest.
+func (s *ContestService) CheckAccess(id uuid.UUID, viewerID uuid.UUID, isAdmin bool) error {
+	_, err := s.findVisibleContest(id, viewerID, isAdmin)
+	return err
+}
+
+// ListContests lists contests with pagination, optionally filtered by status.
+// Private contests are only listed for admins and for users registered for
+// or allowlisted in them.
+func (s *ContestService) ListContests(pagination *dto.PaginationRequest, filters *dto.ContestFilters, viewerID uuid.UUID, isAdmin bool) (*dto.ContestListResponse, error) {
+	switch filters.Status {
+	case "", domain.ContestStatusUpcoming, domain.ContestStatusRunning, domain.ContestStatusPast:
+	default:
+		return nil, ErrInvalidContestStatus
+	}
+
+	domainPagination := &domain.Pagination{
+		Limit:  pagination.Limit,
+		Offset: pagination.Page * pagination.Limit,
+	}
+	domainFilters := &domain.ContestFilters{
+		Status:         filters.Status,
+		IncludePrivate: isAdmin,
+		ViewerID:       viewerID,
+	}
+
+	contests, total, err := s.contestRepo.FindAll(domainPagination, domainFilters)
+	if err != nil {
+		return nil, err
+	}
+
+	contestDTOs := []dto.ContestResponse{}
+	for _, c := range contests {
+		contestDTOs = append(contestDTOs, *dto.ContestResponseFromDomain(c))
+	}
+
+	return &dto.ContestListResponse{
+		Contests: contestDTOs,
+		Total:    total,
+		Page:     pagination.Page,
+		Limit:    pagination.Limit,
+	}, nil
+}
+
+// UpdateContest updates a contest.
+func (s *ContestService) UpdateContest(id uuid.UUID, req *dto.UpdateContestRequest) (*domain.Contest, error) {
+	contest, err := s.findContest(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Title != "" {
+		contest.Title = req.Title
+	}
+	if req.Description != "" {
+		contest.Description = req.Description
+	}
+	if req.StartTime != nil {
+		contest.StartTime = *req.StartTime
+	}
+	if req.EndTime != nil {
+		contest.EndTime = *req.EndTime
+	}
+	if req.IsPublic != nil {
+		contest.IsPublic = *req.IsPublic
+	}
+	if req.ScoringMode != "" {
+		contest.ScoringMode = req.ScoringMode
+	}
+	if req.FreezeMinutes != nil {
+		contest.FreezeMinutes = *req.FreezeMinutes
+	}
+	if req.IsRated != nil {
+		contest.IsRated = *req.IsRated
+	}
+	if req.MaxTeamSize != nil {
+		contest.MaxTeamSize = *req.MaxTeamSize
+	}
+	if req.PublicCodeAfterEnd != nil {
+		contest.PublicCodeAfterEnd = *req.PublicCodeAfterEnd
+	}
+	if req.Password != nil {
+		contest.PasswordHash = ""
+		if *req.Password != "" {
+			hash, err := bcrypt.GenerateFromPassword([]byte(*req.Password), bcrypt.DefaultCost)
+			if err != nil {
+				return nil, err
+			}
+			contest.PasswordHash = string(hash)
+		}
+	}
+
+	if !contest.EndTime.After(contest.StartTime) {
+		return nil, ErrInvalidContestWindow
+	}
+
+	if err := s.contestRepo.Update(contest); err != nil {
+		return nil, err
+	}
+	return contest, nil
+}
+
+// DeleteContest deletes a contest.
+func (s *ContestService) DeleteContest(id uuid.UUID) error {
+	if _, err := s.findContest(id); err != nil {
+		return err
+	}
+	return s.contestRepo.Delete(id)
+}
+
+// ListContestProblems lists the problem set of a contest. Before the contest
+// starts only admins may see it.
+func (s *ContestService) ListContestProblems(contestID uuid.UUID, viewerID uuid.UUID, isAdmin bool) ([]dto.ContestProblemDTO, error) {
+	contest, err := s.findVisibleContest(contestID, viewerID, isAdmin)
+	if err != nil {
+		return nil, err
+	}
+	if !isAdmin && time.Now().Before(contest.StartTime) {
+		return nil, ErrContestNotStarted
+	}
+
+	contestProblems, err := s.contestProblemRepo.FindByContestID(contestID)
+	if err != nil {
+		return nil, err
+	}
+
+	problemDTOs := []dto.ContestProblemDTO{}
+	for _, cp := range contestProblems {
+		problemDTOs = append(problemDTOs, *dto.ContestProblemDTOFromDomain(cp))
+	}
+	return problemDTOs, nil
+}
+
+// AddContestProblem assigns a problem to a contest. When no label is given the
+// next free letter is used.
+func (s *ContestService) AddContestProblem(contestID uuid.UUID, req *dto.AddContestProblemRequest) (*domain.ContestProblem, error) {
+	if _, err := s.contestRepo.FindByID(contestID); err != nil {
+		return nil, err
+	}
+	problem, err := s.problemRepo.FindBySlug(req.ProblemSlug)
+	if err != nil {
+		return nil, errors.New("problem not found")
+	}
+
+	existing, err := s.contestProblemRepo.FindByContestID(contestID)
+	if err != nil {
+		return nil, err
+	}
+
+	label := strings.ToUpper(strings.TrimSpace(req.Label))
+	if label == "" {
+		label = nextContestLabel(existing)
+	}
+	if !contestLabelPattern.MatchString(label) {
+		return nil, ErrInvalidContestLabel
+	}
+	for _, cp := range existing {
+		if cp.ProblemID == problem.ID {
+			return nil, errors.New("problem is already part of this contest")
+		}
+		if cp.Label == label {
+			return nil, errors.New("label is already used in this contest")
+		}
+	}
+
+	points := req.Points
+	if points <= 0 {
+		points = 1
+	}
+
+	contestProblem := &domain.ContestProblem{
+		ContestID: contestID,
+		ProblemID: problem.ID,
+		Label:     label,
+		Points:    points,
+	}
+	if err := s.contestProblemRepo.Create(contestProblem); err != nil {
+		return nil, err
+	}
+	contestProblem.Problem = *problem
+	return contestProblem, nil
+}
+
+// UpdateContestProblem changes the label or points of a contest problem.
+func (s *ContestService) UpdateContestProblem(contestID uuid.UUID, label string, req *dto.UpdateContestProblemRequest) (*domain.ContestProblem, error) {
+	contestProblem, err := s.contestProblemRepo.FindByContestAndLabel(contestID, strings.ToUpper(label))
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Label != "" {
+		newLabel := strings.ToUpper(strings.TrimSpace(req.Label))
+		if !contestLabelPattern.MatchString(newLabel) {
+			return nil, ErrInvalidContestLabel
+		}
+		if newLabel != contestProblem.Label {
+			if _, err := s.contestProblemRepo.FindByContestAndLabel(contestID, newLabel); err == nil {
+				return nil, errors.New("label is already used in this contest")
+			}
+			contestProblem.Label = newLabel
+		}
+	}
+	if req.Points > 0 {
+		contestProblem.Points = req.Points
+	}
+
+	if err := s.contestProblemRepo.Update(contestProblem); err != nil {
+		return nil, err
+	}
+	return contestProblem, nil
+}
+
+// RemoveContestProblem removes a problem from a contest.
+func (s *ContestService) RemoveContestProblem(contestID uuid.UUID, label string) error {
+	contestProblem, err := s.contestProblemRepo.FindByContestAndLabel(contestID, strings.ToUpper(label))
+	if err != nil {
+		return err
+	}
+	return s.contestProblemRepo.Delete(contestProblem.ID)
+}
+
+// Register signs a user up for a contest that has not started yet. Private
+// contests additionally require an allowlist entry, a valid invite code or
+// the contest password.
+func (s *ContestService) Register(contestID uuid.UUID, userID uuid.UUID, req *dto.RegisterContestRequest) error {
+	contest, err := s.findContest(contestID)
+	if err != nil {
+		return err
+	}
+	if contest.MaxTeamSize > 0 {
+		return ErrTeamContest
+	}
+	return s.register(contest, userID, nil, req)
+}
+
+// RegisterTeam signs a team up for a team contest as a single participant.
+// The captain's credentials are used for private contests.
+func (s *ContestService) RegisterTeam(contestID uuid.UUID, team *domain.Team, req *dto.RegisterContestRequest) error {
+	contest, err := s.findContest(contestID)
+	if err != nil {
+		return err
+	}
+	if contest.MaxTeamSize == 0 {
+		return ErrNotTeamContest
+	}
+	if _, err := s.participantRepo.FindByTeamID(team.ID); err == nil {
+		return ErrAlreadyRegistered
+	}
+	return s.register(contest, team.CaptainID, &team.ID, req)
+}
+
+// register creates a registration for a user or, when teamID is set, for the
+// team the user captains.
+func (s *ContestService) register(contest *domain.Contest, userID uuid.UUID, teamID *uuid.UUID, req *dto.RegisterContestRequest) error {
+	if !time.Now().Before(contest.StartTime) {
+		return ErrContestStarted
+	}
+	if _, err := s.participantRepo.FindByContestAndUser(contest.ID, userID); err == nil {
+		return ErrAlreadyRegistered
+	}
+	participant := &domain.ContestParticipant{
+		ContestID: contest.ID,
+		UserID:    userID,
+		TeamID:    teamID,
+	}
+	if contest.IsPublic {
+		return s.participantRepo.Create(participant)
+	}
+
+	invite, err := s.admitToPrivateContest(contest, userID, req)
+	if err != nil {
+		return err
+	}
+	if invite == nil {
+		return s.participantRepo.Create(participant)
+	}
+	// The invite is only used up if the registration is stored.
+	return s.registrations.WithinTransaction(func(repos *repository.ContestRegistrationRepositories) error {
+		ok, err := repos.Invites.Consume(invite.ID)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return ErrInvalidInviteCode
+		}
+		return repos.Participants.Create(participant)
+	})
+}
+
+// Unregister withdraws a user's registration before the contest starts.
+func (s *ContestService) Unregister(contestID uuid.UUID, userID uuid.UUID) error {
+	contest, err := s.findContest(contestID)
+	if err != nil {
+		return err
+	}
+	if !time.Now().Before(contest.StartTime) {
+		return ErrContestStarted
+	}
+	participant, err := s.participantRepo.FindByContestAndUser(contestID, userID)
+	if err != nil {
+		return ErrNotRegistered
+	}
+
+	return s.participantRepo.Delete(participant.ID)
+}
+
+// StartVirtual starts a virtual run of a finished contest for a user. The run
+// lasts as long as the original contest, starting now.
+func (s *ContestService) StartVirtual(contestID uuid.UUID, userID uuid.UUID) (*domain.VirtualParticipation, error) {
+	contest, err := s.findVisibleContest(contestID, userID, false)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if now.Before(contest.EndTime) {
+		return nil, ErrContestNotEnded
+	}
+	if _, err := s.participantRepo.FindByContestAndMember(contestID, userID); err == nil {
+		return nil, ErrAlreadyParticipated
+	}
+	if _, err := s.virtualRepo.FindByContestAndUser(contestID, userID); err == nil {
+		return nil, ErrVirtualStarted
+	}
+
+	participation := &domain.VirtualParticipation{
+		ContestID: contestID,
+		UserID:    userID,
+		StartedAt: now,
+		EndsAt:    now.Add(contest.EndTime.Sub(contest.StartTime)),
+	}
+	if err := s.virtualRepo.Create(participation); err != nil {
+		return nil, err
+	}
+	return participation, nil
+}
+
+// ListParticipants returns the roster of a contest (admin only).
+func (s *ContestService) ListParticipants(contestID uuid.UUID) (*dto.ContestRosterResponse, error) {
+	if _, err := s.contestRepo.FindByID(contestID); err != nil {
+		return nil, err
+	}
+
+	participants, err := s.participantRepo.FindByContestID(contestID)
+	if err != nil {
+		return nil, err
+	}
+
+	participantDTOs := []dto.ContestParticipantDTO{}
+	for _, p := range participants {
+		participantDTOs = append(participantDTOs, dto.ContestParticipantDTO{
+			UserID:       p.UserID,
+			Username:     p.User.Username,
+			FullName:     p.User.FullName,
+			Email:        p.User.Email,
+			TeamID:       p.TeamID,
+			TeamName:     teamName(p.Team),
+			RegisteredAt: p.RegisteredAt,
+		})
+	}
+
+	return &dto.ContestRosterResponse{
+		Participants: participantDTOs,
+		Total:        len(participantDTOs),
+	}, nil
+}
+
+// JoinWithInvite registers a user for the contest an invite code belongs to.
+func (s *ContestService) JoinWithInvite(code string, userID uuid.UUID) (*domain.Contest, error) {
+	invite, err := s.inviteRepo.FindByCode(strings.ToUpper(strings.TrimSpace(code)))
+	if err != nil {
+		return nil, ErrInvalidInviteCode
+	}
+	if err := s.Register(invite.ContestID, userID, &dto.RegisterContestRequest{InviteCode: invite.Code}); err != nil {
+		return nil, err
+	}
+	return s.contestRepo.FindByID(invite.ContestID)
+}
+
+// CreateInvite generates a new invite code for a contest (admin only).
+func (s *ContestService) CreateInvite(contestID uuid.UUID, req *dto.CreateContestInviteRequest, createdBy uuid.UUID) (*domain.ContestInvite, error) {
+	if _, err := s.contestRepo.FindByID(contestID); err != nil {
+		return nil, err
+	}
+
+	code, err := generateInviteCode()
+	if err != nil {
+		return nil, err
+	}
+
+	invite := &domain.ContestInvite{
+		ContestID: contestID,
+		Code:      code,
+		MaxUses:   req.MaxUses,
+		ExpiresAt: req.ExpiresAt,
+		CreatedBy: createdBy,
+	}
+	if err := s.inviteRepo.Create(invite); err != nil {
+		return nil, err
+	}
+	return invite, nil
+}
+
+// ListInvites lists the invite codes of a contest (admin only).
+func (s *ContestService) ListInvites(contestID uuid.UUID) ([]dto.ContestInviteDTO, error) {
+	if _, err := s.contestRepo.FindByID(contestID); err != nil {
+		return nil, err
+	}
+
+	invites, err := s.inviteRepo.FindByContestID(contestID)
+	if err != nil {
+		return nil, err
+	}
+
+	inviteDTOs := []dto.ContestInviteDTO{}
+	for _, invite := range invites {
+		inviteDTOs = append(inviteDTOs, *dto.ContestInviteDTOFromDomain(invite))
+	}
+	return inviteDTOs, nil
+}
+
+// RevokeInvite deletes an invite code of a contest (admin only).
+func (s *ContestService) RevokeInvite(contestID uuid.UUID, inviteID uuid.UUID) error {
+	invite, err := s.inviteRepo.FindByID(inviteID)
+	if err != nil || invite.ContestID != contestID {
+		return errors.New("invite not found")
+	}
+	return s.inviteRepo.Delete(invite.ID)
+}
+
+// ListAllowedUsers lists the allowlist of a contest (admin only).
+func (s *ContestService) ListAllowedUsers(contestID uuid.UUID) ([]dto.ContestAllowedUserDTO, error) {
+	if _, err := s.contestRepo.FindByID(contestID); err != nil {
+		return nil, err
+	}
+
+	allowed, err := s.allowedUserRepo.FindByContestID(contestID)
+	if err != nil {
+		return nil, err
+	}
+
+	allowedDTOs := []dto.ContestAllowedUserDTO{}
+	for _, a := range allowed {
+		allowedDTOs = append(allowedDTOs, dto.ContestAllowedUserDTO{
+			UserID:   a.UserID,
+			Username: a.User.Username,
+			FullName: a.User.FullName,
+			AddedAt:  a.AddedAt,
+		})
+	}
+	return allowedDTOs, nil
+}
+
+// AllowUser adds a user to the allowlist of a contest (admin only).
+func (s *ContestService) AllowUser(contestID uuid.UUID, req *dto.AddAllowedUserRequest) (*dto.ContestAllowedUserDTO, error) {
+	if _, err := s.contestRepo.FindByID(contestID); err != nil {
+		return nil, err
+	}
+	user, err := s.userRepo.FindByUsername(req.Username)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+	if _, err := s.allowedUserRepo.FindByContestAndUser(contestID, user.ID); err == nil {
+		return nil, ErrAlreadyAllowed
+	}
+
+	allowed := &domain.ContestAllowedUser{
+		ContestID: contestID,
+		UserID:    user.ID,
+	}
+	if err := s.allowedUserRepo.Create(allowed); err != nil {
+		return nil, err
+	}
+
+	return &dto.ContestAllowedUserDTO{
+		UserID:   user.ID,
+		Username: user.Username,
+		FullName: user.FullName,
+		AddedAt:  allowed.AddedAt,
+	}, nil
+}
+
+// DisallowUser removes a user from the allowlist of a contest (admin only).
+func (s *ContestService) DisallowUser(contestID uuid.UUID, userID uuid.UUID) error {
+	allowed, err := s.allowedUserRepo.FindByContestAndUser(contestID, userID)
+	if err != nil {
+		return errors.New("user is not on the allowlist")
+	}
+	return s.allowedUserRepo.Delete(allowed.ID)
+}
+
+// findContest loads a contest, reporting a missing one as ErrContestNotFound.
+func (s *ContestService) findContest(id uuid.UUID) (*domain.Contest, error) {
+	contest, err := s.contestRepo.FindByID(id)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, ErrContestNotFound
+	}
+	return contest, err
+}
+
+// findVisibleContest loads a contest, hiding private contests from users who
+// are neither registered for nor allowlisted in them.
+func (s *ContestService) findVisibleContest(id uuid.UUID, viewerID uuid.UUID, isAdmin bool) (*domain.Contest, error) {
+	contest, err := s.contestRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if contest.IsPublic || isAdmin {
+		return contest, nil
+	}
+	if viewerID != uuid.Nil {
+		if _, err := s.participantRepo.FindByContestAndMember(id, viewerID); err == nil {
+			return contest, nil
+		}
+		if _, err := s.allowedUserRepo.FindByContestAndUser(id, viewerID); err == nil {
+			return contest, nil
+		}
+	}
+	return nil, ErrContestNotFound
+}
+
+// admitToPrivateContest checks a user's credentials for a private contest. If
+// an invite code admits them, it returns the invite, for the caller to use up
+// when it registers them.
+func (s *ContestService) admitToPrivateContest(contest *domain.Contest, userID uuid.UUID, req *dto.RegisterContestRequest) (*domain.ContestInvite, error) {
+	if _, err := s.allowedUserRepo.FindByContestAndUser(contest.ID, userID); err == nil {
+		return nil, nil
+	}
+	if req == nil {
+		return nil, ErrContestAccessDenied
+	}
+
+	if req.InviteCode != "" {
+		invite, err := s.inviteRepo.FindByCode(strings.ToUpper(strings.TrimSpace(req.InviteCode)))
+		if err != nil || invite.ContestID != contest.ID || !invite.Usable(time.Now()) {
+			return nil, ErrInvalidInviteCode
+		}
+		return invite, nil
+	}
+
+	if req.Password != "" && contest.PasswordHash != "" {
+		if err := bcrypt.CompareHashAndPassword([]byte(contest.PasswordHash), []byte(req.Password)); err == nil {
+			return nil, nil
+		}
+	}
+	return nil, ErrContestAccessDenied
+}
+
+// generateInviteCode returns a random invite code.
+func generateInviteCode() (string, error) {
+	buf := make([]byte, inviteCodeLength)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	for i, b := range buf {
+		buf[i] = inviteCodeAlphabet[int(b)%len(inviteCodeAlphabet)]
+	}
+	return string(buf), nil
+}
+
+// teamName returns the name of a team, or "" for individual registrations.
+func teamName(team *domain.Team) string {
+	if team == nil {
+		return ""
+	}
+	return team.Name
+}
+
+// nextContestLabel returns the first label not yet used in the problem set,
+// counting A to Z, then AA, AB and so on.
+func nextContestLabel(existing []*domain.ContestProblem) string {
+	used := make(map[string]bool, len(existing))
+	for _, cp := range existing {
+		used[cp.Label] = true
+	}
+	for n := 0; ; n++ {
+		if label := contestLabel(n); !used[label] {
+			return label
+		}
+	}
+}
+
+// contestLabel returns the n-th label, counting from zero, in spreadsheet
+// column order: A..Z, AA..AZ, BA..
+func contestLabel(n int) string {
+	label := ""
+	for n++; n > 0; n = (n - 1) / 26 {
+		label = string(rune('A'+(n-1)%26)) + label
+	}
+	return label
+}
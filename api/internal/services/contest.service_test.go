@@ -0,0 +1,150 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+	"github.com/klaus-creations/klaus-judge/api/internal/dto"
+	"github.com/klaus-creations/klaus-judge/api/internal/repository"
+)
+
+func TestNextContestLabel(t *testing.T) {
+	labels := func(n int) []*domain.ContestProblem {
+		existing := make([]*domain.ContestProblem, n)
+		for i := range existing {
+			existing[i] = &domain.ContestProblem{Label: contestLabel(i)}
+		}
+		return existing
+	}
+
+	tests := []struct {
+		name     string
+		existing []*domain.ContestProblem
+		want     string
+	}{
+		{"empty problem set", nil, "A"},
+		{"fills a gap", []*domain.ContestProblem{{Label: "A"}, {Label: "C"}}, "B"},
+		{"last single letter", labels(25), "Z"},
+		{"after Z", labels(26), "AA"},
+		{"after AZ", labels(52), "BA"},
+		{"after ZZ", labels(702), "AAA"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nextContestLabel(tt.existing); got != tt.want {
+				t.Errorf("nextContestLabel() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegisterWithInviteCode(t *testing.T) {
+	contest := &domain.Contest{ID: uuid.New(), StartTime: time.Now().Add(time.Hour), EndTime: time.Now().Add(2 * time.Hour)}
+	store := &registrationStore{
+		invite:       &domain.ContestInvite{ID: uuid.New(), ContestID: contest.ID, Code: "JOINUS", MaxUses: 1},
+		participants: map[uuid.UUID]*domain.ContestParticipant{},
+	}
+	service := NewContestService(fakeContestRepo{contest: contest}, nil, nil, registrationParticipantRepo{store: store}, nil,
+		registrationInviteRepo{store: store}, registrationAllowedUserRepo{}, nil, registrationTransactor{store})
+	req := &dto.RegisterContestRequest{InviteCode: "joinus"}
+	first, second := uuid.New(), uuid.New()
+
+	store.failCreate = true
+	if err := service.Register(contest.ID, first, req); err == nil {
+		t.Fatal("Register with a failing insert succeeded")
+	}
+	if store.invite.Uses != 0 {
+		t.Fatalf("invite uses after a failed registration = %d, want 0", store.invite.Uses)
+	}
+
+	store.failCreate = false
+	if err := service.Register(contest.ID, first, req); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if store.invite.Uses != 1 || store.participants[first] == nil {
+		t.Fatalf("after registering: %d uses, participant %v; want 1 use and the participant", store.invite.Uses, store.participants[first])
+	}
+
+	if err := service.Register(contest.ID, second, req); !errors.Is(err, ErrInvalidInviteCode) {
+		t.Fatalf("Register with a used-up code: got %v, want ErrInvalidInviteCode", err)
+	}
+}
+
+// registrationStore holds the rows a registration writes; registrationTransactor
+// restores them when the transaction fails.
+type registrationStore struct {
+	invite       *domain.ContestInvite
+	participants map[uuid.UUID]*domain.ContestParticipant
+	failCreate   bool
+}
+
+type registrationTransactor struct{ store *registrationStore }
+
+func (t registrationTransactor) WithinTransaction(fn func(repos *repository.ContestRegistrationRepositories) error) error {
+	uses := t.store.invite.Uses
+	participants := make(map[uuid.UUID]*domain.ContestParticipant, len(t.store.participants))
+	for id, p := range t.store.participants {
+		participants[id] = p
+	}
+	err := fn(&repository.ContestRegistrationRepositories{
+		Participants: registrationParticipantRepo{store: t.store},
+		Invites:      registrationInviteRepo{store: t.store},
+	})
+	if err != nil {
+		t.store.invite.Uses = uses
+		t.store.participants = participants
+	}
+	return err
+}
+
+type registrationParticipantRepo struct {
+	repository.ContestParticipantRepository
+	store *registrationStore
+}
+
+func (r registrationParticipantRepo) FindByContestAndUser(contestID uuid.UUID, userID uuid.UUID) (*domain.ContestParticipant, error) {
+	if p, ok := r.store.participants[userID]; ok {
+		return p, nil
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (r registrationParticipantRepo) Create(participant *domain.ContestParticipant) error {
+	if r.store.failCreate {
+		return errors.New("insert failed")
+	}
+	r.store.participants[participant.UserID] = participant
+	return nil
+}
+
+type registrationInviteRepo struct {
+	repository.ContestInviteRepository
+	store *registrationStore
+}
+
+func (r registrationInviteRepo) FindByCode(code string) (*domain.ContestInvite, error) {
+	if code != r.store.invite.Code {
+		return nil, repository.ErrNotFound
+	}
+	invite := *r.store.invite
+	return &invite, nil
+}
+
+func (r registrationInviteRepo) Consume(id uuid.UUID) (bool, error) {
+	if !r.store.invite.Usable(time.Now()) {
+		return false, nil
+	}
+	r.store.invite.Uses++
+	return true, nil
+}
+
+type registrationAllowedUserRepo struct {
+	repository.ContestAllowedUserRepository
+}
+
+func (registrationAllowedUserRepo) FindByContestAndUser(contestID uuid.UUID, userID uuid.UUID) (*domain.ContestAllowedUser, error) {
+	return nil, repository.ErrNotFound
+}
@@ -0,0 +1,170 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+	"github.com/klaus-creations/klaus-judge/api/internal/dto"
+	"github.com/klaus-creations/klaus-judge/api/internal/repository"
+)
+
+var (
+	ErrEditorialNotFound         = errors.New("editorial not found")
+	ErrEditorialLocked           = errors.New("solve the problem to unlock its editorial")
+	ErrEditorialContestRunning   = errors.New("the editorial is locked while a contest with this problem is running")
+	ErrDuplicateSolutionLanguage = errors.New("an editorial has at most one reference solution per language")
+)
+
+// EditorialService manages problem editorials and who may read them.
+type EditorialService struct {
+	editorialRepo      repository.ProblemEditorialRepository
+	problemRepo        repository.ProblemRepository
+	submissionRepo     repository.SubmissionRepository
+	contestProblemRepo repository.ContestProblemRepository
+	participantRepo    repository.ContestParticipantRepository
+	collaboratorRepo   repository.ProblemCollaboratorRepository
+}
+
+// NewEditorialService creates a new editorial service.
+func NewEditorialService(
+	editorialRepo repository.ProblemEditorialRepository,
+	problemRepo repository.ProblemRepository,
+	submissionRepo repository.SubmissionRepository,
+	contestProblemRepo repository.ContestProblemRepository,
+	participantRepo repository.ContestParticipantRepository,
+	collaboratorRepo repository.ProblemCollaboratorRepository,
+) *EditorialService {
+	return &EditorialService{
+		editorialRepo:      editorialRepo,
+		problemRepo:        problemRepo,
+		submissionRepo:     submissionRepo,
+		contestProblemRepo: contestProblemRepo,
+		participantRepo:    participantRepo,
+		collaboratorRepo:   collaboratorRepo,
+	}
+}
+
+// GetEditorial returns the editorial of a problem. Admins and the problem's
+// collaborators always see it; other users once it has unlocked for them.
+func (s *EditorialService) GetEditorial(slug string, viewerID uuid.UUID, isAdmin bool) (*dto.EditorialResponse, error) {
+	problem, err := s.problemRepo.FindBySlug(slug)
+	if err != nil {
+		return nil, ErrProblemNotFound
+	}
+
+	now := time.Now()
+	staff := isAdmin || hasProblemPermission(s.collaboratorRepo, problem.ID, viewerID, ProblemPermissionView)
+	if !staff {
+		released, err := problemReleased(s.contestProblemRepo, s.participantRepo, problem, viewerID, now)
+		if err != nil {
+			return nil, err
+		}
+		if !released {
+			return nil, ErrProblemNotFound
+		}
+	}
+
+	editorial, err := s.editorialRepo.FindByProblemID(problem.ID)
+	if err != nil {
+		return nil, ErrEditorialNotFound
+	}
+
+	if !staff {
+		unlocked, err := s.editorialUnlocked(problem, editorial, viewerID, now)
+		if err != nil {
+			return nil, err
+		}
+		if !unlocked {
+			return nil, ErrEditorialLocked
+		}
+	}
+	return dto.EditorialResponseFromDomain(editorial), nil
+}
+
+// SetEditorial creates or replaces the editorial of a problem.
+func (s *EditorialService) SetEditorial(slug string, req *dto.EditorialRequest, authorID uuid.UUID) (*dto.EditorialResponse, error) {
+	problem, err := s.problemRepo.FindBySlug(slug)
+	if err != nil {
+		return nil, ErrProblemNotFound
+	}
+
+	solutions := make([]domain.EditorialSolution, 0, len(req.Solutions))
+	seen := make(map[string]bool, len(req.Solutions))
+	for _, sol := range req.Solutions {
+		if !isValidLanguage(sol.Language) {
+			return nil, ErrUnsupportedLanguage
+		}
+		if seen[sol.Language] {
+			return nil, ErrDuplicateSolutionLanguage
+		}
+		seen[sol.Language] = true
+		solutions = append(solutions, domain.EditorialSolution{
+			Language: sol.Language,
+			Code:     sol.Code,
+		})
+	}
+
+	editorial, err := s.editorialRepo.FindByProblemID(problem.ID)
+	if err != nil {
+		editorial = &domain.ProblemEditorial{
+			ProblemID: problem.ID,
+			AuthorID:  authorID,
+		}
+	}
+	editorial.Content = req.Content
+	editorial.RevealAt = req.RevealAt
+	editorial.Solutions = solutions
+
+	if err := s.editorialRepo.Save(editorial); err != nil {
+		return nil, err
+	}
+	return dto.EditorialResponseFromDomain(editorial), nil
+}
+
+// DeleteEditorial removes the editorial of a problem.
+func (s *EditorialService) DeleteEditorial(slug string) error {
+	problem, err := s.problemRepo.FindBySlug(slug)
+	if err != nil {
+		return ErrProblemNotFound
+	}
+
+	editorial, err := s.editorialRepo.FindByProblemID(problem.ID)
+	if err != nil {
+		return ErrEditorialNotFound
+	}
+	return s.editorialRepo.Delete(editorial.ID)
+}
+
+// editorialUnlocked reports whether a user may read an editorial. Nobody may
+// while a contest containing the problem is running, which is reported as
+// ErrEditorialContestRunning. Otherwise everyone may from its reveal date or
+// once the last contest containing the problem has ended, and users who
+// solved the problem may at any time.
+func (s *EditorialService) editorialUnlocked(problem *domain.Problem, editorial *domain.ProblemEditorial, viewerID uuid.UUID, now time.Time) (bool, error) {
+	running, err := s.contestProblemRepo.FindRunningByProblemID(problem.ID, now)
+	if err != nil {
+		return false, err
+	}
+	if len(running) > 0 {
+		return false, ErrEditorialContestRunning
+	}
+
+	if editorial.RevealAt != nil && !now.Before(*editorial.RevealAt) {
+		return true, nil
+	}
+
+	end, err := s.contestProblemRepo.LatestContestEnd(problem.ID)
+	if err != nil {
+		return false, err
+	}
+	if end != nil && !now.Before(*end) {
+		return true, nil
+	}
+
+	if viewerID == uuid.Nil {
+		return false, nil
+	}
+	return s.submissionRepo.HasAccepted(viewerID, problem.ID)
+}
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+	"github.com/klaus-creations/klaus-judge/api/internal/dto"
+	"github.com/klaus-creations/klaus-judge/api/internal/repository"
+)
+
+var (
+	ErrInteractorNotFound = errors.New("problem has no interactor")
+	ErrNotInteractive     = errors.New("problem is not interactive")
+)
+
+// InteractorService manages the interactors of interactive problems.
+type InteractorService struct {
+	interactorRepo repository.ProblemInteractorRepository
+	problemRepo    repository.ProblemRepository
+	transactor     repository.ProblemEditTransactor
+}
+
+// NewInteractorService creates a new interactor service.
+func NewInteractorService(
+	interactorRepo repository.ProblemInteractorRepository,
+	problemRepo repository.ProblemRepository,
+	transactor repository.ProblemEditTransactor,
+) *InteractorService {
+	return &InteractorService{
+		interactorRepo: interactorRepo,
+		problemRepo:    problemRepo,
+		transactor:     transactor,
+	}
+}
+
+// GetInteractor returns the interactor of a problem.
+func (s *InteractorService) GetInteractor(slug string) (*domain.ProblemInteractor, error) {
+	problem, err := s.problemRepo.FindBySlug(slug)
+	if err != nil {
+		return nil, ErrProblemNotFound
+	}
+
+	interactor, err := s.interactorRepo.FindByProblemID(problem.ID)
+	if err != nil {
+		return nil, ErrInteractorNotFound
+	}
+	return interactor, nil
+}
+
+// SetInteractor uploads the interactor of an interactive problem, replacing
+// any existing one, and records a revision.
+func (s *InteractorService) SetInteractor(slug string, req *dto.SetInteractorRequest, userID uuid.UUID) (*domain.ProblemInteractor, error) {
+	if !isValidJudgeProgramLanguage(req.Language) {
+		return nil, ErrUnsupportedLanguage
+	}
+
+	problem, err := s.problemRepo.FindBySlug(slug)
+	if err != nil {
+		return nil, ErrProblemNotFound
+	}
+	if problem.Type != domain.ProblemTypeInteractive {
+		return nil, ErrNotInteractive
+	}
+
+	interactor, err := s.interactorRepo.FindByProblemID(problem.ID)
+	if err != nil {
+		interactor = &domain.ProblemInteractor{ProblemID: problem.ID}
+	}
+	interactor.Language = req.Language
+	interactor.Code = req.Code
+	interactor.CreatedBy = userID
+
+	err = editProblem(s.transactor, problem, userID, domain.RevisionChangeSetInteractor, func(repos *repository.ProblemEditRepositories) error {
+		if interactor.ID == uuid.Nil {
+			return repos.Interactors.Create(interactor)
+		}
+		return repos.Interactors.Update(interactor)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return interactor, nil
+}
+
+// DeleteInteractor removes the interactor of a problem and records a
+// revision. Submissions to an interactive problem without an interactor fail
+// with a system error.
+func (s *InteractorService) DeleteInteractor(slug string, editorID uuid.UUID) error {
+	problem, err := s.problemRepo.FindBySlug(slug)
+	if err != nil {
+		return ErrProblemNotFound
+	}
+
+	if _, err := s.interactorRepo.FindByProblemID(problem.ID); err != nil {
+		return ErrInteractorNotFound
+	}
+	return editProblem(s.transactor, problem, editorID, domain.RevisionChangeDeleteInteractor, func(repos *repository.ProblemEditRepositories) error {
+		return repos.Interactors.DeleteByProblemID(problem.ID)
+	})
+}
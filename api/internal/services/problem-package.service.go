@@ -0,0 +1,422 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/gosimple/slug"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+	"github.com/klaus-creations/klaus-judge/api/internal/dto"
+	"github.com/klaus-creations/klaus-judge/api/internal/repository"
+)
+
+var (
+	ErrInvalidProblemPackage = errors.New("invalid problem package")
+	ErrProblemSlugTaken      = errors.New("a problem with this slug already exists")
+)
+
+const (
+	problemPackageManifest   = "problem.json"
+	problemPackageStatement  = "statement.md"
+	problemPackageTests      = "tests"
+	problemPackageChecker    = "checker"
+	problemPackageInteractor = "interactor"
+
+	// maxProblemPackageFile bounds every decompressed file of an imported package.
+	maxProblemPackageFile = 64 << 20
+)
+
+// ExportProblem packs a problem, its statement, its checker or interactor and
+// all of its test cases into a zip archive that ImportProblem can read back on
+// another judge.
+func (s *ProblemService) ExportProblem(slugStr string) ([]byte, error) {
+	problem, err := s.problemRepo.FindBySlug(slugStr)
+	if err != nil {
+		return nil, ErrProblemNotFound
+	}
+	testCases, err := s.testCaseRepo.FindByProblemID(problem.ID)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := s.testGroupRepo.FindByProblemID(problem.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	statements, err := s.statementRepo.FindByProblemID(problem.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := dto.ProblemPackageManifest{
+		Format:        dto.ProblemPackageFormat,
+		Title:         problem.Title,
+		InputFormat:   problem.InputFormat,
+		OutputFormat:  problem.OutputFormat,
+		Notes:         problem.Notes,
+		DefaultLocale: problem.DefaultLocale,
+		Slug:          problem.Slug,
+		Difficulty:    problem.Difficulty,
+		TimeLimit:     problem.TimeLimit,
+		MemoryLimit:   problem.MemoryLimit,
+		ScoringMode:   problem.ScoringMode,
+		Type:          problem.Type,
+		Tags:          problem.TagNames(),
+		Tests:         []dto.ProblemPackageTest{},
+	}
+	for _, st := range statements {
+		manifest.Translations = append(manifest.Translations, dto.ProblemPackageTranslation{
+			Locale:       st.Locale,
+			Title:        st.Title,
+			Description:  st.Description,
+			InputFormat:  st.InputFormat,
+			OutputFormat: st.OutputFormat,
+			Notes:        st.Notes,
+		})
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	writeFile := func(name string, data string) error {
+		w, err := zw.Create(name)
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, data)
+		return err
+	}
+
+	if err := writeFile(problemPackageStatement, problem.Description); err != nil {
+		return nil, err
+	}
+
+	groupNames := make(map[uuid.UUID]string, len(groups))
+	for _, g := range groups {
+		groupNames[g.ID] = g.Name
+	}
+	for _, g := range groups {
+		group := dto.ProblemPackageGroup{
+			Name:        g.Name,
+			Points:      g.Points,
+			ScoringRule: g.ScoringRule,
+		}
+		for _, dep := range g.DependsOn() {
+			group.DependsOn = append(group.DependsOn, groupNames[dep])
+		}
+		manifest.Groups = append(manifest.Groups, group)
+	}
+
+	// Zero-pad the names so the files sort in test order.
+	width := len(fmt.Sprint(len(testCases)))
+	if width < 2 {
+		width = 2
+	}
+	for i, tc := range testCases {
+		name := fmt.Sprintf("%0*d", width, i+1)
+		if err := writeFile(path.Join(problemPackageTests, name+".in"), tc.Input); err != nil {
+			return nil, err
+		}
+		if err := writeFile(path.Join(problemPackageTests, name+".out"), tc.ExpectedOutput); err != nil {
+			return nil, err
+		}
+		test := dto.ProblemPackageTest{
+			Name:     name,
+			IsSample: tc.IsSample,
+			IsHidden: tc.IsHidden,
+			Points:   tc.Points,
+		}
+		if tc.GroupID != nil {
+			test.Group = groupNames[*tc.GroupID]
+		}
+		manifest.Tests = append(manifest.Tests, test)
+	}
+
+	if checker, err := s.checkerRepo.FindByProblemID(problem.ID); err == nil {
+		manifest.Checker = &dto.ProblemPackageProgram{
+			Language: checker.Language,
+			File:     "checker." + checker.Language,
+		}
+		if err := writeFile(path.Join(problemPackageChecker, manifest.Checker.File), checker.Code); err != nil {
+			return nil, err
+		}
+	}
+	if interactor, err := s.interactorRepo.FindByProblemID(problem.ID); err == nil {
+		manifest.Interactor = &dto.ProblemPackageProgram{
+			Language: interactor.Language,
+			File:     "interactor." + interactor.Language,
+		}
+		if err := writeFile(path.Join(problemPackageInteractor, manifest.Interactor.File), interactor.Code); err != nil {
+			return nil, err
+		}
+	}
+
+	manifestJSON, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := writeFile(problemPackageManifest, string(manifestJSON)); err != nil {
+		return nil, err
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ImportProblem creates a problem, its checker or interactor and all of its
+// test cases from a zip archive produced by ExportProblem. Nothing is stored
+// unless the whole package is valid.
+func (s *ProblemService) ImportProblem(data []byte, createdBy uuid.UUID) (*domain.Problem, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidProblemPackage, err)
+	}
+
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[path.Clean(f.Name)] = f
+	}
+	readFile := func(name string) (string, error) {
+		f, ok := files[name]
+		if !ok {
+			return "", fmt.Errorf("%w: missing %s", ErrInvalidProblemPackage, name)
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return "", fmt.Errorf("%w: %v", ErrInvalidProblemPackage, err)
+		}
+		defer rc.Close()
+		content, err := io.ReadAll(io.LimitReader(rc, maxProblemPackageFile+1))
+		if err != nil {
+			return "", fmt.Errorf("%w: %v", ErrInvalidProblemPackage, err)
+		}
+		if len(content) > maxProblemPackageFile {
+			return "", fmt.Errorf("%w: %s is too large", ErrInvalidProblemPackage, name)
+		}
+		return string(content), nil
+	}
+
+	manifestJSON, err := readFile(problemPackageManifest)
+	if err != nil {
+		return nil, err
+	}
+	var manifest dto.ProblemPackageManifest
+	if err := json.Unmarshal([]byte(manifestJSON), &manifest); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidProblemPackage, err)
+	}
+	if manifest.Format < 1 || manifest.Format > dto.ProblemPackageFormat {
+		return nil, fmt.Errorf("%w: unsupported format %d", ErrInvalidProblemPackage, manifest.Format)
+	}
+	if manifest.Title == "" || manifest.TimeLimit <= 0 || manifest.MemoryLimit <= 0 {
+		return nil, fmt.Errorf("%w: title, time_limit and memory_limit are required", ErrInvalidProblemPackage)
+	}
+	if manifest.ScoringMode != "" && manifest.ScoringMode != domain.ScoringICPC && manifest.ScoringMode != domain.ScoringIOI {
+		return nil, fmt.Errorf("%w: unknown scoring_mode %q", ErrInvalidProblemPackage, manifest.ScoringMode)
+	}
+	if manifest.Type != "" && manifest.Type != domain.ProblemTypeStandard && manifest.Type != domain.ProblemTypeInteractive {
+		return nil, fmt.Errorf("%w: unknown type %q", ErrInvalidProblemPackage, manifest.Type)
+	}
+
+	statement, err := readFile(problemPackageStatement)
+	if err != nil {
+		return nil, err
+	}
+
+	slugStr := manifest.Slug
+	if slugStr == "" {
+		slugStr = slug.Make(manifest.Title)
+	}
+	if _, err := s.problemRepo.FindBySlug(slugStr); err == nil {
+		return nil, ErrProblemSlugTaken
+	}
+
+	problem := &domain.Problem{
+		Title:         manifest.Title,
+		Slug:          slugStr,
+		Description:   statement,
+		InputFormat:   manifest.InputFormat,
+		OutputFormat:  manifest.OutputFormat,
+		Notes:         manifest.Notes,
+		DefaultLocale: defaultLocale,
+		Difficulty:    manifest.Difficulty,
+		TimeLimit:     manifest.TimeLimit,
+		MemoryLimit:   manifest.MemoryLimit,
+		ScoringMode:   domain.ScoringICPC,
+		Type:          domain.ProblemTypeStandard,
+		Status:        domain.ProblemStatusDraft,
+		CreatedBy:     createdBy,
+		Collaborators: []domain.ProblemCollaborator{
+			{UserID: createdBy, Role: domain.CollaboratorRoleOwner},
+		},
+	}
+	if manifest.DefaultLocale != "" {
+		locale, ok := normalizeLocale(manifest.DefaultLocale)
+		if !ok {
+			return nil, fmt.Errorf("%w: invalid default_locale %q", ErrInvalidProblemPackage, manifest.DefaultLocale)
+		}
+		problem.DefaultLocale = locale
+	}
+	locales := map[string]bool{problem.DefaultLocale: true}
+	for _, t := range manifest.Translations {
+		locale, ok := normalizeLocale(t.Locale)
+		if !ok || locales[locale] {
+			return nil, fmt.Errorf("%w: invalid or duplicate translation locale %q", ErrInvalidProblemPackage, t.Locale)
+		}
+		if t.Title == "" || t.Description == "" {
+			return nil, fmt.Errorf("%w: translation %s needs a title and a description", ErrInvalidProblemPackage, locale)
+		}
+		locales[locale] = true
+		problem.Statements = append(problem.Statements, domain.ProblemStatement{
+			Locale:       locale,
+			Title:        t.Title,
+			Description:  t.Description,
+			InputFormat:  t.InputFormat,
+			OutputFormat: t.OutputFormat,
+			Notes:        t.Notes,
+		})
+	}
+	if manifest.ScoringMode != "" {
+		problem.ScoringMode = manifest.ScoringMode
+	}
+	if manifest.Type != "" {
+		problem.Type = manifest.Type
+	}
+
+	// readProgram loads the source of a checker or interactor.
+	readProgram := func(dir string, program *dto.ProblemPackageProgram) (string, error) {
+		if !isValidJudgeProgramLanguage(program.Language) {
+			return "", fmt.Errorf("%w: unsupported %s language %q", ErrInvalidProblemPackage, dir, program.Language)
+		}
+		if program.File == "" || strings.ContainsAny(program.File, `/\`) {
+			return "", fmt.Errorf("%w: invalid %s file %q", ErrInvalidProblemPackage, dir, program.File)
+		}
+		return readFile(path.Join(dir, program.File))
+	}
+	if manifest.Checker != nil {
+		code, err := readProgram(problemPackageChecker, manifest.Checker)
+		if err != nil {
+			return nil, err
+		}
+		problem.Checker = &domain.ProblemChecker{
+			Language:  manifest.Checker.Language,
+			Code:      code,
+			CreatedBy: createdBy,
+		}
+	}
+	if manifest.Interactor != nil {
+		code, err := readProgram(problemPackageInteractor, manifest.Interactor)
+		if err != nil {
+			return nil, err
+		}
+		problem.Interactor = &domain.ProblemInteractor{
+			Language:  manifest.Interactor.Language,
+			Code:      code,
+			CreatedBy: createdBy,
+		}
+	}
+
+	// Group IDs are assigned up front so dependencies and tests can refer to them.
+	groupIDs := make(map[string]uuid.UUID, len(manifest.Groups))
+	for _, g := range manifest.Groups {
+		if g.Name == "" || groupIDs[g.Name] != uuid.Nil {
+			return nil, fmt.Errorf("%w: group names must be unique and non-empty", ErrInvalidProblemPackage)
+		}
+		id, err := uuid.NewV7()
+		if err != nil {
+			return nil, err
+		}
+		groupIDs[g.Name] = id
+	}
+	for i, g := range manifest.Groups {
+		group := domain.TestGroup{
+			ID:          groupIDs[g.Name],
+			Name:        g.Name,
+			Points:      g.Points,
+			ScoringRule: domain.GroupScoringAll,
+			OrderIndex:  i + 1,
+		}
+		if g.ScoringRule == domain.GroupScoringMin {
+			group.ScoringRule = domain.GroupScoringMin
+		} else if g.ScoringRule != "" && g.ScoringRule != domain.GroupScoringAll {
+			return nil, fmt.Errorf("%w: unknown scoring_rule %q", ErrInvalidProblemPackage, g.ScoringRule)
+		}
+		for _, dep := range g.DependsOn {
+			depID, ok := groupIDs[dep]
+			if !ok || dep == g.Name {
+				return nil, fmt.Errorf("%w: group %q has an invalid dependency %q", ErrInvalidProblemPackage, g.Name, dep)
+			}
+			group.Dependencies = append(group.Dependencies, domain.TestGroupDependency{
+				GroupID:     group.ID,
+				DependsOnID: depID,
+			})
+		}
+		problem.TestGroups = append(problem.TestGroups, group)
+	}
+	for i := range problem.TestGroups {
+		if err := validateGroupDependencies(&problem.TestGroups[i], testGroupPointers(problem.TestGroups)); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidProblemPackage, err)
+		}
+	}
+
+	var testCases []*domain.TestCase
+	for i, t := range manifest.Tests {
+		if t.Name == "" || strings.ContainsAny(t.Name, `/\`) {
+			return nil, fmt.Errorf("%w: invalid test name %q", ErrInvalidProblemPackage, t.Name)
+		}
+		input, err := readFile(path.Join(problemPackageTests, t.Name+".in"))
+		if err != nil {
+			return nil, err
+		}
+		output, err := readFile(path.Join(problemPackageTests, t.Name+".out"))
+		if err != nil {
+			return nil, err
+		}
+		testCase := &domain.TestCase{
+			Input:          input,
+			ExpectedOutput: output,
+			IsSample:       t.IsSample,
+			IsHidden:       t.IsHidden,
+			Points:         t.Points,
+			OrderIndex:     i + 1,
+		}
+		if t.Group != "" {
+			groupID, ok := groupIDs[t.Group]
+			if !ok {
+				return nil, fmt.Errorf("%w: test %s refers to unknown group %q", ErrInvalidProblemPackage, t.Name, t.Group)
+			}
+			testCase.GroupID = &groupID
+		}
+		testCases = append(testCases, testCase)
+	}
+
+	if problem.Tags, err = s.resolveTags(manifest.Tags); err != nil {
+		return nil, err
+	}
+	err = s.transactor.WithinTransaction(func(repos *repository.ProblemEditRepositories) error {
+		if err := repos.Problems.CreateWithTestCases(problem, testCases); err != nil {
+			return err
+		}
+		return recordRevision(repos, problem, createdBy, domain.RevisionChangeCreate, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return problem, nil
+}
+
+// testGroupPointers returns pointers to the elements of groups.
+func testGroupPointers(groups []domain.TestGroup) []*domain.TestGroup {
+	pointers := make([]*domain.TestGroup, 0, len(groups))
+	for i := range groups {
+		pointers = append(pointers, &groups[i])
+	}
+	return pointers
+}
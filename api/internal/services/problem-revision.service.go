@@ -0,0 +1,468 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+	"github.com/klaus-creations/klaus-judge/api/internal/dto"
+	"github.com/klaus-creations/klaus-judge/api/internal/repository"
+)
+
+var (
+	ErrRevisionNotFound = errors.New("problem revision not found")
+	ErrRollbackJudged   = errors.New("the revision lacks test cases that submissions were judged on; delete them explicitly first")
+)
+
+// maxStatementDiffCells bounds the table used to diff two statements line by
+// line; larger statements are shown as replaced outright.
+const maxStatementDiffCells = 1 << 20
+
+// ListRevisions returns the revision history of a problem, newest first.
+func (s *ProblemService) ListRevisions(slug string) ([]dto.ProblemRevisionSummaryDTO, error) {
+	problem, err := s.problemRepo.FindBySlug(slug)
+	if err != nil {
+		return nil, ErrProblemNotFound
+	}
+
+	revisions, err := s.revisionRepo.FindByProblemID(problem.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]dto.ProblemRevisionSummaryDTO, 0, len(revisions))
+	for _, r := range revisions {
+		result = append(result, dto.ProblemRevisionSummaryDTOFromDomain(r))
+	}
+	return result, nil
+}
+
+// GetRevision returns one revision of a problem with its test set.
+func (s *ProblemService) GetRevision(slug string, number int) (*dto.ProblemRevisionDTO, error) {
+	_, revision, err := s.findRevision(slug, number)
+	if err != nil {
+		return nil, err
+	}
+
+	tests, err := s.loadTestSet(revision.TestSetHash)
+	if err != nil {
+		return nil, err
+	}
+	return dto.ProblemRevisionDTOFromDomain(revision, tests), nil
+}
+
+// DiffRevisions describes what changed from revision from to revision to.
+func (s *ProblemService) DiffRevisions(slug string, from, to int) (*dto.ProblemRevisionDiffResponse, error) {
+	problem, err := s.problemRepo.FindBySlug(slug)
+	if err != nil {
+		return nil, ErrProblemNotFound
+	}
+	a, err := s.revisionRepo.FindByNumber(problem.ID, from)
+	if err != nil {
+		return nil, ErrRevisionNotFound
+	}
+	b, err := s.revisionRepo.FindByNumber(problem.ID, to)
+	if err != nil {
+		return nil, ErrRevisionNotFound
+	}
+
+	resp := &dto.ProblemRevisionDiffResponse{
+		From:           from,
+		To:             to,
+		Fields:         []dto.RevisionFieldChange{},
+		TestSetChanged: a.TestSetHash != b.TestSetHash,
+		TestsAdded:     []uuid.UUID{},
+		TestsRemoved:   []uuid.UUID{},
+		TestsChanged:   []uuid.UUID{},
+	}
+
+	addField := func(field string, x, y any) {
+		if x != y {
+			resp.Fields = append(resp.Fields, dto.RevisionFieldChange{Field: field, From: x, To: y})
+		}
+	}
+	addField("title", a.Title, b.Title)
+	addField("input_format", a.InputFormat, b.InputFormat)
+	addField("output_format", a.OutputFormat, b.OutputFormat)
+	addField("notes", a.Notes, b.Notes)
+	addField("difficulty", a.Difficulty, b.Difficulty)
+	addField("time_limit", a.TimeLimit, b.TimeLimit)
+	addField("memory_limit", a.MemoryLimit, b.MemoryLimit)
+	addField("scoring_mode", a.ScoringMode, b.ScoringMode)
+	addField("type", a.Type, b.Type)
+	addField("tags", a.Tags, b.Tags)
+
+	if a.Description != b.Description {
+		resp.Statement = diffLines(a.Description, b.Description)
+	}
+
+	if resp.TestSetChanged {
+		before, err := s.loadTestSet(a.TestSetHash)
+		if err != nil {
+			return nil, err
+		}
+		after, err := s.loadTestSet(b.TestSetHash)
+		if err != nil {
+			return nil, err
+		}
+
+		old := make(map[uuid.UUID]domain.TestCaseSnapshot, len(before))
+		for _, tc := range before {
+			old[tc.ID] = tc
+		}
+		for _, tc := range after {
+			prev, ok := old[tc.ID]
+			switch {
+			case !ok:
+				resp.TestsAdded = append(resp.TestsAdded, tc.ID)
+			case !sameTestCase(prev, tc):
+				resp.TestsChanged = append(resp.TestsChanged, tc.ID)
+			}
+			delete(old, tc.ID)
+		}
+		for _, tc := range before {
+			if _, ok := old[tc.ID]; ok {
+				resp.TestsRemoved = append(resp.TestsRemoved, tc.ID)
+			}
+		}
+	}
+
+	return resp, nil
+}
+
+// RollbackProblem restores the statement and its translations, the limits and
+// the test set of a revision and records the result as a new revision. Test
+// cases get their old IDs back, so results of submissions judged against that
+// revision line up again; tests that were in a group since deleted come back
+// ungrouped. Test cases added since then can only be dropped while no
+// submission has been judged on them.
+func (s *ProblemService) RollbackProblem(slug string, number int, editorID uuid.UUID) (*domain.Problem, error) {
+	problem, revision, err := s.findRevision(slug, number)
+	if err != nil {
+		return nil, err
+	}
+
+	tests, err := s.loadTestSet(revision.TestSetHash)
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := s.testGroupRepo.FindByProblemID(problem.ID)
+	if err != nil {
+		return nil, err
+	}
+	groupExists := make(map[uuid.UUID]bool, len(groups))
+	for _, g := range groups {
+		groupExists[g.ID] = true
+	}
+
+	problem.Title = revision.Title
+	problem.Description = revision.Description
+	problem.InputFormat = revision.InputFormat
+	problem.OutputFormat = revision.OutputFormat
+	problem.Notes = revision.Notes
+	problem.Difficulty = revision.Difficulty
+	problem.TimeLimit = revision.TimeLimit
+	problem.MemoryLimit = revision.MemoryLimit
+	problem.ScoringMode = revision.ScoringMode
+	problem.Type = revision.Type
+	if problem.Tags, err = s.resolveTags(strings.Split(revision.Tags, ",")); err != nil {
+		return nil, err
+	}
+
+	testCases := make([]*domain.TestCase, 0, len(tests))
+	for _, tc := range tests {
+		groupID := tc.GroupID
+		if groupID != nil && !groupExists[*groupID] {
+			groupID = nil
+		}
+		testCases = append(testCases, &domain.TestCase{
+			ID:             tc.ID,
+			Input:          tc.Input,
+			ExpectedOutput: tc.ExpectedOutput,
+			IsSample:       tc.IsSample,
+			IsHidden:       tc.IsHidden,
+			Points:         tc.Points,
+			OrderIndex:     tc.OrderIndex,
+			GroupID:        groupID,
+		})
+	}
+
+	err = s.transactor.WithinTransaction(func(repos *repository.ProblemEditRepositories) error {
+		if err := repos.Problems.Restore(problem, testCases); err != nil {
+			if errors.Is(err, repository.ErrTestCaseJudged) {
+				return ErrRollbackJudged
+			}
+			return err
+		}
+		if err := restoreStatements(repos, problem.ID, revision.Statements); err != nil {
+			return err
+		}
+		return recordRevision(repos, problem, editorID, domain.RevisionChangeRollback, &number)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return problem, nil
+}
+
+// findRevision loads a problem and one of its revisions.
+func (s *ProblemService) findRevision(slug string, number int) (*domain.Problem, *domain.ProblemRevision, error) {
+	problem, err := s.problemRepo.FindBySlug(slug)
+	if err != nil {
+		return nil, nil, ErrProblemNotFound
+	}
+
+	revision, err := s.revisionRepo.FindByNumber(problem.ID, number)
+	if err != nil {
+		return nil, nil, ErrRevisionNotFound
+	}
+	return problem, revision, nil
+}
+
+// editProblem applies an edit to a problem and records the result as a
+// revision, in one transaction. A problem that predates revision history
+// first gets a baseline revision of its stored state, so that its first edit
+// can be rolled back.
+func editProblem(transactor repository.ProblemEditTransactor, problem *domain.Problem, editorID uuid.UUID, change string, edit func(repos *repository.ProblemEditRepositories) error) error {
+	return transactor.WithinTransaction(func(repos *repository.ProblemEditRepositories) error {
+		if problem.Revision == 0 {
+			stored, err := repos.Problems.FindByID(problem.ID)
+			if err != nil {
+				return err
+			}
+			if err := recordRevision(repos, stored, stored.CreatedBy, domain.RevisionChangeBaseline, nil); err != nil {
+				return err
+			}
+		}
+		if err := edit(repos); err != nil {
+			return err
+		}
+		return recordRevision(repos, problem, editorID, change, nil)
+	})
+}
+
+// recordRevision snapshots the problem, together with its current test set
+// and translations, and updates problem.Revision to the new revision number.
+func recordRevision(repos *repository.ProblemEditRepositories, problem *domain.Problem, editorID uuid.UUID, change string, rolledBackTo *int) error {
+	testCases, err := repos.TestCases.FindByProblemID(problem.ID)
+	if err != nil {
+		return err
+	}
+	testSet, err := snapshotTestSet(testCases)
+	if err != nil {
+		return err
+	}
+	statements, err := repos.Statements.FindByProblemID(problem.ID)
+	if err != nil {
+		return err
+	}
+	translations, err := snapshotStatements(statements)
+	if err != nil {
+		return err
+	}
+
+	revision := &domain.ProblemRevision{
+		ProblemID:    problem.ID,
+		Change:       change,
+		RolledBackTo: rolledBackTo,
+		Title:        problem.Title,
+		Description:  problem.Description,
+		InputFormat:  problem.InputFormat,
+		OutputFormat: problem.OutputFormat,
+		Notes:        problem.Notes,
+		Difficulty:   problem.Difficulty,
+		TimeLimit:    problem.TimeLimit,
+		MemoryLimit:  problem.MemoryLimit,
+		ScoringMode:  problem.ScoringMode,
+		Type:         problem.Type,
+		Tags:         strings.Join(problem.TagNames(), ","),
+		TestSetHash:  testSet.Hash,
+		Statements:   translations,
+		EditedBy:     editorID,
+	}
+	if err := repos.Revisions.Create(revision, testSet); err != nil {
+		return err
+	}
+	problem.Revision = revision.Number
+	return nil
+}
+
+// loadTestSet decodes a stored test set snapshot.
+func (s *ProblemService) loadTestSet(hash string) ([]domain.TestCaseSnapshot, error) {
+	testSet, err := s.revisionRepo.FindTestSet(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	var tests []domain.TestCaseSnapshot
+	if err := json.Unmarshal([]byte(testSet.Tests), &tests); err != nil {
+		return nil, err
+	}
+	return tests, nil
+}
+
+// snapshotTestSet encodes test cases in judging order and keys the result by
+// its SHA-256, so an unchanged test set always hashes the same.
+func snapshotTestSet(testCases []*domain.TestCase) (*domain.ProblemTestSet, error) {
+	tests := make([]domain.TestCaseSnapshot, 0, len(testCases))
+	for _, tc := range testCases {
+		tests = append(tests, domain.TestCaseSnapshot{
+			ID:             tc.ID,
+			Input:          tc.Input,
+			ExpectedOutput: tc.ExpectedOutput,
+			IsSample:       tc.IsSample,
+			IsHidden:       tc.IsHidden,
+			Points:         tc.Points,
+			OrderIndex:     tc.OrderIndex,
+			GroupID:        tc.GroupID,
+		})
+	}
+	sort.SliceStable(tests, func(i, j int) bool {
+		if tests[i].OrderIndex != tests[j].OrderIndex {
+			return tests[i].OrderIndex < tests[j].OrderIndex
+		}
+		return tests[i].ID.String() < tests[j].ID.String()
+	})
+
+	data, err := json.Marshal(tests)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(data)
+	return &domain.ProblemTestSet{Hash: hex.EncodeToString(sum[:]), Tests: string(data)}, nil
+}
+
+// snapshotStatements encodes statement translations ordered by locale.
+func snapshotStatements(statements []*domain.ProblemStatement) (string, error) {
+	snapshots := make([]domain.StatementSnapshot, 0, len(statements))
+	for _, st := range statements {
+		snapshots = append(snapshots, domain.StatementSnapshot{
+			Locale:       st.Locale,
+			Title:        st.Title,
+			Description:  st.Description,
+			InputFormat:  st.InputFormat,
+			OutputFormat: st.OutputFormat,
+			Notes:        st.Notes,
+		})
+	}
+	sort.Slice(snapshots, func(i, j int) bool { return snapshots[i].Locale < snapshots[j].Locale })
+
+	data, err := json.Marshal(snapshots)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// restoreStatements replaces the translations of a problem with those of a
+// revision. Revisions recorded before translations were tracked leave them
+// as they are.
+func restoreStatements(repos *repository.ProblemEditRepositories, problemID uuid.UUID, recorded string) error {
+	if recorded == "" {
+		return nil
+	}
+	var snapshots []domain.StatementSnapshot
+	if err := json.Unmarshal([]byte(recorded), &snapshots); err != nil {
+		return err
+	}
+
+	current, err := repos.Statements.FindByProblemID(problemID)
+	if err != nil {
+		return err
+	}
+	for _, st := range current {
+		if err := repos.Statements.Delete(st.ID); err != nil {
+			return err
+		}
+	}
+	for _, snap := range snapshots {
+		err := repos.Statements.Create(&domain.ProblemStatement{
+			ProblemID:    problemID,
+			Locale:       snap.Locale,
+			Title:        snap.Title,
+			Description:  snap.Description,
+			InputFormat:  snap.InputFormat,
+			OutputFormat: snap.OutputFormat,
+			Notes:        snap.Notes,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sameTestCase reports whether two snapshots of a test case are identical.
+func sameTestCase(a, b domain.TestCaseSnapshot) bool {
+	sameGroup := (a.GroupID == nil) == (b.GroupID == nil) &&
+		(a.GroupID == nil || *a.GroupID == *b.GroupID)
+	return sameGroup &&
+		a.Input == b.Input &&
+		a.ExpectedOutput == b.ExpectedOutput &&
+		a.IsSample == b.IsSample &&
+		a.IsHidden == b.IsHidden &&
+		a.Points == b.Points &&
+		a.OrderIndex == b.OrderIndex
+}
+
+// diffLines returns the line diff turning a into b, built from their longest
+// common subsequence.
+func diffLines(a, b string) []dto.StatementDiffLine {
+	x := strings.Split(a, "\n")
+	y := strings.Split(b, "\n")
+
+	var diff []dto.StatementDiffLine
+	if len(x)*len(y) > maxStatementDiffCells {
+		for _, line := range x {
+			diff = append(diff, dto.StatementDiffLine{Op: "remove", Text: line})
+		}
+		for _, line := range y {
+			diff = append(diff, dto.StatementDiffLine{Op: "add", Text: line})
+		}
+		return diff
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of x[i:] and y[j:].
+	lcs := make([][]int, len(x)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(y)+1)
+	}
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(x) && j < len(y) {
+		switch {
+		case x[i] == y[j]:
+			diff = append(diff, dto.StatementDiffLine{Op: "keep", Text: x[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			diff = append(diff, dto.StatementDiffLine{Op: "remove", Text: x[i]})
+			i++
+		default:
+			diff = append(diff, dto.StatementDiffLine{Op: "add", Text: y[j]})
+			j++
+		}
+	}
+	for ; i < len(x); i++ {
+		diff = append(diff, dto.StatementDiffLine{Op: "remove", Text: x[i]})
+	}
+	for ; j < len(y); j++ {
+		diff = append(diff, dto.StatementDiffLine{Op: "add", Text: y[j]})
+	}
+	return diff
+}
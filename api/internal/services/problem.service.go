@@ -1,7 +1,11 @@
 package services
 
 import (
+	"errors"
+	"log"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/gosimple/slug"
@@ -11,21 +15,62 @@ import (
 	"github.com/klaus-creations/klaus-judge/api/internal/repository"
 )
 
+var (
+	ErrProblemNotFound        = errors.New("problem not found")
+	ErrExpectedOutputRequired = errors.New("expected output is required for standard problems")
+	ErrTestCaseNotFound       = errors.New("test case not found")
+	ErrInvalidPublishAt       = errors.New("publish_at must be in the future and needs an unpublished status")
+	ErrProblemInUse           = errors.New("problem has submissions or is part of a contest; archive it instead")
+)
+
 // ProblemService handles problem-related business logic.
 type ProblemService struct {
-	problemRepo  repository.ProblemRepository
-	testCaseRepo repository.TestCaseRepository
+	problemRepo        repository.ProblemRepository
+	testCaseRepo       repository.TestCaseRepository
+	testGroupRepo      repository.TestGroupRepository
+	contestProblemRepo repository.ContestProblemRepository
+	participantRepo    repository.ContestParticipantRepository
+	checkerRepo        repository.ProblemCheckerRepository
+	interactorRepo     repository.ProblemInteractorRepository
+	revisionRepo       repository.ProblemRevisionRepository
+	statementRepo      repository.ProblemStatementRepository
+	tagRepo            repository.TagRepository
+	collaboratorRepo   repository.ProblemCollaboratorRepository
+	transactor         repository.ProblemEditTransactor
 }
 
 // NewProblemService creates a new problem service.
-func NewProblemService(problemRepo repository.ProblemRepository, testCaseRepo repository.TestCaseRepository) *ProblemService {
+func NewProblemService(
+	problemRepo repository.ProblemRepository,
+	testCaseRepo repository.TestCaseRepository,
+	testGroupRepo repository.TestGroupRepository,
+	contestProblemRepo repository.ContestProblemRepository,
+	participantRepo repository.ContestParticipantRepository,
+	checkerRepo repository.ProblemCheckerRepository,
+	interactorRepo repository.ProblemInteractorRepository,
+	revisionRepo repository.ProblemRevisionRepository,
+	statementRepo repository.ProblemStatementRepository,
+	tagRepo repository.TagRepository,
+	collaboratorRepo repository.ProblemCollaboratorRepository,
+	transactor repository.ProblemEditTransactor,
+) *ProblemService {
 	return &ProblemService{
-		problemRepo:  problemRepo,
-		testCaseRepo: testCaseRepo,
+		problemRepo:        problemRepo,
+		testCaseRepo:       testCaseRepo,
+		testGroupRepo:      testGroupRepo,
+		contestProblemRepo: contestProblemRepo,
+		participantRepo:    participantRepo,
+		checkerRepo:        checkerRepo,
+		interactorRepo:     interactorRepo,
+		revisionRepo:       revisionRepo,
+		statementRepo:      statementRepo,
+		tagRepo:            tagRepo,
+		collaboratorRepo:   collaboratorRepo,
+		transactor:         transactor,
 	}
 }
 
-// CreateProblem creates a new problem.
+// CreateProblem creates a new draft problem owned by its creator.
 func (s *ProblemService) CreateProblem(req *dto.CreateProblemRequest, createdBy uuid.UUID) (*domain.Problem, error) {
 	// Generate slug
 	slugStr := slug.Make(req.Title)
@@ -33,46 +78,107 @@ func (s *ProblemService) CreateProblem(req *dto.CreateProblemRequest, createdBy
 		slugStr = slug.Make(req.Title + "-" + strings.ToLower(req.Difficulty))
 	}
 
-	problem := &domain.Problem{
-		Title:       req.Title,
-		Slug:        slugStr,
-		Description: req.Description,
-		Difficulty:  req.Difficulty,
-		TimeLimit:   req.TimeLimit,
-		MemoryLimit: req.MemoryLimit,
-		Tags:        strings.Join(req.Tags, ","),
-		CreatedBy:   createdBy,
-	}
-
-	if err := s.problemRepo.Create(problem); err != nil {
+	tags, err := s.resolveTags(req.Tags)
+	if err != nil {
 		return nil, err
 	}
 
-	// Add test cases if provided
-	for order, tc := range req.TestCases {
-		testCase := &domain.TestCase{
-			ProblemID:      problem.ID,
-			Input:          tc.Input,
-			ExpectedOutput: tc.ExpectedOutput,
-			IsSample:       tc.IsSample,
-			Points:         tc.Points,
-			OrderIndex:     order + 1,
+	problem := &domain.Problem{
+		Title:         req.Title,
+		Slug:          slugStr,
+		Description:   req.Description,
+		InputFormat:   req.InputFormat,
+		OutputFormat:  req.OutputFormat,
+		Notes:         req.Notes,
+		DefaultLocale: defaultLocale,
+		Difficulty:    req.Difficulty,
+		TimeLimit:     req.TimeLimit,
+		MemoryLimit:   req.MemoryLimit,
+		ScoringMode:   domain.ScoringICPC,
+		Type:          domain.ProblemTypeStandard,
+		Tags:          tags,
+		Status:        domain.ProblemStatusDraft,
+		CreatedBy:     createdBy,
+		Collaborators: []domain.ProblemCollaborator{
+			{UserID: createdBy, Role: domain.CollaboratorRoleOwner},
+		},
+	}
+	if req.PublishAt != nil {
+		if !req.PublishAt.After(time.Now()) {
+			return nil, ErrInvalidPublishAt
 		}
-		if err := s.testCaseRepo.Create(testCase); err != nil {
-			return nil, err
+		problem.PublishAt = req.PublishAt
+	}
+	if req.DefaultLocale != "" {
+		locale, ok := normalizeLocale(req.DefaultLocale)
+		if !ok {
+			return nil, ErrInvalidLocale
 		}
+		problem.DefaultLocale = locale
+	}
+	if req.ScoringMode != "" {
+		problem.ScoringMode = req.ScoringMode
 	}
+	if req.Type != "" {
+		problem.Type = req.Type
+	}
+	if problem.Type != domain.ProblemTypeInteractive {
+		for _, tc := range req.TestCases {
+			if tc.ExpectedOutput == "" {
+				return nil, ErrExpectedOutputRequired
+			}
+		}
+	}
+
+	err = s.transactor.WithinTransaction(func(repos *repository.ProblemEditRepositories) error {
+		if err := repos.Problems.Create(problem); err != nil {
+			return err
+		}
 
+		// Add test cases if provided
+		for order, tc := range req.TestCases {
+			testCase := &domain.TestCase{
+				ProblemID:      problem.ID,
+				Input:          tc.Input,
+				ExpectedOutput: tc.ExpectedOutput,
+				IsSample:       tc.IsSample,
+				Points:         tc.Points,
+				OrderIndex:     order + 1,
+			}
+			if err := repos.TestCases.Create(testCase); err != nil {
+				return err
+			}
+		}
+
+		return recordRevision(repos, problem, createdBy, domain.RevisionChangeCreate, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
 	return problem, nil
 }
 
-// GetProblem retrieves a problem by slug, with optional test cases for admin.
-func (s *ProblemService) GetProblem(slug string, includeHiddenTestCases bool) (*dto.ProblemResponse, error) {
-	problem, err := s.problemRepo.FindBySlug(slug)
+// GetProblem retrieves a problem by slug. Admins and the problem's
+// collaborators also see it before its release, with its hidden test cases.
+// The statement is given in the first of the preferred locales the problem
+// is translated into, or in its default locale.
+func (s *ProblemService) GetProblem(slug string, isAdmin bool, viewerID uuid.UUID, locales []string) (*dto.ProblemResponse, error) {
+	problem, err := s.findProblem(slug)
 	if err != nil {
 		return nil, err
 	}
 
+	includeHiddenTestCases := isAdmin || hasProblemPermission(s.collaboratorRepo, problem.ID, viewerID, ProblemPermissionView)
+	if !includeHiddenTestCases {
+		released, err := problemReleased(s.contestProblemRepo, s.participantRepo, problem, viewerID, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		if !released {
+			return nil, ErrProblemNotFound
+		}
+	}
+
 	testCases, err := s.testCaseRepo.FindByProblemID(problem.ID)
 	if err != nil {
 		return nil, err
@@ -87,36 +193,75 @@ func (s *ProblemService) GetProblem(slug string, includeHiddenTestCases bool) (*
 				ExpectedOutput: tc.ExpectedOutput,
 				IsSample:       tc.IsSample,
 				Points:         tc.Points,
+				GroupID:        tc.GroupID,
 			})
 		}
 	}
 
-	tags := strings.Split(problem.Tags, ",")
+	statements, err := s.statementRepo.FindByProblemID(problem.ID)
+	if err != nil {
+		return nil, err
+	}
+	available := []string{problem.DefaultLocale}
+	for _, st := range statements {
+		available = append(available, st.Locale)
+	}
+	statement := dto.DefaultStatementDTO(problem)
+	if locale := pickLocale(locales, available, problem.DefaultLocale); locale != problem.DefaultLocale {
+		for _, st := range statements {
+			if st.Locale == locale {
+				statement = dto.StatementDTOFromDomain(st)
+			}
+		}
+	}
 
 	return &dto.ProblemResponse{
-		ID:              problem.ID,
-		Title:           problem.Title,
-		Slug:            problem.Slug,
-		Description:     problem.Description,
-		Difficulty:      problem.Difficulty,
-		TimeLimit:       problem.TimeLimit,
-		MemoryLimit:     problem.MemoryLimit,
-		Tags:            tags,
-		AcceptedCount:   problem.AcceptedCount,
-		SubmissionCount: problem.SubmissionCount,
-		TestCases:       filteredTestCases,
+		ID:               problem.ID,
+		Title:            statement.Title,
+		Slug:             problem.Slug,
+		Description:      statement.Description,
+		InputFormat:      statement.InputFormat,
+		OutputFormat:     statement.OutputFormat,
+		Notes:            statement.Notes,
+		Locale:           statement.Locale,
+		AvailableLocales: available,
+		Difficulty:       problem.Difficulty,
+		TimeLimit:        problem.TimeLimit,
+		MemoryLimit:      problem.MemoryLimit,
+		ScoringMode:      problem.ScoringMode,
+		Type:             problem.Type,
+		Tags:             problem.TagNames(),
+		AcceptedCount:    problem.AcceptedCount,
+		SubmissionCount:  problem.SubmissionCount,
+		Revision:         problem.Revision,
+		Status:           problem.Status,
+		PublishAt:        problem.PublishAt,
+		PublishedAt:      problem.PublishedAt,
+		TestCases:        filteredTestCases,
 	}, nil
 }
 
-// ListProblems lists problems with pagination and filters.
-func (s *ProblemService) ListProblems(pagination *dto.PaginationRequest, filters *dto.ProblemFilters) (*dto.ProblemListResponse, error) {
+// ListProblems lists problems with pagination and filters. Unless includeUnreleased
+// is set, only published problems are listed, along with those the viewer
+// collaborates on, and problems belonging only to contests that have not
+// started are omitted.
+// Titles are given in the first of the preferred locales each problem has.
+func (s *ProblemService) ListProblems(pagination *dto.PaginationRequest, filters *dto.ProblemFilters, includeUnreleased bool, viewerID uuid.UUID, locales []string) (*dto.ProblemListResponse, error) {
 	domainPagination := &domain.Pagination{
 		Limit:  pagination.Limit,
 		Offset: pagination.Page * pagination.Limit,
 	}
 	domainFilters := &domain.ProblemFilters{
-		Difficulty: filters.Difficulty,
-		Tags:       filters.Tags,
+		Difficulty:   filters.Difficulty,
+		Tags:         filters.Tags,
+		MatchAllTags: filters.MatchAllTags,
+		Query:        filters.Query,
+		Status:       filters.Status,
+	}
+	if !includeUnreleased {
+		now := time.Now()
+		domainFilters.VisibleAt = &now
+		domainFilters.CollaboratorID = viewerID
 	}
 
 	problems, total, err := s.problemRepo.FindAll(domainPagination, domainFilters)
@@ -124,17 +269,34 @@ func (s *ProblemService) ListProblems(pagination *dto.PaginationRequest, filters
 		return nil, err
 	}
 
+	titles, err := s.localizedTitles(problems, locales)
+	if err != nil {
+		return nil, err
+	}
+
+	var snippets map[uuid.UUID]string
+	if filters.Query != "" {
+		ids := make([]uuid.UUID, 0, len(problems))
+		for _, p := range problems {
+			ids = append(ids, p.ID)
+		}
+		if snippets, err = s.problemRepo.Snippets(ids, filters.Query); err != nil {
+			return nil, err
+		}
+	}
+
 	var problemDTOs []dto.ProblemSummaryDTO
 	for _, p := range problems {
-		tags := strings.Split(p.Tags, ",")
 		problemDTOs = append(problemDTOs, dto.ProblemSummaryDTO{
 			ID:              p.ID,
-			Title:           p.Title,
+			Title:           titles[p.ID],
 			Slug:            p.Slug,
 			Difficulty:      p.Difficulty,
-			Tags:            tags,
+			Tags:            p.TagNames(),
 			AcceptedCount:   p.AcceptedCount,
 			SubmissionCount: p.SubmissionCount,
+			Status:          p.Status,
+			Snippet:         snippets[p.ID],
 		})
 	}
 
@@ -146,8 +308,89 @@ func (s *ProblemService) ListProblems(pagination *dto.PaginationRequest, filters
 	}, nil
 }
 
-// UpdateProblem updates a problem.
-func (s *ProblemService) UpdateProblem(slug string, req *dto.UpdateProblemRequest) (*domain.Problem, error) {
+// SuggestProblems returns up to limit problems for typeahead, matching each
+// word of prefix against the start of words in their titles.
+func (s *ProblemService) SuggestProblems(prefix string, limit int, includeUnreleased bool) ([]dto.ProblemSuggestionDTO, error) {
+	var visibleAt *time.Time
+	if !includeUnreleased {
+		now := time.Now()
+		visibleAt = &now
+	}
+
+	problems, err := s.problemRepo.SuggestByTitle(prefix, limit, visibleAt)
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions := make([]dto.ProblemSuggestionDTO, 0, len(problems))
+	for _, p := range problems {
+		suggestions = append(suggestions, dto.ProblemSuggestionDTO{Title: p.Title, Slug: p.Slug})
+	}
+	return suggestions, nil
+}
+
+// SetProblemStatus moves a problem through its lifecycle. Publishing can be
+// scheduled by giving an unpublished status and a future PublishAt. Only
+// admins publish, schedule and archive problems; collaborators move drafts
+// to review and back.
+func (s *ProblemService) SetProblemStatus(slug string, req *dto.ProblemStatusRequest, isAdmin bool) (*domain.Problem, error) {
+	problem, err := s.problemRepo.FindBySlug(slug)
+	if err != nil {
+		return nil, ErrProblemNotFound
+	}
+	if !isAdmin && (!isUnpublishedStatus(problem.Status) || !isUnpublishedStatus(req.Status) || req.PublishAt != nil) {
+		return nil, ErrProblemAccessDenied
+	}
+
+	now := time.Now()
+	if isUnpublishedStatus(req.Status) {
+		if req.PublishAt != nil && !req.PublishAt.After(now) {
+			return nil, ErrInvalidPublishAt
+		}
+		problem.PublishAt = req.PublishAt
+	} else {
+		if req.PublishAt != nil {
+			return nil, ErrInvalidPublishAt
+		}
+		problem.PublishAt = nil
+	}
+	if req.Status == domain.ProblemStatusPublished && !problem.IsPublished() {
+		problem.PublishedAt = &now
+	}
+	problem.Status = req.Status
+
+	if err := s.problemRepo.Update(problem); err != nil {
+		return nil, err
+	}
+	return problem, nil
+}
+
+// StartPublishScheduler periodically publishes problems whose scheduled
+// publication time has passed.
+func (s *ProblemService) StartPublishScheduler(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			s.PublishDueProblems()
+		}
+	}()
+}
+
+// PublishDueProblems publishes every problem whose PublishAt has passed.
+func (s *ProblemService) PublishDueProblems() {
+	published, err := s.problemRepo.PublishDue(time.Now())
+	if err != nil {
+		log.Printf("problem: failed to publish scheduled problems: %v", err)
+		return
+	}
+	if published > 0 {
+		log.Printf("problem: published %d scheduled problem(s)", published)
+	}
+}
+
+// UpdateProblem updates a problem and records a revision.
+func (s *ProblemService) UpdateProblem(slug string, req *dto.UpdateProblemRequest, editorID uuid.UUID) (*domain.Problem, error) {
 	problem, err := s.problemRepo.FindBySlug(slug)
 	if err != nil {
 		return nil, err
@@ -160,6 +403,27 @@ func (s *ProblemService) UpdateProblem(slug string, req *dto.UpdateProblemReques
 	if req.Description != "" {
 		problem.Description = req.Description
 	}
+	if req.InputFormat != "" {
+		problem.InputFormat = req.InputFormat
+	}
+	if req.OutputFormat != "" {
+		problem.OutputFormat = req.OutputFormat
+	}
+	if req.Notes != "" {
+		problem.Notes = req.Notes
+	}
+	if req.DefaultLocale != "" {
+		locale, ok := normalizeLocale(req.DefaultLocale)
+		if !ok {
+			return nil, ErrInvalidLocale
+		}
+		// The default statement is stored on the problem, so it cannot share
+		// its locale with a translation.
+		if _, err := s.statementRepo.FindByProblemAndLocale(problem.ID, locale); err == nil {
+			return nil, ErrStatementLocaleTaken
+		}
+		problem.DefaultLocale = locale
+	}
 	if req.Difficulty != "" {
 		problem.Difficulty = req.Difficulty
 	}
@@ -169,31 +433,106 @@ func (s *ProblemService) UpdateProblem(slug string, req *dto.UpdateProblemReques
 	if req.MemoryLimit != 0 {
 		problem.MemoryLimit = req.MemoryLimit
 	}
+	if req.ScoringMode != "" {
+		problem.ScoringMode = req.ScoringMode
+	}
+	if req.Type != "" {
+		problem.Type = req.Type
+	}
 	if len(req.Tags) > 0 {
-		problem.Tags = strings.Join(req.Tags, ",")
+		if problem.Tags, err = s.resolveTags(req.Tags); err != nil {
+			return nil, err
+		}
 	}
 
-	if err := s.problemRepo.Update(problem); err != nil {
+	err = editProblem(s.transactor, problem, editorID, domain.RevisionChangeUpdate, func(repos *repository.ProblemEditRepositories) error {
+		return repos.Problems.Update(problem)
+	})
+	if err != nil {
 		return nil, err
 	}
 	return problem, nil
 }
 
+// isUnpublishedStatus reports whether a status is one a problem waits in
+// before publication.
+func isUnpublishedStatus(status string) bool {
+	return status == domain.ProblemStatusDraft || status == domain.ProblemStatusReview
+}
+
+// problemReleased reports whether a viewer may see a problem at the given
+// time. While one of its contests is running a problem is shown whatever its
+// status, so contests can use unpublished problems; otherwise it is shown once
+// published and its first contest has started, which lets archiving withdraw
+// it after the contest. While a private contest containing the problem has
+// not ended, only its participants may see it.
+func problemReleased(contestProblemRepo repository.ContestProblemRepository, participantRepo repository.ContestParticipantRepository, problem *domain.Problem, viewerID uuid.UUID, now time.Time) (bool, error) {
+	unfinished, err := contestProblemRepo.FindUnfinishedByProblemID(problem.ID, now)
+	if err != nil {
+		return false, err
+	}
+	for _, cp := range unfinished {
+		if cp.Contest.IsPublic {
+			continue
+		}
+		if viewerID == uuid.Nil {
+			return false, nil
+		}
+		if _, err := participantRepo.FindByContestAndMember(cp.ContestID, viewerID); err != nil {
+			return false, nil
+		}
+	}
+
+	running, err := contestProblemRepo.FindRunningByProblemID(problem.ID, now)
+	if err != nil {
+		return false, err
+	}
+	if len(running) > 0 {
+		return true, nil
+	}
+
+	start, err := contestProblemRepo.EarliestContestStart(problem.ID)
+	if err != nil {
+		return false, err
+	}
+	if start != nil && now.Before(*start) {
+		return false, nil
+	}
+	return problem.IsPublished(), nil
+}
+
+// resolveTags normalizes tag names and loads their tags, creating new ones.
+func (s *ProblemService) resolveTags(names []string) ([]domain.Tag, error) {
+	return s.tagRepo.FindOrCreate(normalizeTagNames(names))
+}
+
 // DeleteProblem deletes a problem.
 func (s *ProblemService) DeleteProblem(slug string) error {
-	problem, err := s.problemRepo.FindBySlug(slug)
+	problem, err := s.findProblem(slug)
 	if err != nil {
 		return err
 	}
-	return s.problemRepo.Delete(problem.ID)
+	if err := s.problemRepo.Delete(problem.ID); err != nil {
+		if errors.Is(err, repository.ErrProblemInUse) {
+			return ErrProblemInUse
+		}
+		return err
+	}
+	return nil
 }
 
-// AddTestCase adds a new test case to a problem.
-func (s *ProblemService) AddTestCase(slug string, req *dto.TestCaseRequest) (*domain.TestCase, error) {
+// AddTestCase adds a new test case to a problem and records a revision.
+func (s *ProblemService) AddTestCase(slug string, req *dto.TestCaseRequest, editorID uuid.UUID) (*domain.TestCase, error) {
 	problem, err := s.problemRepo.FindBySlug(slug)
 	if err != nil {
 		return nil, err
 	}
+	if req.ExpectedOutput == "" && problem.Type != domain.ProblemTypeInteractive {
+		return nil, ErrExpectedOutputRequired
+	}
+	if err := s.checkTestGroup(problem.ID, req.GroupID); err != nil {
+		return nil, err
+	}
 
 	testCase := &domain.TestCase{
 		ProblemID:      problem.ID,
@@ -202,17 +541,21 @@ func (s *ProblemService) AddTestCase(slug string, req *dto.TestCaseRequest) (*do
 		IsSample:       req.IsSample,
 		Points:         req.Points,
 		OrderIndex:     req.OrderIndex,
+		GroupID:        req.GroupID,
 	}
 
-	if err := s.testCaseRepo.Create(testCase); err != nil {
+	err = editProblem(s.transactor, problem, editorID, domain.RevisionChangeAddTestCase, func(repos *repository.ProblemEditRepositories) error {
+		return repos.TestCases.Create(testCase)
+	})
+	if err != nil {
 		return nil, err
 	}
 	return testCase, nil
 }
 
-// UpdateTestCase updates a test case.
-func (s *ProblemService) UpdateTestCase(id uuid.UUID, req *dto.TestCaseRequest) (*domain.TestCase, error) {
-	testCase, err := s.testCaseRepo.FindByID(id)
+// UpdateTestCase updates a test case of a problem and records a revision.
+func (s *ProblemService) UpdateTestCase(slug string, id uuid.UUID, req *dto.TestCaseRequest, editorID uuid.UUID) (*domain.TestCase, error) {
+	problem, testCase, err := s.findTestCase(slug, id)
 	if err != nil {
 		return nil, err
 	}
@@ -230,14 +573,103 @@ func (s *ProblemService) UpdateTestCase(id uuid.UUID, req *dto.TestCaseRequest)
 	if req.OrderIndex != 0 {
 		testCase.OrderIndex = req.OrderIndex
 	}
+	if req.GroupID != nil {
+		if err := s.checkTestGroup(testCase.ProblemID, req.GroupID); err != nil {
+			return nil, err
+		}
+		testCase.GroupID = req.GroupID
+	}
 
-	if err := s.testCaseRepo.Update(testCase); err != nil {
+	err = editProblem(s.transactor, problem, editorID, domain.RevisionChangeUpdateTestCase, func(repos *repository.ProblemEditRepositories) error {
+		return repos.TestCases.Update(testCase)
+	})
+	if err != nil {
 		return nil, err
 	}
 	return testCase, nil
 }
 
-// DeleteTestCase deletes a test case.
-func (s *ProblemService) DeleteTestCase(id uuid.UUID) error {
-	return s.testCaseRepo.Delete(id)
+// DeleteTestCase deletes a test case of a problem and records a revision.
+func (s *ProblemService) DeleteTestCase(slug string, id uuid.UUID, editorID uuid.UUID) error {
+	problem, _, err := s.findTestCase(slug, id)
+	if err != nil {
+		return err
+	}
+
+	return editProblem(s.transactor, problem, editorID, domain.RevisionChangeDeleteTestCase, func(repos *repository.ProblemEditRepositories) error {
+		return repos.TestCases.Delete(id)
+	})
+}
+
+// findProblem loads a problem by slug, translating a missing record into
+// ErrProblemNotFound.
+func (s *ProblemService) findProblem(slug string) (*domain.Problem, error) {
+	problem, err := s.problemRepo.FindBySlug(slug)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, ErrProblemNotFound
+	}
+	return problem, err
+}
+
+// findTestCase loads a problem and one of its test cases.
+func (s *ProblemService) findTestCase(slug string, id uuid.UUID) (*domain.Problem, *domain.TestCase, error) {
+	problem, err := s.problemRepo.FindBySlug(slug)
+	if err != nil {
+		return nil, nil, ErrProblemNotFound
+	}
+
+	testCase, err := s.testCaseRepo.FindByID(id)
+	if err != nil || testCase.ProblemID != problem.ID {
+		return nil, nil, ErrTestCaseNotFound
+	}
+	return problem, testCase, nil
+}
+
+// localizedTitles picks the title of each problem in the preferred locales.
+func (s *ProblemService) localizedTitles(problems []*domain.Problem, locales []string) (map[uuid.UUID]string, error) {
+	titles := make(map[uuid.UUID]string, len(problems))
+	ids := make([]uuid.UUID, 0, len(problems))
+	for _, p := range problems {
+		titles[p.ID] = p.Title
+		ids = append(ids, p.ID)
+	}
+	if len(locales) == 0 {
+		return titles, nil
+	}
+
+	translations, err := s.statementRepo.FindTitles(ids)
+	if err != nil {
+		return nil, err
+	}
+	byProblem := make(map[uuid.UUID]map[string]string, len(problems))
+	for _, st := range translations {
+		if byProblem[st.ProblemID] == nil {
+			byProblem[st.ProblemID] = make(map[string]string)
+		}
+		byProblem[st.ProblemID][st.Locale] = st.Title
+	}
+
+	for _, p := range problems {
+		available := []string{p.DefaultLocale}
+		for locale := range byProblem[p.ID] {
+			available = append(available, locale)
+		}
+		sort.Strings(available[1:])
+		if locale := pickLocale(locales, available, p.DefaultLocale); locale != p.DefaultLocale {
+			titles[p.ID] = byProblem[p.ID][locale]
+		}
+	}
+	return titles, nil
+}
+
+// checkTestGroup verifies that a test case's group belongs to its problem.
+func (s *ProblemService) checkTestGroup(problemID uuid.UUID, groupID *uuid.UUID) error {
+	if groupID == nil {
+		return nil
+	}
+	group, err := s.testGroupRepo.FindByID(*groupID)
+	if err != nil || group.ProblemID != problemID {
+		return ErrTestGroupNotFound
+	}
+	return nil
 }
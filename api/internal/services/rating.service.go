@@ -0,0 +1,266 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+	"github.com/klaus-creations/klaus-judge/api/internal/dto"
+	"github.com/klaus-creations/klaus-judge/api/internal/repository"
+)
+
+var (
+	ErrContestNotRated    = errors.New("contest is not rated")
+	ErrRatingsApplied     = errors.New("ratings for this contest have already been applied")
+	ErrJudgingPending     = errors.New("some submissions are still being judged")
+	ErrRatingUserNotFound = errors.New("user not found")
+)
+
+const (
+	// Bounds of the binary search for the rating matching an expected rank.
+	minSearchRating = 1
+	maxSearchRating = 8000
+)
+
+// RatingService applies Codeforces-style rating changes once rated contests end.
+type RatingService struct {
+	contestRepo       repository.ContestRepository
+	ratingChangeRepo  repository.RatingChangeRepository
+	userRepo          repository.UserRepository
+	scoreboardService *ScoreboardService
+}
+
+// NewRatingService creates a new rating service.
+func NewRatingService(
+	contestRepo repository.ContestRepository,
+	ratingChangeRepo repository.RatingChangeRepository,
+	userRepo repository.UserRepository,
+	scoreboardService *ScoreboardService,
+) *RatingService {
+	return &RatingService{
+		contestRepo:       contestRepo,
+		ratingChangeRepo:  ratingChangeRepo,
+		userRepo:          userRepo,
+		scoreboardService: scoreboardService,
+	}
+}
+
+// StartScheduler periodically applies the ratings of rated contests that have ended.
+func (s *RatingService) StartScheduler(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			s.ApplyDueRatings()
+		}
+	}()
+}
+
+// ApplyDueRatings applies ratings for every ended rated contest not yet
+// processed. Contests with submissions still in the queue are retried later.
+func (s *RatingService) ApplyDueRatings() {
+	contests, err := s.contestRepo.FindRatingsDue(time.Now())
+	if err != nil {
+		log.Printf("rating: failed to find contests due: %v", err)
+		return
+	}
+	for _, contest := range contests {
+		if err := s.ApplyContestRatings(contest.ID); err != nil && !errors.Is(err, ErrJudgingPending) {
+			log.Printf("rating: failed to apply contest %s: %v", contest.ID, err)
+		}
+	}
+}
+
+// ApplyContestRatings computes and stores the rating changes of an ended rated contest.
+func (s *RatingService) ApplyContestRatings(contestID uuid.UUID) error {
+	contest, err := s.contestRepo.FindByID(contestID)
+	if err != nil {
+		return err
+	}
+	if !contest.IsRated {
+		return ErrContestNotRated
+	}
+	if contest.RatingsApplied {
+		return ErrRatingsApplied
+	}
+	if time.Now().Before(contest.EndTime) {
+		return ErrContestNotEnded
+	}
+
+	board, err := s.scoreboardService.GetFinalScoreboard(contestID)
+	if err != nil {
+		return err
+	}
+
+	var contestants []*ratingContestant
+	for i, row := range board.Rows {
+		// Team results do not change individual ratings.
+		if row.TeamID != nil {
+			continue
+		}
+		attempted := false
+		for _, cell := range row.Problems {
+			if cell.Pending > 0 {
+				return ErrJudgingPending
+			}
+			if cell.Attempts > 0 {
+				attempted = true
+			}
+		}
+		// Registered users who never submitted did not take part.
+		if !attempted {
+			continue
+		}
+
+		user, err := s.userRepo.FindByID(row.UserID)
+		if err != nil {
+			return err
+		}
+		contestants = append(contestants, &ratingContestant{
+			userID: row.UserID,
+			place:  i,
+			rank:   row.Rank,
+			rating: user.Rating,
+		})
+	}
+
+	computeRatingChanges(contestants)
+
+	changes := make([]*domain.RatingChange, 0, len(contestants))
+	for _, c := range contestants {
+		changes = append(changes, &domain.RatingChange{
+			ContestID: contestID,
+			UserID:    c.userID,
+			Rank:      c.rank,
+			OldRating: c.rating,
+			NewRating: c.rating + c.delta,
+		})
+	}
+	return s.ratingChangeRepo.ApplyContest(contestID, changes)
+}
+
+// GetRatingHistory returns a user's rating graph.
+func (s *RatingService) GetRatingHistory(username string) (*dto.RatingHistoryResponse, error) {
+	user, err := s.userRepo.FindByUsername(username)
+	if err != nil {
+		return nil, ErrRatingUserNotFound
+	}
+
+	changes, err := s.ratingChangeRepo.FindByUserID(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	history := []dto.RatingChangeDTO{}
+	for _, c := range changes {
+		history = append(history, dto.RatingChangeDTO{
+			ContestID:    c.ContestID,
+			ContestTitle: c.Contest.Title,
+			Rank:         c.Rank,
+			OldRating:    c.OldRating,
+			NewRating:    c.NewRating,
+			Delta:        c.NewRating - c.OldRating,
+			RatedAt:      c.Contest.EndTime,
+		})
+	}
+
+	return &dto.RatingHistoryResponse{
+		UserID:   user.ID,
+		Username: user.Username,
+		Rating:   user.Rating,
+		History:  history,
+	}, nil
+}
+
+// ratingContestant is one rated participant of a contest.
+type ratingContestant struct {
+	userID uuid.UUID
+	place  int // index in the standings
+	rank   int // displayed rank; ties share the best rank
+	rating int
+	delta  int
+}
+
+// computeRatingChanges fills in each contestant's delta. A contestant's
+// expected rank (seed) follows from the Elo win probabilities against every
+// other contestant; the rating change moves them halfway towards the rating
+// whose seed equals the geometric mean of their seed and actual rank. Deltas
+// are then shifted so the total is slightly negative and the top contestants
+// do not inflate the pool.
+func computeRatingChanges(contestants []*ratingContestant) {
+	n := len(contestants)
+	if n < 2 {
+		return
+	}
+
+	// Tied contestants are ranked at the worst place of their group.
+	sort.SliceStable(contestants, func(i, j int) bool { return contestants[i].place < contestants[j].place })
+	places := make([]float64, n)
+	for i := n - 1; i >= 0; i-- {
+		places[i] = float64(i + 1)
+		if i+1 < n && contestants[i+1].rank == contestants[i].rank {
+			places[i] = places[i+1]
+		}
+	}
+
+	for i, c := range contestants {
+		seed := 1.0
+		for j, other := range contestants {
+			if i != j {
+				seed += eloWinProbability(float64(other.rating), float64(c.rating))
+			}
+		}
+		midRank := math.Sqrt(places[i] * seed)
+		need := ratingForSeed(contestants, midRank)
+		c.delta = (need - c.rating) / 2
+	}
+
+	sort.SliceStable(contestants, func(i, j int) bool { return contestants[i].rating > contestants[j].rating })
+
+	sum := 0
+	for _, c := range contestants {
+		sum += c.delta
+	}
+	inc := -sum/n - 1
+	for _, c := range contestants {
+		c.delta += inc
+	}
+
+	topCount := min(n, 4*int(math.Round(math.Sqrt(float64(n)))))
+	topSum := 0
+	for _, c := range contestants[:topCount] {
+		topSum += c.delta
+	}
+	inc = min(max(-topSum/topCount, -10), 0)
+	for _, c := range contestants {
+		c.delta += inc
+	}
+}
+
+// ratingForSeed binary-searches the rating whose expected rank among the
+// contestants is rank.
+func ratingForSeed(contestants []*ratingContestant, rank float64) int {
+	lo, hi := minSearchRating, maxSearchRating
+	for hi-lo > 1 {
+		mid := (lo + hi) / 2
+		seed := 1.0
+		for _, c := range contestants {
+			seed += eloWinProbability(float64(c.rating), float64(mid))
+		}
+		if seed < rank {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+	return lo
+}
+
+// eloWinProbability is the probability that a player rated ra beats one rated rb.
+func eloWinProbability(ra, rb float64) float64 {
+	return 1 / (1 + math.Pow(10, (rb-ra)/400))
+}
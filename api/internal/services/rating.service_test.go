@@ -0,0 +1,90 @@
+package services
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEloWinProbability(t *testing.T) {
+	tests := []struct {
+		name   string
+		ra, rb float64
+		want   float64
+	}{
+		{"equal ratings", 1500, 1500, 0.5},
+		{"400 points stronger", 1900, 1500, 10.0 / 11},
+		{"400 points weaker", 1500, 1900, 1.0 / 11},
+		{"800 points stronger", 2300, 1500, 100.0 / 101},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := eloWinProbability(tt.ra, tt.rb); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("eloWinProbability(%v, %v) = %v, want %v", tt.ra, tt.rb, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRatingForSeed(t *testing.T) {
+	pair := []*ratingContestant{{rating: 1500}, {rating: 1500}}
+	tests := []struct {
+		name        string
+		contestants []*ratingContestant
+		rank        float64
+		want        int
+	}{
+		{"middle of an equal field", pair, 2, 1500},
+		{"unreachable first place", pair, 1, maxSearchRating - 1},
+		{"unreachable last place", pair, 3, minSearchRating},
+		{"level with a single opponent", []*ratingContestant{{rating: 2000}}, 1.5, 2000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ratingForSeed(tt.contestants, tt.rank); got != tt.want {
+				t.Errorf("ratingForSeed(rank %v) = %d, want %d", tt.rank, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComputeRatingChanges(t *testing.T) {
+	type contestant struct{ rank, rating int }
+	tests := []struct {
+		name        string
+		contestants []contestant // in standings order
+		want        []int        // delta of each contestant, in standings order
+	}{
+		{"single contestant is unrated", []contestant{{1, 1500}}, []int{0}},
+		{"equal ratings, clear winner", []contestant{{1, 1500}, {2, 1500}}, []int{65, -67}},
+		{"equal ratings, tie", []contestant{{1, 1500}, {1, 1500}}, []int{-1, -1}},
+		{"favourite wins", []contestant{{1, 1800}, {2, 1200}}, []int{104, -106}},
+		{"underdog wins", []contestant{{1, 1200}, {2, 1800}}, []int{155, -156}},
+		{"four equal contestants", []contestant{{1, 1500}, {2, 1500}, {3, 1500}, {4, 1500}}, []int{95, 11, -36, -72}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contestants := make([]*ratingContestant, len(tt.contestants))
+			for i, c := range tt.contestants {
+				contestants[i] = &ratingContestant{place: i, rank: c.rank, rating: c.rating}
+			}
+
+			// computeRatingChanges reorders the slice, so read the deltas by place.
+			computeRatingChanges(contestants)
+			got := make([]int, len(contestants))
+			sum := 0
+			for _, c := range contestants {
+				got[c.place] = c.delta
+				sum += c.delta
+			}
+
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Fatalf("deltas = %v, want %v", got, tt.want)
+				}
+			}
+			if len(contestants) > 1 && sum >= 0 {
+				t.Errorf("deltas sum to %d, want a negative total", sum)
+			}
+		})
+	}
+}
@@ -0,0 +1,705 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+	"github.com/klaus-creations/klaus-judge/api/internal/dto"
+	"github.com/klaus-creations/klaus-judge/api/internal/repository"
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	ErrContestNotEnded  = errors.New("contest has not ended yet")
+	ErrContestNotFrozen = errors.New("contest scoreboard is not frozen")
+	ErrNoVirtualRun     = errors.New("no virtual participation in this contest")
+)
+
+// ICPC penalty for every rejected attempt before the first accepted one.
+const penaltyPerRejection = 20 // minutes
+
+// scoreboardBuiltField marks a contest hash as fully populated from the database.
+const scoreboardBuiltField = "_built"
+
+// scoreboardAttempt is the cached summary of one contest submission.
+type scoreboardAttempt struct {
+	Verdict     string    `json:"verdict"`
+	SubmittedAt time.Time `json:"submitted_at"`
+	Score       float64   `json:"score"`
+}
+
+// scoreboardCell is the state of one participant on one problem as shown on a board.
+type scoreboardCell struct {
+	Attempts int // judged attempts up to and including the first AC
+	Pending  int // attempts still queued, judging or hidden by the freeze
+	Frozen   bool
+	Solved   bool
+	SolvedAt time.Time
+	Score    float64 // best score over all judged attempts (IOI)
+}
+
+// scoreboardView controls which attempts a board takes into account.
+type scoreboardView struct {
+	cutoff   time.Time       // attempts at or after the cutoff are ignored (zero: none)
+	frozenAt time.Time       // attempts at or after the freeze are shown as pending (zero: live)
+	revealed map[string]bool // cells the resolver has already unfrozen
+}
+
+// ScoreboardService maintains contest scoreboards. The attempts of every
+// participant on every problem are kept in Redis and refreshed whenever a
+// contest submission changes, so reads never scan the submissions table once
+// a board is warm.
+type ScoreboardService struct {
+	contestRepo        repository.ContestRepository
+	contestProblemRepo repository.ContestProblemRepository
+	participantRepo    repository.ContestParticipantRepository
+	submissionRepo     repository.SubmissionRepository
+	virtualRepo        repository.VirtualParticipationRepository
+	redisClient        *redis.Client
+}
+
+// NewScoreboardService creates a new scoreboard service.
+func NewScoreboardService(
+	contestRepo repository.ContestRepository,
+	contestProblemRepo repository.ContestProblemRepository,
+	participantRepo repository.ContestParticipantRepository,
+	submissionRepo repository.SubmissionRepository,
+	virtualRepo repository.VirtualParticipationRepository,
+	redisClient *redis.Client,
+) *ScoreboardService {
+	return &ScoreboardService{
+		contestRepo:        contestRepo,
+		contestProblemRepo: contestProblemRepo,
+		participantRepo:    participantRepo,
+		submissionRepo:     submissionRepo,
+		virtualRepo:        virtualRepo,
+		redisClient:        redisClient,
+	}
+}
+
+// GetScoreboard returns the scoreboard of a contest, ICPC or IOI style
+// depending on the contest's scoring mode. After the freeze time the public
+// board shows new attempts as pending, while admins keep the live board.
+func (s *ScoreboardService) GetScoreboard(contestID uuid.UUID, isAdmin bool) (*dto.ScoreboardResponse, error) {
+	contest, err := s.contestRepo.FindByID(contestID)
+	if err != nil {
+		return nil, err
+	}
+
+	view := scoreboardView{}
+	if !isAdmin && contest.IsFrozen(time.Now()) {
+		view.frozenAt = *contest.FreezeTime()
+		if view.revealed, err = s.loadRevealed(contestID); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.buildContestScoreboard(contest, view)
+}
+
+// GetFinalScoreboard builds the live board of a contest straight from the
+// submissions table, bypassing the cache, for results that must not depend
+// on a stale or partially written cache entry.
+func (s *ScoreboardService) GetFinalScoreboard(contestID uuid.UUID) (*dto.ScoreboardResponse, error) {
+	contest, err := s.contestRepo.FindByID(contestID)
+	if err != nil {
+		return nil, err
+	}
+	problems, err := s.contestProblemRepo.FindByContestID(contest.ID)
+	if err != nil {
+		return nil, err
+	}
+	participants, err := s.participantRepo.FindByContestID(contest.ID)
+	if err != nil {
+		return nil, err
+	}
+	attempts, err := s.readAttempts(contest)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildScoreboard(contest, problems, participants, attempts, scoreboardView{}), nil
+}
+
+// GetVirtualStanding places a virtual participant on the original scoreboard
+// as it stood at the same relative time into the contest.
+func (s *ScoreboardService) GetVirtualStanding(contestID uuid.UUID, userID uuid.UUID) (*dto.VirtualStandingResponse, error) {
+	contest, err := s.contestRepo.FindByID(contestID)
+	if err != nil {
+		return nil, err
+	}
+	vp, err := s.virtualRepo.FindByContestAndUser(contestID, userID)
+	if err != nil {
+		return nil, ErrNoVirtualRun
+	}
+
+	now := time.Now()
+	finished := !now.Before(vp.EndsAt)
+	elapsed := now.Sub(vp.StartedAt)
+	if finished {
+		elapsed = vp.EndsAt.Sub(vp.StartedAt)
+	}
+
+	problems, err := s.contestProblemRepo.FindByContestID(contestID)
+	if err != nil {
+		return nil, err
+	}
+	participants, err := s.participantRepo.FindByContestID(contestID)
+	if err != nil {
+		return nil, err
+	}
+	attempts, err := s.loadAttempts(contest)
+	if err != nil {
+		return nil, err
+	}
+
+	// Replay the virtual attempts on the original timeline.
+	virtualSubs, err := s.submissionRepo.FindByContestID(contestID, &domain.SubmissionFilters{UserID: userID, IsVirtual: true})
+	if err != nil {
+		return nil, err
+	}
+	shift := contest.StartTime.Sub(vp.StartedAt)
+	for _, sub := range virtualSubs {
+		shifted := *sub
+		shifted.SubmittedAt = sub.SubmittedAt.Add(shift)
+		field := cellField(userID, sub.ProblemID)
+		attempts[field] = append(attempts[field], attemptsFromSubmissions([]*domain.Submission{&shifted})...)
+	}
+	participants = append(participants, &domain.ContestParticipant{ContestID: contestID, UserID: userID, User: vp.User})
+
+	// Other entrants are shown as the public sees them: while the original
+	// board is frozen their later attempts stay pending unless the resolver
+	// has revealed them. The virtual participant always sees their own results.
+	view := scoreboardView{cutoff: contest.StartTime.Add(elapsed)}
+	if contest.IsFrozen(now) {
+		view.frozenAt = *contest.FreezeTime()
+		if view.revealed, err = s.loadRevealed(contestID); err != nil {
+			return nil, err
+		}
+		for _, problem := range problems {
+			view.revealed[cellField(userID, problem.ProblemID)] = true
+		}
+	}
+
+	board := buildScoreboard(contest, problems, participants, attempts, view)
+
+	resp := &dto.VirtualStandingResponse{
+		ContestID:      contestID,
+		StartedAt:      vp.StartedAt,
+		EndsAt:         vp.EndsAt,
+		ElapsedMinutes: int(elapsed.Minutes()),
+		Finished:       finished,
+		Scoreboard:     board,
+	}
+	for i := range board.Rows {
+		if board.Rows[i].UserID == userID {
+			board.Rows[i].Virtual = true
+			resp.Rank = board.Rows[i].Rank
+			resp.Row = board.Rows[i]
+			break
+		}
+	}
+	return resp, nil
+}
+
+// GetResolverState returns the board as the award ceremony currently shows it.
+func (s *ScoreboardService) GetResolverState(contestID uuid.UUID) (*dto.ResolverStepResponse, error) {
+	contest, err := s.resolvableContest(contestID)
+	if err != nil {
+		return nil, err
+	}
+	revealed, err := s.loadRevealed(contestID)
+	if err != nil {
+		return nil, err
+	}
+
+	board, err := s.buildContestScoreboard(contest, scoreboardView{frozenAt: *contest.FreezeTime(), revealed: revealed})
+	if err != nil {
+		return nil, err
+	}
+	_, remaining := nextResolverCell(board)
+
+	return &dto.ResolverStepResponse{Remaining: remaining, Scoreboard: board}, nil
+}
+
+// RevealNext unfreezes the next cell in resolver order: the frozen problem with
+// the lowest label of the lowest-ranked participant that still has one. Once
+// nothing is left the contest is marked as unfrozen.
+func (s *ScoreboardService) RevealNext(contestID uuid.UUID) (*dto.ResolverStepResponse, error) {
+	contest, err := s.resolvableContest(contestID)
+	if err != nil {
+		return nil, err
+	}
+	revealed, err := s.loadRevealed(contestID)
+	if err != nil {
+		return nil, err
+	}
+
+	view := scoreboardView{frozenAt: *contest.FreezeTime(), revealed: revealed}
+	board, err := s.buildContestScoreboard(contest, view)
+	if err != nil {
+		return nil, err
+	}
+
+	next, _ := nextResolverCell(board)
+	if next == nil {
+		if err := s.markUnfrozen(contest); err != nil {
+			return nil, err
+		}
+		return &dto.ResolverStepResponse{Remaining: 0, Scoreboard: board}, nil
+	}
+
+	userID := board.Rows[next.row].UserID
+	field := cellField(rowEntrantID(board.Rows[next.row]), board.Problems[next.problem].ProblemID)
+	if err := s.addRevealed(contestID, field); err != nil {
+		return nil, err
+	}
+	revealed[field] = true
+
+	board, err = s.buildContestScoreboard(contest, view)
+	if err != nil {
+		return nil, err
+	}
+	_, remaining := nextResolverCell(board)
+	if remaining == 0 {
+		if err := s.markUnfrozen(contest); err != nil {
+			return nil, err
+		}
+	}
+
+	step := &dto.ResolverStepResponse{Remaining: remaining, Scoreboard: board}
+	for _, row := range board.Rows {
+		if row.UserID == userID {
+			step.Revealed = &dto.ResolverRevealDTO{
+				UserID:   row.UserID,
+				Username: row.Username,
+				Rank:     row.Rank,
+				Cell:     row.Problems[next.problem],
+			}
+			break
+		}
+	}
+	return step, nil
+}
+
+// Unfreeze reveals every remaining frozen cell at once.
+func (s *ScoreboardService) Unfreeze(contestID uuid.UUID) error {
+	contest, err := s.resolvableContest(contestID)
+	if err != nil {
+		return err
+	}
+	return s.markUnfrozen(contest)
+}
+
+// RefreshSubmission recaches the attempts of the cell touched by a contest submission.
+func (s *ScoreboardService) RefreshSubmission(submission *domain.Submission) error {
+	if submission.ContestID == nil || submission.IsVirtual || s.redisClient == nil {
+		return nil
+	}
+
+	filters := &domain.SubmissionFilters{UserID: submission.UserID, ProblemID: submission.ProblemID}
+	if submission.TeamID != nil {
+		filters = &domain.SubmissionFilters{TeamID: *submission.TeamID, ProblemID: submission.ProblemID}
+	}
+	subs, err := s.submissionRepo.FindByContestID(*submission.ContestID, filters)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(attemptsFromSubmissions(subs))
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	return s.redisClient.HSet(ctx, scoreboardKey(*submission.ContestID),
+		cellField(submission.EntrantID(), submission.ProblemID), data).Err()
+}
+
+// buildContestScoreboard loads everything a board needs and builds it for the given view.
+func (s *ScoreboardService) buildContestScoreboard(contest *domain.Contest, view scoreboardView) (*dto.ScoreboardResponse, error) {
+	problems, err := s.contestProblemRepo.FindByContestID(contest.ID)
+	if err != nil {
+		return nil, err
+	}
+	participants, err := s.participantRepo.FindByContestID(contest.ID)
+	if err != nil {
+		return nil, err
+	}
+	attempts, err := s.loadAttempts(contest)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildScoreboard(contest, problems, participants, attempts, view), nil
+}
+
+// resolvableContest loads an ended contest whose board is still frozen.
+func (s *ScoreboardService) resolvableContest(contestID uuid.UUID) (*domain.Contest, error) {
+	contest, err := s.contestRepo.FindByID(contestID)
+	if err != nil {
+		return nil, err
+	}
+	if time.Now().Before(contest.EndTime) {
+		return nil, ErrContestNotEnded
+	}
+	if contest.FreezeTime() == nil || contest.Unfrozen {
+		return nil, ErrContestNotFrozen
+	}
+	return contest, nil
+}
+
+// markUnfrozen publishes the live board and forgets the resolver progress.
+func (s *ScoreboardService) markUnfrozen(contest *domain.Contest) error {
+	contest.Unfrozen = true
+	if err := s.contestRepo.Update(contest); err != nil {
+		return err
+	}
+
+	if s.redisClient != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		s.redisClient.Del(ctx, revealedKey(contest.ID))
+	}
+	return nil
+}
+
+// loadAttempts reads the cached attempts of a contest, rebuilding them from
+// the submissions table on a cold cache.
+func (s *ScoreboardService) loadAttempts(contest *domain.Contest) (map[string][]scoreboardAttempt, error) {
+	if s.redisClient != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+
+		raw, err := s.redisClient.HGetAll(ctx, scoreboardKey(contest.ID)).Result()
+		if err == nil && raw[scoreboardBuiltField] != "" {
+			attempts := make(map[string][]scoreboardAttempt, len(raw))
+			for field, value := range raw {
+				if field == scoreboardBuiltField {
+					continue
+				}
+				var cellAttempts []scoreboardAttempt
+				if err := json.Unmarshal([]byte(value), &cellAttempts); err == nil {
+					attempts[field] = cellAttempts
+				}
+			}
+			return attempts, nil
+		}
+	}
+
+	return s.rebuildAttempts(contest)
+}
+
+// rebuildAttempts reads every attempt of a contest from the database and caches them.
+func (s *ScoreboardService) rebuildAttempts(contest *domain.Contest) (map[string][]scoreboardAttempt, error) {
+	attempts, err := s.readAttempts(contest)
+	if err != nil {
+		return nil, err
+	}
+
+	values := []interface{}{scoreboardBuiltField, "1"}
+	for field, cellAttempts := range attempts {
+		if data, err := json.Marshal(cellAttempts); err == nil {
+			values = append(values, field, data)
+		}
+	}
+
+	if s.redisClient != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		// A cache write failure only costs another rebuild on the next read.
+		s.redisClient.HSet(ctx, scoreboardKey(contest.ID), values...)
+	}
+
+	return attempts, nil
+}
+
+// readAttempts reads every attempt of a contest from the database, grouped by cell.
+func (s *ScoreboardService) readAttempts(contest *domain.Contest) (map[string][]scoreboardAttempt, error) {
+	subs, err := s.submissionRepo.FindByContestID(contest.ID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	grouped := make(map[string][]*domain.Submission)
+	for _, sub := range subs {
+		field := cellField(sub.EntrantID(), sub.ProblemID)
+		grouped[field] = append(grouped[field], sub)
+	}
+
+	attempts := make(map[string][]scoreboardAttempt, len(grouped))
+	for field, group := range grouped {
+		attempts[field] = attemptsFromSubmissions(group)
+	}
+	return attempts, nil
+}
+
+// loadRevealed returns the cells the resolver has already unfrozen.
+func (s *ScoreboardService) loadRevealed(contestID uuid.UUID) (map[string]bool, error) {
+	revealed := make(map[string]bool)
+	if s.redisClient == nil {
+		return revealed, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	fields, err := s.redisClient.SMembers(ctx, revealedKey(contestID)).Result()
+	if err != nil {
+		return nil, err
+	}
+	for _, field := range fields {
+		revealed[field] = true
+	}
+	return revealed, nil
+}
+
+// addRevealed records a cell as unfrozen by the resolver.
+func (s *ScoreboardService) addRevealed(contestID uuid.UUID, field string) error {
+	if s.redisClient == nil {
+		return errors.New("resolver requires redis")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	return s.redisClient.SAdd(ctx, revealedKey(contestID), field).Err()
+}
+
+func attemptsFromSubmissions(subs []*domain.Submission) []scoreboardAttempt {
+	attempts := make([]scoreboardAttempt, len(subs))
+	for i, sub := range subs {
+		attempts[i] = scoreboardAttempt{
+			Verdict:     sub.Verdict,
+			SubmittedAt: sub.SubmittedAt,
+			Score:       sub.Score,
+		}
+	}
+	return attempts
+}
+
+// computeCell folds one participant's attempts on one problem, in submission
+// order, into a scoreboard cell. Compilation and system errors are not
+// penalised and attempts after the first AC only count towards the best score. Attempts at
+// or after the cutoff are ignored; those at or after the freeze are pending.
+func computeCell(attempts []scoreboardAttempt, cutoff time.Time, frozenAt time.Time) scoreboardCell {
+	var cell scoreboardCell
+	for _, a := range attempts {
+		if !cutoff.IsZero() && !a.SubmittedAt.Before(cutoff) {
+			break
+		}
+		if !frozenAt.IsZero() && !a.SubmittedAt.Before(frozenAt) {
+			if !cell.Solved && a.Verdict != domain.VerdictCE && a.Verdict != domain.VerdictSE {
+				cell.Pending++
+				cell.Frozen = true
+			}
+			continue
+		}
+
+		switch a.Verdict {
+		case domain.VerdictQueued, domain.VerdictJudging:
+			if !cell.Solved {
+				cell.Pending++
+			}
+		case domain.VerdictCE, domain.VerdictSE:
+		default:
+			if a.Score > cell.Score {
+				cell.Score = a.Score
+			}
+			if cell.Solved {
+				continue
+			}
+			cell.Attempts++
+			if a.Verdict == domain.VerdictAC {
+				cell.Solved = true
+				cell.SolvedAt = a.SubmittedAt
+			}
+		}
+	}
+	return cell
+}
+
+// buildScoreboard ranks participants by problems solved, then by penalty time,
+// or by total score in IOI contests.
+func buildScoreboard(
+	contest *domain.Contest,
+	problems []*domain.ContestProblem,
+	participants []*domain.ContestParticipant,
+	attempts map[string][]scoreboardAttempt,
+	view scoreboardView,
+) *dto.ScoreboardResponse {
+	cells := make(map[string]scoreboardCell)
+	for _, p := range participants {
+		for _, cp := range problems {
+			field := cellField(p.EntrantID(), cp.ProblemID)
+			frozenAt := view.frozenAt
+			if view.revealed[field] {
+				frozenAt = time.Time{}
+			}
+			cells[field] = computeCell(attempts[field], view.cutoff, frozenAt)
+		}
+	}
+
+	// Earliest solve per problem, for first-solve markers.
+	firstSolve := make(map[uuid.UUID]time.Time)
+	for _, p := range participants {
+		for _, cp := range problems {
+			cell := cells[cellField(p.EntrantID(), cp.ProblemID)]
+			if !cell.Solved {
+				continue
+			}
+			if first, seen := firstSolve[cp.ProblemID]; !seen || cell.SolvedAt.Before(first) {
+				firstSolve[cp.ProblemID] = cell.SolvedAt
+			}
+		}
+	}
+
+	problemDTOs := make([]dto.ScoreboardProblemDTO, len(problems))
+	for i, cp := range problems {
+		problemDTOs[i] = dto.ScoreboardProblemDTO{Label: cp.Label, ProblemID: cp.ProblemID}
+	}
+
+	rows := make([]dto.ScoreboardRowDTO, 0, len(participants))
+	for _, p := range participants {
+		row := dto.ScoreboardRowDTO{
+			UserID:   p.UserID,
+			Username: p.User.Username,
+			TeamID:   p.TeamID,
+			Problems: make([]dto.ScoreboardCellDTO, len(problems)),
+		}
+		if p.Team != nil {
+			row.TeamName = p.Team.Name
+		}
+		for i, cp := range problems {
+			cell := cells[cellField(p.EntrantID(), cp.ProblemID)]
+			cellDTO := dto.ScoreboardCellDTO{
+				Label:    cp.Label,
+				Attempts: cell.Attempts,
+				Pending:  cell.Pending,
+				Frozen:   cell.Frozen,
+				Solved:   cell.Solved,
+				Score:    cell.Score,
+			}
+			row.Score += cell.Score
+			if cell.Solved {
+				minutes := int(cell.SolvedAt.Sub(contest.StartTime).Minutes())
+				cellDTO.SolvedAt = minutes
+				cellDTO.Penalty = minutes + penaltyPerRejection*(cell.Attempts-1)
+				cellDTO.FirstSolve = cell.SolvedAt.Equal(firstSolve[cp.ProblemID])
+
+				row.Solved++
+				row.Penalty += cellDTO.Penalty
+				problemDTOs[i].Solved++
+			}
+			problemDTOs[i].Attempts += cell.Attempts + cell.Pending
+			row.Problems[i] = cellDTO
+		}
+		rows = append(rows, row)
+	}
+
+	if contest.ScoringMode == domain.ScoringIOI {
+		rankRowsByScore(rows)
+	} else {
+		rankRows(rows)
+	}
+
+	return &dto.ScoreboardResponse{
+		ContestID:   contest.ID,
+		ScoringMode: contest.ScoringMode,
+		Frozen:      !view.frozenAt.IsZero(),
+		Problems:    problemDTOs,
+		Rows:        rows,
+		UpdatedAt:   time.Now(),
+	}
+}
+
+// resolverCell points at a cell of a built scoreboard.
+type resolverCell struct {
+	row     int
+	problem int
+}
+
+// nextResolverCell finds the frozen cell to reveal next: the lowest-ranked row
+// with a frozen cell, and within it the problem with the lowest label. It also
+// reports how many frozen cells remain.
+func nextResolverCell(board *dto.ScoreboardResponse) (*resolverCell, int) {
+	var next *resolverCell
+	remaining := 0
+	for i := len(board.Rows) - 1; i >= 0; i-- {
+		for j, cell := range board.Rows[i].Problems {
+			if !cell.Frozen {
+				continue
+			}
+			remaining++
+			if next == nil {
+				next = &resolverCell{row: i, problem: j}
+			}
+		}
+	}
+	return next, remaining
+}
+
+// rankRows sorts rows by solved (desc) and penalty (asc); equal rows share a rank.
+func rankRows(rows []dto.ScoreboardRowDTO) {
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].Solved != rows[j].Solved {
+			return rows[i].Solved > rows[j].Solved
+		}
+		if rows[i].Penalty != rows[j].Penalty {
+			return rows[i].Penalty < rows[j].Penalty
+		}
+		return rows[i].Username < rows[j].Username
+	})
+	for i := range rows {
+		if i > 0 && rows[i].Solved == rows[i-1].Solved && rows[i].Penalty == rows[i-1].Penalty {
+			rows[i].Rank = rows[i-1].Rank
+		} else {
+			rows[i].Rank = i + 1
+		}
+	}
+}
+
+// rankRowsByScore sorts rows by total score (desc); equal scores share a rank.
+func rankRowsByScore(rows []dto.ScoreboardRowDTO) {
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].Score != rows[j].Score {
+			return rows[i].Score > rows[j].Score
+		}
+		return rows[i].Username < rows[j].Username
+	})
+	for i := range rows {
+		if i > 0 && rows[i].Score == rows[i-1].Score {
+			rows[i].Rank = rows[i-1].Rank
+		} else {
+			rows[i].Rank = i + 1
+		}
+	}
+}
+
+func scoreboardKey(contestID uuid.UUID) string {
+	return fmt.Sprintf("scoreboard:%s", contestID)
+}
+
+func revealedKey(contestID uuid.UUID) string {
+	return fmt.Sprintf("scoreboard:%s:revealed", contestID)
+}
+
+// rowEntrantID returns the ID a row's cells are keyed by: its team, or its user.
+func rowEntrantID(row dto.ScoreboardRowDTO) uuid.UUID {
+	if row.TeamID != nil {
+		return *row.TeamID
+	}
+	return row.UserID
+}
+
+func cellField(entrantID uuid.UUID, problemID uuid.UUID) string {
+	return fmt.Sprintf("%s:%s", entrantID, problemID)
+}
@@ -0,0 +1,361 @@
+package services
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+	"github.com/klaus-creations/klaus-judge/api/internal/dto"
+	"github.com/klaus-creations/klaus-judge/api/internal/repository"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestJudgedVerdictReachesWarmScoreboard(t *testing.T) {
+	for _, verdict := range []string{"AC", "ACCEPTED"} {
+		t.Run(verdict, func(t *testing.T) {
+			now := time.Now()
+			contest := &domain.Contest{
+				ID:          uuid.New(),
+				StartTime:   now.Add(-time.Hour),
+				EndTime:     now.Add(time.Hour),
+				IsPublic:    true,
+				ScoringMode: domain.ScoringICPC,
+			}
+			problem := &domain.Problem{ID: uuid.New(), ScoringMode: domain.ScoringICPC}
+			user := &domain.User{ID: uuid.New(), Username: "alice"}
+			submission := &domain.Submission{
+				ID:          uuid.New(),
+				UserID:      user.ID,
+				ProblemID:   problem.ID,
+				ContestID:   &contest.ID,
+				Verdict:     domain.VerdictQueued,
+				SubmittedAt: now.Add(-30 * time.Minute),
+			}
+			testCase := &domain.TestCase{ID: uuid.New(), ProblemID: problem.ID, Points: 1}
+
+			submissions := &fakeSubmissionRepo{byID: map[uuid.UUID]*domain.Submission{submission.ID: submission}}
+			scoreboard := NewScoreboardService(
+				fakeContestRepo{contest: contest},
+				fakeContestProblemRepo{problems: []*domain.ContestProblem{{ContestID: contest.ID, ProblemID: problem.ID, Label: "A"}}},
+				fakeParticipantRepo{participants: []*domain.ContestParticipant{{ContestID: contest.ID, UserID: user.ID, User: *user}}},
+				submissions,
+				nil,
+				newFakeRedis(t),
+			)
+			service := &SubmissionService{
+				submissionRepo:    submissions,
+				testCaseRepo:      fakeTestCaseRepo{testCases: []*domain.TestCase{testCase}},
+				testGroupRepo:     fakeTestGroupRepo{},
+				problemRepo:       fakeProblemRepo{problem: problem},
+				contestRepo:       fakeContestRepo{contest: contest},
+				userRepo:          fakeUserRepo{user: user},
+				scoreboardService: scoreboard,
+			}
+
+			// Warm the board while the submission is queued.
+			if err := scoreboard.RefreshSubmission(submission); err != nil {
+				t.Fatalf("RefreshSubmission: %v", err)
+			}
+			board, err := scoreboard.GetScoreboard(contest.ID, false)
+			if err != nil {
+				t.Fatalf("GetScoreboard: %v", err)
+			}
+			if cell := board.Rows[0].Problems[0]; cell.Pending != 1 || cell.Solved {
+				t.Fatalf("before judging: got pending=%d solved=%v, want a pending attempt", cell.Pending, cell.Solved)
+			}
+
+			err = service.UpdateSubmissionAfterJudging(&dto.JudgeReport{
+				SubmissionID:  submission.ID,
+				Verdict:       verdict,
+				ExecutionTime: 12,
+				Tests:         []dto.JudgeTestReport{{TestCaseID: testCase.ID, Verdict: verdict}},
+			})
+			if err != nil {
+				t.Fatalf("UpdateSubmissionAfterJudging: %v", err)
+			}
+
+			board, err = scoreboard.GetScoreboard(contest.ID, false)
+			if err != nil {
+				t.Fatalf("GetScoreboard: %v", err)
+			}
+			cell := board.Rows[0].Problems[0]
+			if !cell.Solved || cell.Pending != 0 || cell.Attempts != 1 {
+				t.Errorf("after judging: got solved=%v pending=%d attempts=%d, want one solving attempt",
+					cell.Solved, cell.Pending, cell.Attempts)
+			}
+			if submissions.rebuilds != 1 {
+				t.Errorf("board rebuilt from the database %d times, want once", submissions.rebuilds)
+			}
+			if submission.Verdict != domain.VerdictAC || submission.TestsPassed != 1 || submission.Score != 1 {
+				t.Errorf("stored verdict=%q passed=%d score=%v, want AC, 1 and 1",
+					submission.Verdict, submission.TestsPassed, submission.Score)
+			}
+		})
+	}
+}
+
+// fakeSubmissionRepo keeps submissions in memory and counts full contest
+// scans, which only a cold scoreboard makes.
+type fakeSubmissionRepo struct {
+	repository.SubmissionRepository
+	byID     map[uuid.UUID]*domain.Submission
+	rebuilds int
+}
+
+func (r *fakeSubmissionRepo) FindByID(id uuid.UUID) (*domain.Submission, error) {
+	if sub, ok := r.byID[id]; ok {
+		return sub, nil
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (r *fakeSubmissionRepo) FindByContestID(contestID uuid.UUID, filters *domain.SubmissionFilters) ([]*domain.Submission, error) {
+	if filters == nil {
+		r.rebuilds++
+	}
+	var subs []*domain.Submission
+	for _, sub := range r.byID {
+		if sub.ContestID == nil || *sub.ContestID != contestID {
+			continue
+		}
+		if filters != nil && filters.UserID != uuid.Nil && sub.UserID != filters.UserID {
+			continue
+		}
+		if filters != nil && filters.ProblemID != uuid.Nil && sub.ProblemID != filters.ProblemID {
+			continue
+		}
+		subs = append(subs, sub)
+	}
+	return subs, nil
+}
+
+func (r *fakeSubmissionRepo) SaveJudgement(submission *domain.Submission, results []*domain.TestCaseResult) error {
+	r.byID[submission.ID] = submission
+	return nil
+}
+
+type fakeContestRepo struct {
+	repository.ContestRepository
+	contest *domain.Contest
+}
+
+func (r fakeContestRepo) FindByID(id uuid.UUID) (*domain.Contest, error) {
+	if id != r.contest.ID {
+		return nil, repository.ErrNotFound
+	}
+	return r.contest, nil
+}
+
+type fakeContestProblemRepo struct {
+	repository.ContestProblemRepository
+	problems []*domain.ContestProblem
+}
+
+func (r fakeContestProblemRepo) FindByContestID(contestID uuid.UUID) ([]*domain.ContestProblem, error) {
+	return r.problems, nil
+}
+
+type fakeParticipantRepo struct {
+	repository.ContestParticipantRepository
+	participants []*domain.ContestParticipant
+}
+
+func (r fakeParticipantRepo) FindByContestID(contestID uuid.UUID) ([]*domain.ContestParticipant, error) {
+	return r.participants, nil
+}
+
+type fakeProblemRepo struct {
+	repository.ProblemRepository
+	problem *domain.Problem
+}
+
+func (r fakeProblemRepo) FindByID(id uuid.UUID) (*domain.Problem, error) {
+	return r.problem, nil
+}
+
+func (r fakeProblemRepo) IncrementAcceptedCount(id uuid.UUID) error {
+	return nil
+}
+
+type fakeTestCaseRepo struct {
+	repository.TestCaseRepository
+	testCases []*domain.TestCase
+}
+
+func (r fakeTestCaseRepo) FindByProblemID(problemID uuid.UUID) ([]*domain.TestCase, error) {
+	return r.testCases, nil
+}
+
+type fakeTestGroupRepo struct {
+	repository.TestGroupRepository
+}
+
+func (r fakeTestGroupRepo) FindByProblemID(problemID uuid.UUID) ([]*domain.TestGroup, error) {
+	return nil, nil
+}
+
+type fakeUserRepo struct {
+	repository.UserRepository
+	user *domain.User
+}
+
+func (r fakeUserRepo) FindByID(id uuid.UUID) (*domain.User, error) {
+	return r.user, nil
+}
+
+func (r fakeUserRepo) Update(user *domain.User) error {
+	return nil
+}
+
+// newFakeRedis starts an in-process server speaking enough RESP2 for the
+// scoreboard cache (hashes, sets and DEL) and the judge result lists, and
+// returns a client for it.
+func newFakeRedis(t *testing.T) *redis.Client {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	var mu sync.Mutex
+	hashes := map[string]map[string]string{}
+	sets := map[string]map[string]bool{}
+	lists := map[string][]string{}
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				r := bufio.NewReader(conn)
+				for {
+					args, err := readRESPCommand(r)
+					if err != nil {
+						return
+					}
+					mu.Lock()
+					reply := fakeRedisReply(hashes, sets, lists, args)
+					mu.Unlock()
+					if _, err := io.WriteString(conn, reply); err != nil {
+						return
+					}
+				}
+			}()
+		}
+	}()
+
+	client := redis.NewClient(&redis.Options{
+		Addr:            ln.Addr().String(),
+		Protocol:        2,
+		DisableIdentity: true,
+	})
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func fakeRedisReply(hashes map[string]map[string]string, sets map[string]map[string]bool, lists map[string][]string, args []string) string {
+	switch strings.ToUpper(args[0]) {
+	case "PING":
+		return "+PONG\r\n"
+	case "HSET":
+		h := hashes[args[1]]
+		if h == nil {
+			h = map[string]string{}
+			hashes[args[1]] = h
+		}
+		added := 0
+		for i := 2; i+1 < len(args); i += 2 {
+			if _, ok := h[args[i]]; !ok {
+				added++
+			}
+			h[args[i]] = args[i+1]
+		}
+		return fmt.Sprintf(":%d\r\n", added)
+	case "HGETALL":
+		var b strings.Builder
+		fmt.Fprintf(&b, "*%d\r\n", 2*len(hashes[args[1]]))
+		for field, value := range hashes[args[1]] {
+			fmt.Fprintf(&b, "$%d\r\n%s\r\n$%d\r\n%s\r\n", len(field), field, len(value), value)
+		}
+		return b.String()
+	case "SMEMBERS":
+		var b strings.Builder
+		fmt.Fprintf(&b, "*%d\r\n", len(sets[args[1]]))
+		for member := range sets[args[1]] {
+			fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(member), member)
+		}
+		return b.String()
+	case "LPUSH":
+		for _, value := range args[2:] {
+			lists[args[1]] = append([]string{value}, lists[args[1]]...)
+		}
+		return fmt.Sprintf(":%d\r\n", len(lists[args[1]]))
+	case "LLEN":
+		return fmt.Sprintf(":%d\r\n", len(lists[args[1]]))
+	case "RPOP":
+		list := lists[args[1]]
+		if len(list) == 0 {
+			return "$-1\r\n"
+		}
+		value := list[len(list)-1]
+		lists[args[1]] = list[:len(list)-1]
+		return fmt.Sprintf("$%d\r\n%s\r\n", len(value), value)
+	case "DEL":
+		deleted := 0
+		for _, key := range args[1:] {
+			if _, ok := hashes[key]; ok {
+				deleted++
+			}
+			delete(hashes, key)
+			delete(sets, key)
+			delete(lists, key)
+		}
+		return fmt.Sprintf(":%d\r\n", deleted)
+	default:
+		return "-ERR unknown command '" + args[0] + "'\r\n"
+	}
+}
+
+// readRESPCommand reads one command sent as an array of bulk strings.
+func readRESPCommand(r *bufio.Reader) ([]string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(line, "*") {
+		return nil, fmt.Errorf("unexpected %q", line)
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+	if err != nil {
+		return nil, err
+	}
+	args := make([]string, n)
+	for i := range args {
+		header, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		size, err := strconv.Atoi(strings.TrimSpace(header[1:]))
+		if err != nil {
+			return nil, err
+		}
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		args[i] = string(buf[:size])
+	}
+	return args, nil
+}
@@ -0,0 +1,163 @@
+package services
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+	"github.com/klaus-creations/klaus-judge/api/internal/dto"
+	"github.com/klaus-creations/klaus-judge/api/internal/repository"
+)
+
+var (
+	ErrStatementNotFound    = errors.New("statement not found for this locale")
+	ErrInvalidLocale        = errors.New("locale must be a language tag such as en, fr or pt-br")
+	ErrDefaultStatement     = errors.New("the default locale statement is edited through the problem itself")
+	ErrStatementLocaleTaken = errors.New("the problem already has a translation in this locale")
+)
+
+// defaultLocale is the locale of problems created without one.
+const defaultLocale = "en"
+
+var localePattern = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]{2,8})*$`)
+
+// StatementService manages the translations of problem statements.
+type StatementService struct {
+	statementRepo repository.ProblemStatementRepository
+	problemRepo   repository.ProblemRepository
+	transactor    repository.ProblemEditTransactor
+}
+
+// NewStatementService creates a new statement service.
+func NewStatementService(
+	statementRepo repository.ProblemStatementRepository,
+	problemRepo repository.ProblemRepository,
+	transactor repository.ProblemEditTransactor,
+) *StatementService {
+	return &StatementService{
+		statementRepo: statementRepo,
+		problemRepo:   problemRepo,
+		transactor:    transactor,
+	}
+}
+
+// ListStatements returns the statement of a problem in every locale, the
+// default locale first.
+func (s *StatementService) ListStatements(slug string) ([]dto.StatementDTO, error) {
+	problem, err := s.problemRepo.FindBySlug(slug)
+	if err != nil {
+		return nil, ErrProblemNotFound
+	}
+
+	statements, err := s.statementRepo.FindByProblemID(problem.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []dto.StatementDTO{dto.DefaultStatementDTO(problem)}
+	for _, st := range statements {
+		result = append(result, dto.StatementDTOFromDomain(st))
+	}
+	return result, nil
+}
+
+// SetStatement creates or replaces the translation of a problem into a locale
+// and records a revision.
+func (s *StatementService) SetStatement(slug, locale string, req *dto.StatementRequest, editorID uuid.UUID) (*domain.ProblemStatement, error) {
+	problem, locale, err := s.findProblemAndLocale(slug, locale)
+	if err != nil {
+		return nil, err
+	}
+
+	statement, err := s.statementRepo.FindByProblemAndLocale(problem.ID, locale)
+	if err != nil {
+		statement = &domain.ProblemStatement{ProblemID: problem.ID, Locale: locale}
+	}
+	statement.Title = req.Title
+	statement.Description = req.Description
+	statement.InputFormat = req.InputFormat
+	statement.OutputFormat = req.OutputFormat
+	statement.Notes = req.Notes
+
+	err = editProblem(s.transactor, problem, editorID, domain.RevisionChangeSetStatement, func(repos *repository.ProblemEditRepositories) error {
+		if statement.CreatedAt.IsZero() {
+			return repos.Statements.Create(statement)
+		}
+		return repos.Statements.Update(statement)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return statement, nil
+}
+
+// DeleteStatement removes the translation of a problem into a locale and
+// records a revision.
+func (s *StatementService) DeleteStatement(slug, locale string, editorID uuid.UUID) error {
+	problem, locale, err := s.findProblemAndLocale(slug, locale)
+	if err != nil {
+		return err
+	}
+
+	statement, err := s.statementRepo.FindByProblemAndLocale(problem.ID, locale)
+	if err != nil {
+		return ErrStatementNotFound
+	}
+	return editProblem(s.transactor, problem, editorID, domain.RevisionChangeDeleteStatement, func(repos *repository.ProblemEditRepositories) error {
+		return repos.Statements.Delete(statement.ID)
+	})
+}
+
+// findProblemAndLocale loads a problem and validates a translation locale for it.
+func (s *StatementService) findProblemAndLocale(slug, locale string) (*domain.Problem, string, error) {
+	problem, err := s.problemRepo.FindBySlug(slug)
+	if err != nil {
+		return nil, "", ErrProblemNotFound
+	}
+
+	locale, ok := normalizeLocale(locale)
+	if !ok {
+		return nil, "", ErrInvalidLocale
+	}
+	if locale == problem.DefaultLocale {
+		return nil, "", ErrDefaultStatement
+	}
+	return problem, locale, nil
+}
+
+// normalizeLocale lower-cases a language tag and checks its shape.
+func normalizeLocale(locale string) (string, bool) {
+	locale = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+	return locale, localePattern.MatchString(locale)
+}
+
+// pickLocale chooses the best available locale for the preferences, most
+// preferred first. Each preference is matched exactly, then by language
+// (fr-ca finds fr, fr finds fr-ca), before the next one is tried. Without a
+// match it returns fallback.
+func pickLocale(preferences, available []string, fallback string) string {
+	language := func(locale string) string {
+		lang, _, _ := strings.Cut(locale, "-")
+		return lang
+	}
+
+	for _, pref := range preferences {
+		pref, ok := normalizeLocale(pref)
+		if !ok {
+			continue
+		}
+		for _, locale := range available {
+			if locale == pref {
+				return locale
+			}
+		}
+		for _, locale := range available {
+			if language(locale) == language(pref) {
+				return locale
+			}
+		}
+	}
+	return fallback
+}
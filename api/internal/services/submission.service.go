@@ -1,7 +1,10 @@
 package services
 
 import (
+	"encoding/json"
 	"errors"
+	"log"
+	"math"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,48 +14,138 @@ import (
 	"github.com/klaus-creations/klaus-judge/api/internal/repository"
 )
 
+var (
+	ErrNotRegisteredForContest = errors.New("you must be registered for the running contest to submit to this problem")
+	ErrAmbiguousContest        = errors.New("the problem is part of several of your running contests; choose one with contest_id")
+	ErrUnsupportedLanguage     = errors.New("unsupported language")
+)
+
 // SubmissionService handles submission-related business logic.
 type SubmissionService struct {
 	submissionRepo     repository.SubmissionRepository
-	testCaseResultRepo repository.TestCaseResultRepository
+	testCaseRepo       repository.TestCaseRepository
+	testGroupRepo      repository.TestGroupRepository
 	problemRepo        repository.ProblemRepository
+	contestRepo        repository.ContestRepository
 	userRepo           repository.UserRepository
+	contestProblemRepo repository.ContestProblemRepository
+	participantRepo    repository.ContestParticipantRepository
+	allowedUserRepo    repository.ContestAllowedUserRepository
+	virtualRepo        repository.VirtualParticipationRepository
+	collaboratorRepo   repository.ProblemCollaboratorRepository
+	scoreboardService  *ScoreboardService
 }
 
 // NewSubmissionService creates a new submission service.
 func NewSubmissionService(
 	submissionRepo repository.SubmissionRepository,
-	testCaseResultRepo repository.TestCaseResultRepository,
+	testCaseRepo repository.TestCaseRepository,
+	testGroupRepo repository.TestGroupRepository,
 	problemRepo repository.ProblemRepository,
+	contestRepo repository.ContestRepository,
 	userRepo repository.UserRepository,
+	contestProblemRepo repository.ContestProblemRepository,
+	participantRepo repository.ContestParticipantRepository,
+	allowedUserRepo repository.ContestAllowedUserRepository,
+	virtualRepo repository.VirtualParticipationRepository,
+	collaboratorRepo repository.ProblemCollaboratorRepository,
+	scoreboardService *ScoreboardService,
 ) *SubmissionService {
 	return &SubmissionService{
 		submissionRepo:     submissionRepo,
-		testCaseResultRepo: testCaseResultRepo,
+		testCaseRepo:       testCaseRepo,
+		testGroupRepo:      testGroupRepo,
 		problemRepo:        problemRepo,
+		contestRepo:        contestRepo,
 		userRepo:           userRepo,
+		contestProblemRepo: contestProblemRepo,
+		participantRepo:    participantRepo,
+		allowedUserRepo:    allowedUserRepo,
+		virtualRepo:        virtualRepo,
+		collaboratorRepo:   collaboratorRepo,
+		scoreboardService:  scoreboardService,
 	}
 }
 
 // SubmitSolution creates a new submission and queues it for judging.
-func (s *SubmissionService) SubmitSolution(req *dto.SubmitRequest, userID uuid.UUID, ipAddress string) (*domain.Submission, error) {
+// Submissions made while a contest containing the problem is running are
+// tagged with that contest and are only accepted from registered participants;
+// a team member's submissions count for their team.
+// Submissions made during a virtual run of a finished contest are tagged with
+// that contest as virtual.
+func (s *SubmissionService) SubmitSolution(req *dto.SubmitRequest, userID uuid.UUID, ipAddress string, isAdmin bool) (*domain.Submission, error) {
 	problem, err := s.problemRepo.FindBySlug(req.Slug)
 	if err != nil {
 		return nil, errors.New("problem not found")
 	}
 
 	if !isValidLanguage(req.Language) {
-		return nil, errors.New("unsupported language")
+		return nil, ErrUnsupportedLanguage
+	}
+
+	now := time.Now()
+	if !isAdmin && !hasProblemPermission(s.collaboratorRepo, problem.ID, userID, ProblemPermissionView) {
+		released, err := problemReleased(s.contestProblemRepo, s.participantRepo, problem, userID, now)
+		if err != nil {
+			return nil, err
+		}
+		if !released {
+			return nil, errors.New("problem not found")
+		}
+	}
+
+	var contestID, teamID *uuid.UUID
+	running, err := s.contestProblemRepo.FindRunningByProblemID(problem.ID, now)
+	if err != nil {
+		return nil, err
+	}
+	// A problem may be in several running contests at once; the submission
+	// counts for the one the user names, or the only one they take part in.
+	for _, cp := range running {
+		if req.ContestID != nil && *req.ContestID != cp.ContestID {
+			continue
+		}
+		participant, err := s.participantRepo.FindByContestAndMember(cp.ContestID, userID)
+		if err != nil {
+			continue
+		}
+		if contestID != nil {
+			return nil, ErrAmbiguousContest
+		}
+		contestID = &cp.ContestID
+		teamID = participant.TeamID
+	}
+	if len(running) > 0 && contestID == nil && !isAdmin {
+		return nil, ErrNotRegisteredForContest
+	}
+
+	isVirtual := false
+	if contestID == nil {
+		virtuals, err := s.virtualRepo.FindActiveByUserID(userID, now)
+		if err != nil {
+			return nil, err
+		}
+		for _, vp := range virtuals {
+			if _, err := s.contestProblemRepo.FindByContestAndProblem(vp.ContestID, problem.ID); err == nil {
+				contestID = &vp.ContestID
+				isVirtual = true
+				break
+			}
+		}
 	}
 
 	submission := &domain.Submission{
-		UserID:      userID,
-		ProblemID:   problem.ID,
-		Code:        req.Code,
-		Language:    req.Language,
-		Verdict:     domain.VerdictQueued,
-		IPAddress:   ipAddress,
-		SubmittedAt: time.Now(),
+		UserID:          userID,
+		ProblemID:       problem.ID,
+		ContestID:       contestID,
+		IsVirtual:       isVirtual,
+		TeamID:          teamID,
+		Code:            req.Code,
+		Language:        req.Language,
+		ProblemRevision: problem.Revision,
+		Verdict:         domain.VerdictQueued,
+		IPAddress:       ipAddress,
+		SubmittedAt:     now,
 	}
 
 	if err := s.submissionRepo.Create(submission); err != nil {
@@ -69,46 +162,157 @@ func (s *SubmissionService) SubmitSolution(req *dto.SubmitRequest, userID uuid.U
 		return nil, err
 	}
 
+	// Show the attempt as pending on the contest scoreboard
+	if err := s.scoreboardService.RefreshSubmission(submission); err != nil {
+		// Log error but continue
+	}
+
 	return submission, nil
 }
 
-// GetSubmission retrieves a submission details.
+// GetSubmission retrieves a submission details, as far as the disclosure
+// policy lets the viewer see them.
 func (s *SubmissionService) GetSubmission(id uuid.UUID, userID uuid.UUID, isAdmin bool) (*dto.SubmissionResponse, error) {
 	submission, err := s.submissionRepo.FindByID(id)
 	if err != nil {
 		return nil, err
 	}
 
-	if submission.UserID != userID && !isAdmin {
-		return nil, errors.New("unauthorized")
+	level, err := s.disclosure(submission, userID, isAdmin, time.Now())
+	if err != nil {
+		return nil, err
 	}
-
-	var testResults []dto.TestCaseResultDTO
-	for _, tr := range submission.TestResults {
-		testResults = append(testResults, dto.TestCaseResultDTO{
-			ID:            tr.ID,
-			Verdict:       tr.Verdict,
-			ExecutionTime: tr.ExecutionTime,
-			MemoryUsed:    tr.MemoryUsed,
-			Output:        tr.Output,
-			ErrorMessage:  tr.ErrorMessage,
-		})
+	if level == discloseNone {
+		return nil, errors.New("unauthorized")
 	}
 
-	return &dto.SubmissionResponse{
+	resp := &dto.SubmissionResponse{
 		ID:            submission.ID,
+		ContestID:     submission.ContestID,
+		IsVirtual:     submission.IsVirtual,
+		TeamID:        submission.TeamID,
 		Verdict:       submission.Verdict,
 		ExecutionTime: submission.ExecutionTime,
 		MemoryUsed:    submission.MemoryUsed,
 		Score:         submission.Score,
 		TestsPassed:   submission.TestsPassed,
 		TestsFailed:   submission.TestsFailed,
-		Code:          submission.Code, // Only show code to owner or admin
+		Revision:      submission.ProblemRevision,
 		Language:      submission.Language,
 		SubmittedAt:   submission.SubmittedAt,
 		JudgedAt:      submission.JudgedAt,
-		TestResults:   testResults,
-	}, nil
+	}
+
+	groups, err := s.testGroupRepo.FindByProblemID(submission.ProblemID)
+	if err != nil {
+		return nil, err
+	}
+	if len(groups) > 0 {
+		testCases, err := s.testCaseRepo.FindByProblemID(submission.ProblemID)
+		if err != nil {
+			return nil, err
+		}
+		resp.Groups, _ = scoreTestGroups(groups, testCases, submissionTestResults(submission))
+	}
+
+	if level == discloseSummary {
+		return resp, nil
+	}
+
+	resp.Code = submission.Code
+	for _, tr := range submission.TestResults {
+		resp.TestResults = append(resp.TestResults, dto.TestCaseResultDTO{
+			ID:            tr.ID,
+			Verdict:       tr.Verdict,
+			ExecutionTime: tr.ExecutionTime,
+			MemoryUsed:    tr.MemoryUsed,
+			Output:        tr.Output,
+			ErrorMessage:  tr.ErrorMessage,
+			Score:         tr.Score,
+		})
+	}
+
+	return resp, nil
+}
+
+// submissionDisclosure is how much of a submission a viewer may see.
+type submissionDisclosure int
+
+const (
+	discloseNone    submissionDisclosure = iota // nothing
+	discloseSummary                             // verdict and resources, but no code or per-test results
+	discloseFull                                // everything
+)
+
+// disclosure applies the disclosure policy: owners, their teammates and admins
+// see everything. Other people never see practice submissions, and see
+// nothing of a contest submission until the contest is over (and, on a frozen
+// board, until the results are revealed). Afterwards the verdict is public to
+// anyone who can see the contest, while the code and per-test results only
+// become public if the contest allows it and none of its problems is being
+// used in a running contest or in the viewer's own virtual run.
+func (s *SubmissionService) disclosure(sub *domain.Submission, viewerID uuid.UUID, isAdmin bool, now time.Time) (submissionDisclosure, error) {
+	if isAdmin || sub.UserID == viewerID {
+		return discloseFull, nil
+	}
+	if sub.ContestID == nil {
+		return discloseNone, nil
+	}
+	if sub.TeamID != nil {
+		participant, err := s.participantRepo.FindByContestAndMember(*sub.ContestID, viewerID)
+		if err == nil && participant.TeamID != nil && *participant.TeamID == *sub.TeamID {
+			return discloseFull, nil
+		}
+	}
+
+	contest, err := s.contestRepo.FindByID(*sub.ContestID)
+	if err != nil {
+		return discloseNone, nil
+	}
+	if now.Before(contest.EndTime) {
+		return discloseNone, nil
+	}
+	if !s.canSeeContest(contest, viewerID) {
+		return discloseNone, nil
+	}
+	if freeze := contest.FreezeTime(); contest.IsFrozen(now) && !sub.SubmittedAt.Before(*freeze) {
+		return discloseNone, nil
+	}
+	if !contest.PublicCodeAfterEnd {
+		return discloseSummary, nil
+	}
+
+	running, err := s.contestProblemRepo.FindRunningByProblemID(sub.ProblemID, now)
+	if err != nil {
+		return discloseNone, err
+	}
+	if len(running) > 0 {
+		return discloseSummary, nil
+	}
+	virtuals, err := s.virtualRepo.FindActiveByUserID(viewerID, now)
+	if err != nil {
+		return discloseNone, err
+	}
+	for _, vp := range virtuals {
+		if vp.ContestID == contest.ID {
+			return discloseSummary, nil
+		}
+	}
+
+	return discloseFull, nil
+}
+
+// canSeeContest reports whether a viewer may see a contest: anyone may see a
+// public contest, only its participants and allowlisted users a private one.
+func (s *SubmissionService) canSeeContest(contest *domain.Contest, viewerID uuid.UUID) bool {
+	if contest.IsPublic {
+		return true
+	}
+	if _, err := s.participantRepo.FindByContestAndMember(contest.ID, viewerID); err == nil {
+		return true
+	}
+	_, err := s.allowedUserRepo.FindByContestAndUser(contest.ID, viewerID)
+	return err == nil
 }
 
 // ListMySubmissions lists submissions for the current user.
@@ -128,6 +332,9 @@ func (s *SubmissionService) ListMySubmissions(userID uuid.UUID, pagination *dto.
 		submissionDTOs = append(submissionDTOs, dto.SubmissionSummaryDTO{
 			ID:          sub.ID,
 			ProblemSlug: "", // Fetch if needed
+			ContestID:   sub.ContestID,
+			IsVirtual:   sub.IsVirtual,
+			TeamID:      sub.TeamID,
 			Verdict:     sub.Verdict,
 			SubmittedAt: sub.SubmittedAt,
 		})
@@ -163,6 +370,9 @@ func (s *SubmissionService) ListAllSubmissions(pagination *dto.PaginationRequest
 			ID:          sub.ID,
 			UserID:      sub.UserID,
 			ProblemID:   sub.ProblemID,
+			ContestID:   sub.ContestID,
+			IsVirtual:   sub.IsVirtual,
+			TeamID:      sub.TeamID,
 			Verdict:     sub.Verdict,
 			SubmittedAt: sub.SubmittedAt,
 		})
@@ -176,29 +386,202 @@ func (s *SubmissionService) ListAllSubmissions(pagination *dto.PaginationRequest
 	}, nil
 }
 
-// UpdateSubmissionAfterJudging updates the submission after judging (called by worker).
-func (s *SubmissionService) UpdateSubmissionAfterJudging(submissionID uuid.UUID, verdict string, executionTime int, memoryUsed int, score float64, testsPassed int, testsFailed int, testResults []*domain.TestCaseResult) error {
-	submission, err := s.submissionRepo.FindByID(submissionID)
+// ListContestSubmissions lists the live submissions of a contest under the
+// disclosure policy: while it is running contestants only see their own (or
+// their team's) submissions; afterwards everyone who can see the contest sees
+// all of them, except those made after the freeze while the board is frozen.
+func (s *SubmissionService) ListContestSubmissions(contestID uuid.UUID, viewerID uuid.UUID, isAdmin bool, pagination *dto.PaginationRequest, filters *dto.SubmissionFilters) (*dto.SubmissionListResponse, error) {
+	contest, err := s.contestRepo.FindByID(contestID)
+	if err != nil {
+		return nil, ErrContestNotFound
+	}
+
+	domainPagination := &domain.Pagination{
+		Limit:  pagination.Limit,
+		Offset: pagination.Page * pagination.Limit,
+	}
+	domainFilters := &domain.SubmissionFilters{
+		ContestID: contestID,
+		ProblemID: filters.ProblemID,
+		Verdict:   filters.Verdict,
+	}
+
+	if !isAdmin {
+		now := time.Now()
+		participant, err := s.participantRepo.FindByContestAndMember(contestID, viewerID)
+		if err != nil {
+			participant = nil
+		}
+		if participant == nil && !s.canSeeContest(contest, viewerID) {
+			return nil, ErrContestAccessDenied
+		}
+
+		switch {
+		case now.Before(contest.EndTime) && participant == nil:
+			return &dto.SubmissionListResponse{
+				Submissions: []dto.SubmissionSummaryDTO{},
+				Page:        pagination.Page,
+				Limit:       pagination.Limit,
+			}, nil
+		case now.Before(contest.EndTime) && participant.TeamID != nil:
+			domainFilters.TeamID = *participant.TeamID
+		case now.Before(contest.EndTime):
+			domainFilters.UserID = viewerID
+		case contest.IsFrozen(now):
+			domainFilters.SubmittedBefore = contest.FreezeTime()
+		}
+	}
+
+	submissions, total, err := s.submissionRepo.FindAll(domainPagination, domainFilters)
+	if err != nil {
+		return nil, err
+	}
+
+	var submissionDTOs []dto.SubmissionSummaryDTO
+	for _, sub := range submissions {
+		submissionDTOs = append(submissionDTOs, dto.SubmissionSummaryDTO{
+			ID:          sub.ID,
+			UserID:      sub.UserID,
+			ProblemID:   sub.ProblemID,
+			ContestID:   sub.ContestID,
+			TeamID:      sub.TeamID,
+			Verdict:     sub.Verdict,
+			SubmittedAt: sub.SubmittedAt,
+		})
+	}
+
+	return &dto.SubmissionListResponse{
+		Submissions: submissionDTOs,
+		Total:       total,
+		Page:        pagination.Page,
+		Limit:       pagination.Limit,
+	}, nil
+}
+
+const (
+	// maxJudgeResultAttempts is how often recording a judge result is tried
+	// before it is moved to the dead-letter list.
+	maxJudgeResultAttempts = 5
+	// judgeResultRetryDelay is the pause after the first failed attempt; it
+	// doubles with every further one.
+	judgeResultRetryDelay = time.Second
+)
+
+// StartResultConsumer records the results workers push onto the judge
+// results queue. Each result is consumed once, so every API instance may run
+// it; results that fail to be recorded go back on the queue, with a growing
+// pause, until they run out of attempts.
+func (s *SubmissionService) StartResultConsumer() {
+	go func() {
+		for {
+			raw, err := config.PopJudgeResult(5 * time.Second)
+			if err != nil {
+				log.Printf("submission: failed to read judge results: %v", err)
+				time.Sleep(time.Second)
+				continue
+			}
+			if raw == "" {
+				continue
+			}
+
+			var report dto.JudgeReport
+			if err := json.Unmarshal([]byte(raw), &report); err != nil {
+				log.Printf("submission: parking malformed judge result: %v", err)
+				if err := config.DeadJudgeResult(raw); err != nil {
+					log.Printf("submission: lost malformed judge result: %v", err)
+				}
+				continue
+			}
+			err = s.UpdateSubmissionAfterJudging(&report)
+			switch {
+			case err == nil:
+			case errors.Is(err, repository.ErrNotFound):
+				log.Printf("submission: dropping judge result of unknown submission %s", report.SubmissionID)
+			default:
+				log.Printf("submission: failed to record judge result of %s: %v", report.SubmissionID, err)
+				if err := retryJudgeResult(&report); err != nil {
+					log.Printf("submission: lost judge result of %s: %v", report.SubmissionID, err)
+				}
+				time.Sleep(judgeResultBackoff(report.Attempts))
+			}
+		}
+	}()
+}
+
+// retryJudgeResult counts a failed attempt to record the report and puts it
+// back on the queue, or on the dead-letter list once it has had
+// maxJudgeResultAttempts tries.
+func retryJudgeResult(report *dto.JudgeReport) error {
+	report.Attempts++
+	payload, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+	if report.Attempts >= maxJudgeResultAttempts {
+		log.Printf("submission: giving up on judge result of %s after %d attempts", report.SubmissionID, report.Attempts)
+		return config.DeadJudgeResult(string(payload))
+	}
+	return config.RequeueJudgeResult(string(payload))
+}
+
+// judgeResultBackoff is the pause after the given number of failed attempts.
+func judgeResultBackoff(attempts int) time.Duration {
+	if attempts < 1 {
+		attempts = 1
+	}
+	return judgeResultRetryDelay << (attempts - 1)
+}
+
+// UpdateSubmissionAfterJudging records a worker's judge report: the verdict,
+// the per-test results and the score derived from them. It then refreshes
+// the contest scoreboard and, on acceptance, the solve counts.
+func (s *SubmissionService) UpdateSubmissionAfterJudging(report *dto.JudgeReport) error {
+	submission, err := s.submissionRepo.FindByID(report.SubmissionID)
+	if err != nil {
+		return err
+	}
+
+	verdict := domain.NormalizeVerdict(report.Verdict)
+	testResults := make([]*domain.TestCaseResult, 0, len(report.Tests))
+	testsPassed := 0
+	for _, t := range report.Tests {
+		result := &domain.TestCaseResult{
+			SubmissionID:  submission.ID,
+			TestCaseID:    t.TestCaseID,
+			Verdict:       domain.NormalizeVerdict(t.Verdict),
+			ExecutionTime: t.ExecutionTime,
+			MemoryUsed:    t.MemoryUsed,
+			Output:        t.Output,
+			ErrorMessage:  t.ErrorMessage,
+			Score:         t.Score,
+		}
+		if result.Verdict == domain.VerdictAC {
+			testsPassed++
+		}
+		testResults = append(testResults, result)
+	}
+
+	score, err := s.computeScore(submission, verdict, testResults)
 	if err != nil {
 		return err
 	}
 
+	now := time.Now()
 	submission.Verdict = verdict
-	submission.ExecutionTime = executionTime
-	submission.MemoryUsed = memoryUsed
+	submission.ExecutionTime = report.ExecutionTime
+	submission.MemoryUsed = report.MemoryUsed
 	submission.Score = score
+	submission.CompileError = report.CompileError
 	submission.TestsPassed = testsPassed
-	submission.TestsFailed = testsFailed
-	submission.JudgedAt = &time.Time{} // Set to current time
-	*submission.JudgedAt = time.Now()
+	submission.TestsFailed = len(testResults) - testsPassed
+	submission.JudgedAt = &now
 
-	if err := s.submissionRepo.Update(submission); err != nil {
+	if err := s.submissionRepo.SaveJudgement(submission, testResults); err != nil {
 		return err
 	}
 
-	// Create test case results
-	if err := s.testCaseResultRepo.CreateBatch(testResults); err != nil {
-		return err
+	if err := s.scoreboardService.RefreshSubmission(submission); err != nil {
+		log.Printf("submission: failed to refresh scoreboard for %s: %v", submission.ID, err)
 	}
 
 	// If accepted, update user and problem stats
@@ -206,11 +589,10 @@ func (s *SubmissionService) UpdateSubmissionAfterJudging(submissionID uuid.UUID,
 		if err := s.problemRepo.IncrementAcceptedCount(submission.ProblemID); err != nil {
 			// Log
 		}
+		// Ratings are only changed by rated contests (see RatingService).
 		user, err := s.userRepo.FindByID(submission.UserID)
 		if err == nil {
 			user.SolvedProblems++
-			// Update rating (simple Elo-like, or use advanced system)
-			user.Rating += 10 // Placeholder
 			s.userRepo.Update(user)
 		}
 	}
@@ -218,6 +600,70 @@ func (s *SubmissionService) UpdateSubmissionAfterJudging(submissionID uuid.UUID,
 	return nil
 }
 
+// computeScore sums the points of the test cases a submission passed, or the
+// share of them a checker awarded; on problems with test groups it sums the
+// group scores instead. Under ICPC scoring the points only count when the
+// whole submission is accepted.
+func (s *SubmissionService) computeScore(submission *domain.Submission, verdict string, testResults []*domain.TestCaseResult) (float64, error) {
+	problem, err := s.problemRepo.FindByID(submission.ProblemID)
+	if err != nil {
+		return 0, err
+	}
+	mode := problem.ScoringMode
+	if submission.ContestID != nil {
+		if contest, err := s.contestRepo.FindByID(*submission.ContestID); err == nil && contest.ScoringMode == domain.ScoringIOI {
+			mode = domain.ScoringIOI
+		}
+	}
+
+	testCases, err := s.testCaseRepo.FindByProblemID(submission.ProblemID)
+	if err != nil {
+		return 0, err
+	}
+
+	groups, err := s.testGroupRepo.FindByProblemID(submission.ProblemID)
+	if err != nil {
+		return 0, err
+	}
+
+	if mode != domain.ScoringIOI {
+		if verdict != domain.VerdictAC {
+			return 0, nil
+		}
+		total := 0
+		for _, tc := range testCases {
+			total += tc.Points
+		}
+		if len(groups) > 0 {
+			total = 0
+			for _, g := range groups {
+				total += g.Points
+			}
+		}
+		return float64(total), nil
+	}
+
+	if len(groups) > 0 {
+		_, score := scoreTestGroups(groups, testCases, testResults)
+		return score, nil
+	}
+
+	points := make(map[uuid.UUID]int, len(testCases))
+	for _, tc := range testCases {
+		points[tc.ID] = tc.Points
+	}
+	score := 0.0
+	for _, tr := range testResults {
+		switch {
+		case tr.Score != nil:
+			score += float64(points[tr.TestCaseID]) * math.Max(0, math.Min(1, *tr.Score))
+		case tr.Verdict == domain.VerdictAC:
+			score += float64(points[tr.TestCaseID])
+		}
+	}
+	return score, nil
+}
+
 func isValidLanguage(lang string) bool {
 	valid := []string{domain.LangCPP, domain.LangPython, domain.LangJava, domain.LangRust, domain.LangGo}
 	for _, v := range valid {
@@ -227,3 +673,12 @@ func isValidLanguage(lang string) bool {
 	}
 	return false
 }
+
+// submissionTestResults returns pointers to a submission's loaded test results.
+func submissionTestResults(submission *domain.Submission) []*domain.TestCaseResult {
+	results := make([]*domain.TestCaseResult, 0, len(submission.TestResults))
+	for i := range submission.TestResults {
+		results = append(results, &submission.TestResults[i])
+	}
+	return results
+}
@@ -0,0 +1,55 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/config"
+	"github.com/klaus-creations/klaus-judge/api/internal/dto"
+)
+
+func TestRetryJudgeResult(t *testing.T) {
+	client := newFakeRedis(t)
+	previous := config.RedisClient
+	config.RedisClient = client
+	t.Cleanup(func() { config.RedisClient = previous })
+	ctx := context.Background()
+
+	report := &dto.JudgeReport{SubmissionID: uuid.New(), Verdict: "AC"}
+	for attempt := 1; attempt <= maxJudgeResultAttempts; attempt++ {
+		if err := retryJudgeResult(report); err != nil {
+			t.Fatalf("attempt %d: %v", attempt, err)
+		}
+
+		queue := "judge_results"
+		if attempt == maxJudgeResultAttempts {
+			queue = "judge_results:dead"
+		}
+		raw, err := client.RPop(ctx, queue).Result()
+		if err != nil {
+			t.Fatalf("attempt %d: nothing on %s: %v", attempt, queue, err)
+		}
+		var requeued dto.JudgeReport
+		if err := json.Unmarshal([]byte(raw), &requeued); err != nil {
+			t.Fatalf("attempt %d: decode: %v", attempt, err)
+		}
+		if requeued.SubmissionID != report.SubmissionID || requeued.Attempts != attempt {
+			t.Fatalf("attempt %d: requeued %+v, want the report with %d attempts", attempt, requeued, attempt)
+		}
+		*report = requeued
+	}
+	if n, _ := client.LLen(ctx, "judge_results").Result(); n != 0 {
+		t.Errorf("judge_results holds %d reports after the last attempt, want 0", n)
+	}
+}
+
+func TestJudgeResultBackoff(t *testing.T) {
+	for attempts, want := range []int{1, 1, 2, 4, 8, 16} {
+		if got := judgeResultBackoff(attempts); got != judgeResultRetryDelay*time.Duration(want) {
+			t.Errorf("judgeResultBackoff(%d) = %v, want %v", attempts, got, judgeResultRetryDelay*time.Duration(want))
+		}
+	}
+}
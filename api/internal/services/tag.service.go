@@ -0,0 +1,100 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+	"github.com/klaus-creations/klaus-judge/api/internal/dto"
+	"github.com/klaus-creations/klaus-judge/api/internal/repository"
+)
+
+var (
+	ErrTagNotFound      = errors.New("tag not found")
+	ErrTagExists        = errors.New("a tag with this name already exists")
+	ErrInvalidTagName   = errors.New("tag name must not be empty")
+	ErrMergeTagIntoSelf = errors.New("a tag cannot be merged into itself")
+)
+
+// TagService manages the tags problems are categorized by.
+type TagService struct {
+	tagRepo repository.TagRepository
+}
+
+// NewTagService creates a new tag service.
+func NewTagService(tagRepo repository.TagRepository) *TagService {
+	return &TagService{tagRepo: tagRepo}
+}
+
+// ListTags returns every tag with its number of problems. Unless
+// includeUnreleased is set, only problems listed publicly are counted.
+func (s *TagService) ListTags(includeUnreleased bool) ([]dto.TagCountDTO, error) {
+	var visibleAt *time.Time
+	if !includeUnreleased {
+		now := time.Now()
+		visibleAt = &now
+	}
+
+	tags, err := s.tagRepo.FindAllWithCounts(visibleAt)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]dto.TagCountDTO, 0, len(tags))
+	for _, t := range tags {
+		result = append(result, dto.TagCountDTOFromDomain(t))
+	}
+	return result, nil
+}
+
+// RenameTag changes the name of a tag on every problem carrying it.
+func (s *TagService) RenameTag(id uuid.UUID, name string) (*domain.Tag, error) {
+	tag, err := s.tagRepo.FindByID(id)
+	if err != nil {
+		return nil, ErrTagNotFound
+	}
+
+	name = domain.NormalizeTagName(name)
+	if name == "" {
+		return nil, ErrInvalidTagName
+	}
+	if existing, err := s.tagRepo.FindByName(name); err == nil && existing.ID != tag.ID {
+		return nil, ErrTagExists
+	}
+
+	tag.Name = name
+	if err := s.tagRepo.Update(tag); err != nil {
+		return nil, err
+	}
+	return tag, nil
+}
+
+// MergeTag moves the problems of one tag to another and deletes the first.
+func (s *TagService) MergeTag(id, intoID uuid.UUID) error {
+	if id == intoID {
+		return ErrMergeTagIntoSelf
+	}
+	if _, err := s.tagRepo.FindByID(id); err != nil {
+		return ErrTagNotFound
+	}
+	if _, err := s.tagRepo.FindByID(intoID); err != nil {
+		return ErrTagNotFound
+	}
+	return s.tagRepo.Merge(id, intoID)
+}
+
+// normalizeTagNames normalizes tag names, dropping empty and repeated ones.
+func normalizeTagNames(names []string) []string {
+	result := make([]string, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		name = domain.NormalizeTagName(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		result = append(result, name)
+	}
+	return result
+}
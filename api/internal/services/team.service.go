@@ -0,0 +1,310 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+	"github.com/klaus-creations/klaus-judge/api/internal/dto"
+	"github.com/klaus-creations/klaus-judge/api/internal/repository"
+)
+
+var (
+	ErrTeamNotFound       = errors.New("team not found")
+	ErrAlreadyInTeam      = errors.New("you are already in a team for this contest")
+	ErrUserAlreadyInTeam  = errors.New("user is already in a team for this contest")
+	ErrTeamFull           = errors.New("team is full")
+	ErrNotTeamCaptain     = errors.New("only the team captain can do this")
+	ErrNotTeamMember      = errors.New("you are not a member of this team")
+	ErrAlreadyInvited     = errors.New("user has already been invited")
+	ErrTeamInviteNotFound = errors.New("invite not found")
+)
+
+// TeamService handles teams that take part in contests as one participant.
+// Team membership can only change before the contest starts.
+type TeamService struct {
+	teamRepo        repository.TeamRepository
+	teamInviteRepo  repository.TeamInviteRepository
+	contestRepo     repository.ContestRepository
+	participantRepo repository.ContestParticipantRepository
+	userRepo        repository.UserRepository
+	contestService  *ContestService
+}
+
+// NewTeamService creates a new team service.
+func NewTeamService(
+	teamRepo repository.TeamRepository,
+	teamInviteRepo repository.TeamInviteRepository,
+	contestRepo repository.ContestRepository,
+	participantRepo repository.ContestParticipantRepository,
+	userRepo repository.UserRepository,
+	contestService *ContestService,
+) *TeamService {
+	return &TeamService{
+		teamRepo:        teamRepo,
+		teamInviteRepo:  teamInviteRepo,
+		contestRepo:     contestRepo,
+		participantRepo: participantRepo,
+		userRepo:        userRepo,
+		contestService:  contestService,
+	}
+}
+
+// CreateTeam creates a team with the user as its captain and only member.
+func (s *TeamService) CreateTeam(contestID uuid.UUID, userID uuid.UUID, req *dto.CreateTeamRequest) (*dto.TeamResponse, error) {
+	if _, err := s.teamContest(contestID); err != nil {
+		return nil, err
+	}
+	if _, err := s.teamRepo.FindByContestAndMember(contestID, userID); err == nil {
+		return nil, ErrAlreadyInTeam
+	}
+
+	team := &domain.Team{
+		ContestID: contestID,
+		Name:      req.Name,
+		CaptainID: userID,
+		Members:   []domain.TeamMember{{ContestID: contestID, UserID: userID}},
+	}
+	if err := s.teamRepo.Create(team); err != nil {
+		return nil, errors.New("team name is already taken in this contest")
+	}
+
+	created, err := s.teamRepo.FindByID(team.ID)
+	if err != nil {
+		return nil, err
+	}
+	return dto.TeamResponseFromDomain(created, false), nil
+}
+
+// ListTeams lists the teams of a contest.
+func (s *TeamService) ListTeams(contestID uuid.UUID, viewerID uuid.UUID, isAdmin bool) ([]dto.TeamResponse, error) {
+	if err := s.contestService.CheckAccess(contestID, viewerID, isAdmin); err != nil {
+		return nil, err
+	}
+
+	teams, err := s.teamRepo.FindByContestID(contestID)
+	if err != nil {
+		return nil, err
+	}
+
+	teamDTOs := []dto.TeamResponse{}
+	for _, team := range teams {
+		_, err := s.participantRepo.FindByTeamID(team.ID)
+		teamDTOs = append(teamDTOs, *dto.TeamResponseFromDomain(team, err == nil))
+	}
+	return teamDTOs, nil
+}
+
+// GetMyTeam returns the user's team in a contest and their pending invites.
+func (s *TeamService) GetMyTeam(contestID uuid.UUID, userID uuid.UUID) (*dto.MyTeamResponse, error) {
+	if _, err := s.contestRepo.FindByID(contestID); err != nil {
+		return nil, err
+	}
+
+	resp := &dto.MyTeamResponse{Invites: []dto.TeamInviteDTO{}}
+	if team, err := s.teamRepo.FindByContestAndMember(contestID, userID); err == nil {
+		_, err := s.participantRepo.FindByTeamID(team.ID)
+		resp.Team = dto.TeamResponseFromDomain(team, err == nil)
+	}
+
+	invites, err := s.teamInviteRepo.FindByContestAndUser(contestID, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, invite := range invites {
+		resp.Invites = append(resp.Invites, dto.TeamInviteDTO{
+			TeamID:    invite.TeamID,
+			TeamName:  invite.Team.Name,
+			InvitedAt: invite.CreatedAt,
+		})
+	}
+	return resp, nil
+}
+
+// InviteMember invites a user to the captain's team.
+func (s *TeamService) InviteMember(contestID uuid.UUID, teamID uuid.UUID, captainID uuid.UUID, req *dto.InviteTeamMemberRequest) error {
+	contest, err := s.teamContest(contestID)
+	if err != nil {
+		return err
+	}
+	team, err := s.findTeam(contestID, teamID)
+	if err != nil {
+		return err
+	}
+	if team.CaptainID != captainID {
+		return ErrNotTeamCaptain
+	}
+	if len(team.Members) >= contest.MaxTeamSize {
+		return ErrTeamFull
+	}
+
+	user, err := s.userRepo.FindByUsername(req.Username)
+	if err != nil {
+		return errors.New("user not found")
+	}
+	if _, err := s.teamRepo.FindByContestAndMember(contestID, user.ID); err == nil {
+		return ErrUserAlreadyInTeam
+	}
+	if _, err := s.teamInviteRepo.FindByTeamAndUser(teamID, user.ID); err == nil {
+		return ErrAlreadyInvited
+	}
+
+	return s.teamInviteRepo.Create(&domain.TeamInvite{
+		TeamID:    teamID,
+		UserID:    user.ID,
+		InvitedBy: captainID,
+	})
+}
+
+// AcceptInvite adds the user to the team that invited them.
+func (s *TeamService) AcceptInvite(contestID uuid.UUID, teamID uuid.UUID, userID uuid.UUID) (*dto.TeamResponse, error) {
+	contest, err := s.teamContest(contestID)
+	if err != nil {
+		return nil, err
+	}
+	team, err := s.findTeam(contestID, teamID)
+	if err != nil {
+		return nil, err
+	}
+	invite, err := s.teamInviteRepo.FindByTeamAndUser(teamID, userID)
+	if err != nil {
+		return nil, ErrTeamInviteNotFound
+	}
+	if _, err := s.teamRepo.FindByContestAndMember(contestID, userID); err == nil {
+		return nil, ErrAlreadyInTeam
+	}
+	if len(team.Members) >= contest.MaxTeamSize {
+		return nil, ErrTeamFull
+	}
+
+	if err := s.teamRepo.AddMember(&domain.TeamMember{TeamID: teamID, ContestID: contestID, UserID: userID}); err != nil {
+		return nil, ErrAlreadyInTeam
+	}
+	if err := s.teamInviteRepo.Delete(invite.ID); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.teamRepo.FindByID(teamID)
+	if err != nil {
+		return nil, err
+	}
+	_, err = s.participantRepo.FindByTeamID(teamID)
+	return dto.TeamResponseFromDomain(updated, err == nil), nil
+}
+
+// DeclineInvite discards an invitation.
+func (s *TeamService) DeclineInvite(contestID uuid.UUID, teamID uuid.UUID, userID uuid.UUID) error {
+	if _, err := s.findTeam(contestID, teamID); err != nil {
+		return err
+	}
+	invite, err := s.teamInviteRepo.FindByTeamAndUser(teamID, userID)
+	if err != nil {
+		return ErrTeamInviteNotFound
+	}
+	return s.teamInviteRepo.Delete(invite.ID)
+}
+
+// LeaveTeam removes the user from their team. A leaving captain hands over to
+// the longest-standing member; the last member leaving disbands the team and
+// withdraws its registration.
+func (s *TeamService) LeaveTeam(contestID uuid.UUID, teamID uuid.UUID, userID uuid.UUID) error {
+	if _, err := s.teamContest(contestID); err != nil {
+		return err
+	}
+	team, err := s.findTeam(contestID, teamID)
+	if err != nil {
+		return err
+	}
+
+	var successor *domain.TeamMember
+	isMember := false
+	for i := range team.Members {
+		if team.Members[i].UserID == userID {
+			isMember = true
+		} else if successor == nil {
+			successor = &team.Members[i]
+		}
+	}
+	if !isMember {
+		return ErrNotTeamMember
+	}
+
+	participant, err := s.participantRepo.FindByTeamID(teamID)
+	registered := err == nil
+
+	if successor == nil {
+		if registered {
+			if err := s.participantRepo.Delete(participant.ID); err != nil {
+				return err
+			}
+		}
+		return s.teamRepo.Delete(teamID)
+	}
+
+	if err := s.teamRepo.RemoveMember(teamID, userID); err != nil {
+		return err
+	}
+	if team.CaptainID == userID {
+		team.CaptainID = successor.UserID
+		if err := s.teamRepo.Update(team); err != nil {
+			return err
+		}
+		// The registration is held by the captain.
+		if registered {
+			participant.UserID = successor.UserID
+			return s.participantRepo.Update(participant)
+		}
+	}
+	return nil
+}
+
+// RegisterTeam registers the captain's team for the contest.
+func (s *TeamService) RegisterTeam(contestID uuid.UUID, teamID uuid.UUID, captainID uuid.UUID, req *dto.RegisterContestRequest) error {
+	team, err := s.findTeam(contestID, teamID)
+	if err != nil {
+		return err
+	}
+	if team.CaptainID != captainID {
+		return ErrNotTeamCaptain
+	}
+	return s.contestService.RegisterTeam(contestID, team, req)
+}
+
+// UnregisterTeam withdraws the captain's team from the contest.
+func (s *TeamService) UnregisterTeam(contestID uuid.UUID, teamID uuid.UUID, captainID uuid.UUID) error {
+	team, err := s.findTeam(contestID, teamID)
+	if err != nil {
+		return err
+	}
+	if team.CaptainID != captainID {
+		return ErrNotTeamCaptain
+	}
+	return s.contestService.Unregister(contestID, captainID)
+}
+
+// teamContest loads a team contest whose teams may still change. Private
+// contests are not hidden here: teams form before anyone is admitted, and the
+// captain's invite code or password is checked when the team registers.
+func (s *TeamService) teamContest(contestID uuid.UUID) (*domain.Contest, error) {
+	contest, err := s.contestService.findContest(contestID)
+	if err != nil {
+		return nil, err
+	}
+	if contest.MaxTeamSize == 0 {
+		return nil, ErrNotTeamContest
+	}
+	if !time.Now().Before(contest.StartTime) {
+		return nil, ErrContestStarted
+	}
+	return contest, nil
+}
+
+// findTeam loads a team of the given contest.
+func (s *TeamService) findTeam(contestID uuid.UUID, teamID uuid.UUID) (*domain.Team, error) {
+	team, err := s.teamRepo.FindByID(teamID)
+	if err != nil || team.ContestID != contestID {
+		return nil, ErrTeamNotFound
+	}
+	return team, nil
+}
@@ -0,0 +1,294 @@
+package services
+
+import (
+	"errors"
+	"math"
+
+	"github.com/google/uuid"
+	"github.com/klaus-creations/klaus-judge/api/internal/domain"
+	"github.com/klaus-creations/klaus-judge/api/internal/dto"
+	"github.com/klaus-creations/klaus-judge/api/internal/repository"
+)
+
+var (
+	ErrTestGroupNotFound      = errors.New("test group not found")
+	ErrInvalidGroupDependency = errors.New("a test group can only depend on other groups of the same problem")
+	ErrGroupDependencyCycle   = errors.New("test group dependencies must not form a cycle")
+)
+
+// TestGroupService manages the test groups (subtasks) of problems.
+type TestGroupService struct {
+	testGroupRepo repository.TestGroupRepository
+	problemRepo   repository.ProblemRepository
+	transactor    repository.ProblemEditTransactor
+}
+
+// NewTestGroupService creates a new test group service.
+func NewTestGroupService(
+	testGroupRepo repository.TestGroupRepository,
+	problemRepo repository.ProblemRepository,
+	transactor repository.ProblemEditTransactor,
+) *TestGroupService {
+	return &TestGroupService{
+		testGroupRepo: testGroupRepo,
+		problemRepo:   problemRepo,
+		transactor:    transactor,
+	}
+}
+
+// ListGroups returns the test groups of a problem in order.
+func (s *TestGroupService) ListGroups(slug string) ([]dto.TestGroupDTO, error) {
+	problem, err := s.problemRepo.FindBySlug(slug)
+	if err != nil {
+		return nil, ErrProblemNotFound
+	}
+
+	groups, err := s.testGroupRepo.FindByProblemID(problem.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]dto.TestGroupDTO, 0, len(groups))
+	for _, g := range groups {
+		result = append(result, dto.TestGroupDTOFromDomain(g))
+	}
+	return result, nil
+}
+
+// CreateGroup adds a test group to a problem and records a revision.
+func (s *TestGroupService) CreateGroup(slug string, req *dto.TestGroupRequest, editorID uuid.UUID) (*domain.TestGroup, error) {
+	problem, err := s.problemRepo.FindBySlug(slug)
+	if err != nil {
+		return nil, ErrProblemNotFound
+	}
+
+	existing, err := s.testGroupRepo.FindByProblemID(problem.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	group := &domain.TestGroup{ProblemID: problem.ID}
+	applyTestGroupRequest(group, req)
+	if group.OrderIndex == 0 {
+		group.OrderIndex = len(existing) + 1
+	}
+	if err := validateGroupDependencies(group, existing); err != nil {
+		return nil, err
+	}
+
+	err = editProblem(s.transactor, problem, editorID, domain.RevisionChangeAddTestGroup, func(repos *repository.ProblemEditRepositories) error {
+		return repos.TestGroups.Create(group)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return group, nil
+}
+
+// UpdateGroup replaces the settings and dependencies of a test group and
+// records a revision.
+func (s *TestGroupService) UpdateGroup(slug string, groupID uuid.UUID, req *dto.TestGroupRequest, editorID uuid.UUID) (*domain.TestGroup, error) {
+	problem, group, err := s.findGroup(slug, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.testGroupRepo.FindByProblemID(problem.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	applyTestGroupRequest(group, req)
+	if err := validateGroupDependencies(group, existing); err != nil {
+		return nil, err
+	}
+
+	err = editProblem(s.transactor, problem, editorID, domain.RevisionChangeUpdateTestGroup, func(repos *repository.ProblemEditRepositories) error {
+		return repos.TestGroups.Update(group)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return group, nil
+}
+
+// DeleteGroup removes a test group and records a revision. Its test cases
+// stay on the problem without a group, and groups depending on it lose that
+// dependency.
+func (s *TestGroupService) DeleteGroup(slug string, groupID uuid.UUID, editorID uuid.UUID) error {
+	problem, _, err := s.findGroup(slug, groupID)
+	if err != nil {
+		return err
+	}
+	return editProblem(s.transactor, problem, editorID, domain.RevisionChangeDeleteTestGroup, func(repos *repository.ProblemEditRepositories) error {
+		return repos.TestGroups.Delete(groupID)
+	})
+}
+
+// findGroup loads a problem and one of its test groups.
+func (s *TestGroupService) findGroup(slug string, groupID uuid.UUID) (*domain.Problem, *domain.TestGroup, error) {
+	problem, err := s.problemRepo.FindBySlug(slug)
+	if err != nil {
+		return nil, nil, ErrProblemNotFound
+	}
+
+	group, err := s.testGroupRepo.FindByID(groupID)
+	if err != nil || group.ProblemID != problem.ID {
+		return nil, nil, ErrTestGroupNotFound
+	}
+	return problem, group, nil
+}
+
+// applyTestGroupRequest copies a request onto a group.
+func applyTestGroupRequest(group *domain.TestGroup, req *dto.TestGroupRequest) {
+	group.Name = req.Name
+	group.Points = req.Points
+	group.ScoringRule = domain.GroupScoringAll
+	if req.ScoringRule != "" {
+		group.ScoringRule = req.ScoringRule
+	}
+	if req.OrderIndex != 0 {
+		group.OrderIndex = req.OrderIndex
+	}
+
+	group.Dependencies = nil
+	seen := make(map[uuid.UUID]bool, len(req.DependsOn))
+	for _, id := range req.DependsOn {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		group.Dependencies = append(group.Dependencies, domain.TestGroupDependency{
+			GroupID:     group.ID,
+			DependsOnID: id,
+		})
+	}
+}
+
+// validateGroupDependencies checks that a group only depends on other groups
+// of its problem and that the dependency graph stays acyclic with its new edges.
+func validateGroupDependencies(group *domain.TestGroup, siblings []*domain.TestGroup) error {
+	edges := make(map[uuid.UUID][]uuid.UUID, len(siblings)+1)
+	for _, g := range siblings {
+		edges[g.ID] = g.DependsOn()
+	}
+	for _, dep := range group.DependsOn() {
+		if _, ok := edges[dep]; !ok || dep == group.ID {
+			return ErrInvalidGroupDependency
+		}
+	}
+	edges[group.ID] = group.DependsOn()
+
+	// Depth-first search for a path leading back to the group.
+	visited := make(map[uuid.UUID]bool, len(edges))
+	var reaches func(id uuid.UUID) bool
+	reaches = func(id uuid.UUID) bool {
+		if id == group.ID {
+			return true
+		}
+		if visited[id] {
+			return false
+		}
+		visited[id] = true
+		for _, next := range edges[id] {
+			if reaches(next) {
+				return true
+			}
+		}
+		return false
+	}
+	for _, dep := range edges[group.ID] {
+		if reaches(dep) {
+			return ErrGroupDependencyCycle
+		}
+	}
+	return nil
+}
+
+// scoreTestGroups rolls per-test results up into per-group results and the
+// total score. A test scores the share a checker awarded, otherwise 1 if
+// accepted and 0 if not (or if it never ran). An "all" group earns its points
+// only when every test scores 1; a "min" group earns its points times its
+// lowest test score. Either way a group earns nothing unless every group it
+// depends on passed in full.
+func scoreTestGroups(groups []*domain.TestGroup, testCases []*domain.TestCase, results []*domain.TestCaseResult) ([]dto.TestGroupResultDTO, float64) {
+	testScores := make(map[uuid.UUID]float64, len(results))
+	for _, tr := range results {
+		switch {
+		case tr.Score != nil:
+			testScores[tr.TestCaseID] = math.Max(0, math.Min(1, *tr.Score))
+		case tr.Verdict == domain.VerdictAC:
+			testScores[tr.TestCaseID] = 1
+		}
+	}
+
+	// Share of each group's points earned before dependencies are considered
+	fractions := make(map[uuid.UUID]float64, len(groups))
+	counts := make(map[uuid.UUID]int, len(groups))
+	for _, tc := range testCases {
+		if tc.GroupID == nil {
+			continue
+		}
+		score := testScores[tc.ID]
+		if counts[*tc.GroupID] == 0 || score < fractions[*tc.GroupID] {
+			fractions[*tc.GroupID] = score
+		}
+		counts[*tc.GroupID]++
+	}
+
+	byID := make(map[uuid.UUID]*domain.TestGroup, len(groups))
+	for _, g := range groups {
+		byID[g.ID] = g
+		if g.ScoringRule != domain.GroupScoringMin && fractions[g.ID] < 1 {
+			fractions[g.ID] = 0
+		}
+	}
+
+	// passed reports whether a group and everything it depends on is solved
+	// in full; visiting guards against cycles in stale data.
+	memo := make(map[uuid.UUID]bool, len(groups))
+	visiting := make(map[uuid.UUID]bool, len(groups))
+	var passed func(id uuid.UUID) bool
+	dependenciesMet := func(g *domain.TestGroup) bool {
+		for _, dep := range g.DependsOn() {
+			if !passed(dep) {
+				return false
+			}
+		}
+		return true
+	}
+	passed = func(id uuid.UUID) bool {
+		if ok, done := memo[id]; done {
+			return ok
+		}
+		g, exists := byID[id]
+		if !exists || visiting[id] {
+			return false
+		}
+		visiting[id] = true
+		ok := counts[id] > 0 && fractions[id] == 1 && dependenciesMet(g)
+		visiting[id] = false
+		memo[id] = ok
+		return ok
+	}
+
+	groupResults := make([]dto.TestGroupResultDTO, 0, len(groups))
+	total := 0.0
+	for _, g := range groups {
+		met := dependenciesMet(g)
+		score := 0.0
+		if met && counts[g.ID] > 0 {
+			score = float64(g.Points) * fractions[g.ID]
+		}
+		total += score
+		groupResults = append(groupResults, dto.TestGroupResultDTO{
+			GroupID:         g.ID,
+			Name:            g.Name,
+			Points:          g.Points,
+			Score:           score,
+			Passed:          passed(g.ID),
+			DependenciesMet: met,
+		})
+	}
+	return groupResults, total
+}
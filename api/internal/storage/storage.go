@@ -0,0 +1,21 @@
+// Package storage keeps uploaded files such as problem attachments outside the
+// database, on the local filesystem or in an S3-compatible object store.
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+var ErrNotFound = errors.New("object not found")
+
+// Storage stores blobs under slash-separated keys.
+type Storage interface {
+	// Put stores size bytes read from body under key, replacing any object there.
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	// Open returns the object stored under key, or ErrNotFound.
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the object stored under key; missing objects are not an error.
+	Delete(ctx context.Context, key string) error
+}
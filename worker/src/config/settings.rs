@@ -20,6 +20,8 @@ pub struct DatabaseConfig {
 pub struct RedisConfig {
     pub url: String,
     pub queue_name: String,
+    /// List the API consumes judge reports from.
+    pub results_queue: String,
 }
 
 #[derive(Debug, Clone, Deserialize)]
@@ -62,6 +64,7 @@ impl Settings {
             .set_default("logging.level", "info")?
             .set_default("logging.json_format", false)?
             .set_default("redis.queue_name", "judge_queue")?
+            .set_default("redis.results_queue", "judge_results")?
             // Override with environment variables
             .add_source(
                 config::Environment::default()
@@ -0,0 +1,28 @@
+use crate::database::DbPool;
+use crate::models::Checker;
+use anyhow::{Context, Result};
+use tracing::info;
+use uuid::Uuid;
+
+pub async fn fetch_checker(pool: &DbPool, problem_id: Uuid) -> Result<Option<Checker>> {
+    info!("🔍 Fetching checker for problem {}", problem_id);
+
+    let checker = sqlx::query_as!(
+        Checker,
+        r#"
+        SELECT
+            id,
+            problem_id,
+            language as "language!",
+            code as "code!"
+        FROM problem_checkers
+        WHERE problem_id = $1
+        "#,
+        problem_id
+    )
+    .fetch_optional(pool)
+    .await
+    .context("Failed to fetch checker from database")?;
+
+    Ok(checker)
+}
@@ -0,0 +1,28 @@
+use crate::database::DbPool;
+use crate::models::Interactor;
+use anyhow::{Context, Result};
+use tracing::info;
+use uuid::Uuid;
+
+pub async fn fetch_interactor(pool: &DbPool, problem_id: Uuid) -> Result<Option<Interactor>> {
+    info!("🔍 Fetching interactor for problem {}", problem_id);
+
+    let interactor = sqlx::query_as!(
+        Interactor,
+        r#"
+        SELECT
+            id,
+            problem_id,
+            language as "language!",
+            code as "code!"
+        FROM problem_interactors
+        WHERE problem_id = $1
+        "#,
+        problem_id
+    )
+    .fetch_optional(pool)
+    .await
+    .context("Failed to fetch interactor from database")?;
+
+    Ok(interactor)
+}
@@ -1,5 +1,9 @@
+pub mod checkers;
 mod connection;
+pub mod interactors;
+pub mod problems;
 pub mod submission;
 pub mod test_cases;
+pub mod test_groups;
 
 pub use connection::{create_pool, DbPool};
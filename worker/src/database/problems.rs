@@ -0,0 +1,41 @@
+use crate::database::DbPool;
+use anyhow::{Context, Result};
+use uuid::Uuid;
+
+/// Returns the problem type: "standard" or "interactive".
+pub async fn fetch_problem_type(pool: &DbPool, problem_id: Uuid) -> Result<String> {
+    let problem_type = sqlx::query_scalar!(
+        r#"
+        SELECT type as "type!"
+        FROM problems
+        WHERE id = $1
+        "#,
+        problem_id
+    )
+    .fetch_one(pool)
+    .await
+    .context("Failed to fetch problem type from database")?;
+
+    Ok(problem_type)
+}
+
+/// Reports whether a submission is scored per test (IOI scoring on its
+/// problem or its contest), so every test has to run instead of stopping at
+/// the first failure.
+pub async fn is_partially_scored(pool: &DbPool, submission_id: Uuid) -> Result<bool> {
+    let ioi = sqlx::query_scalar!(
+        r#"
+        SELECT (p.scoring_mode = 'ioi' OR COALESCE(c.scoring_mode = 'ioi', false)) as "ioi!"
+        FROM submissions s
+        JOIN problems p ON p.id = s.problem_id
+        LEFT JOIN contests c ON c.id = s.contest_id
+        WHERE s.id = $1
+        "#,
+        submission_id
+    )
+    .fetch_one(pool)
+    .await
+    .context("Failed to fetch scoring mode from database")?;
+
+    Ok(ioi)
+}
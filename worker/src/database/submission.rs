@@ -55,34 +55,3 @@ pub async fn update_submission_status(
 
     Ok(())
 }
-
-pub async fn update_submission_verdict(
-    pool: &DbPool,
-    submission_id: Uuid,
-    verdict: &str,
-    execution_time: f64,
-    memory_used: i64,
-) -> Result<()> {
-    info!("✅ Updating submission {} verdict to: {}", submission_id, verdict);
-
-    sqlx::query!(
-        r#"
-        UPDATE submissions
-        SET
-            verdict = $1,
-            execution_time = $2,
-            memory_used = $3,
-            judged_at = NOW()
-        WHERE id = $4
-        "#,
-        verdict,
-        execution_time as i32,
-        memory_used as i32,
-        submission_id
-    )
-    .execute(pool)
-    .await
-    .context("Failed to update submission verdict")?;
-
-    Ok(())
-}
@@ -17,7 +17,8 @@ pub async fn fetch_test_cases(pool: &DbPool, problem_id: Uuid) -> Result<Vec<Tes
             expected_output,
             CAST(NULL AS integer) as time_limit,
             CAST(NULL AS bigint) as memory_limit,
-            is_sample as "is_sample!"
+            is_sample as "is_sample!",
+            group_id
         FROM test_cases
         WHERE problem_id = $1
         ORDER BY order_index ASC
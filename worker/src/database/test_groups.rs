@@ -0,0 +1,53 @@
+use crate::database::DbPool;
+use crate::models::TestGroup;
+use anyhow::{Context, Result};
+use tracing::info;
+use uuid::Uuid;
+
+pub async fn fetch_test_groups(pool: &DbPool, problem_id: Uuid) -> Result<Vec<TestGroup>> {
+    info!("📋 Fetching test groups for problem {}", problem_id);
+
+    let rows = sqlx::query!(
+        r#"
+        SELECT
+            id,
+            scoring_rule as "scoring_rule!"
+        FROM test_groups
+        WHERE problem_id = $1
+        "#,
+        problem_id
+    )
+    .fetch_all(pool)
+    .await
+    .context("Failed to fetch test groups from database")?;
+
+    let dependencies = sqlx::query!(
+        r#"
+        SELECT
+            d.group_id,
+            d.depends_on_id
+        FROM test_group_dependencies d
+        JOIN test_groups g ON g.id = d.group_id
+        WHERE g.problem_id = $1
+        "#,
+        problem_id
+    )
+    .fetch_all(pool)
+    .await
+    .context("Failed to fetch test group dependencies from database")?;
+
+    let groups = rows
+        .into_iter()
+        .map(|row| TestGroup {
+            id: row.id,
+            scoring_rule: row.scoring_rule,
+            depends_on: dependencies
+                .iter()
+                .filter(|d| d.group_id == row.id)
+                .map(|d| d.depends_on_id)
+                .collect(),
+        })
+        .collect();
+
+    Ok(groups)
+}
@@ -0,0 +1,16 @@
+use serde::{Deserialize, Serialize};
+use uuid::Uuid;
+
+use super::SubmissionLanguage;
+
+/// Custom checker (special judge) of a problem. It is run as
+/// `checker input.txt output.txt answer.txt`; exit code 0 accepts the output,
+/// 1 rejects it and anything else is a checker failure. An optional first
+/// stdout line with a number in [0, 1] is the share of the test's points.
+#[derive(Debug, Clone, Serialize, Deserialize)]
+pub struct Checker {
+    pub id: Uuid,
+    pub problem_id: Uuid,
+    pub language: SubmissionLanguage,
+    pub code: String,
+}
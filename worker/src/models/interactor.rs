@@ -0,0 +1,16 @@
+use serde::{Deserialize, Serialize};
+use uuid::Uuid;
+
+use super::SubmissionLanguage;
+
+/// Judge side of an interactive problem. It is run as
+/// `interactor input.txt answer.txt` with its stdout wired to the solution's
+/// stdin and the solution's stdout wired to its stdin. Exit code 0 accepts,
+/// 1 rejects and anything else is an interactor failure.
+#[derive(Debug, Clone, Serialize, Deserialize)]
+pub struct Interactor {
+    pub id: Uuid,
+    pub problem_id: Uuid,
+    pub language: SubmissionLanguage,
+    pub code: String,
+}
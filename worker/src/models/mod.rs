@@ -1,7 +1,13 @@
+mod checker;
+mod interactor;
 mod result;
 mod submission;
 mod test_case;
+mod test_group;
 
-pub use result::{SubmissionResult, TestResult, Verdict};
+pub use checker::Checker;
+pub use interactor::Interactor;
+pub use result::{JudgeReport, SubmissionResult, TestResult, Verdict};
 pub use submission::{Submission, SubmissionLanguage};
 pub use test_case::TestCase;
+pub use test_group::TestGroup;
@@ -14,15 +14,16 @@ pub enum Verdict {
 }
 
 impl Verdict {
+    /// The verdict code the API stores (its domain.Verdict* constants).
     pub fn as_str(&self) -> &str {
         match self {
-            Self::Accepted => "ACCEPTED",
-            Self::WrongAnswer => "WRONG_ANSWER",
-            Self::TimeLimitExceeded => "TIME_LIMIT_EXCEEDED",
-            Self::MemoryLimitExceeded => "MEMORY_LIMIT_EXCEEDED",
-            Self::RuntimeError => "RUNTIME_ERROR",
-            Self::CompilationError => "COMPILATION_ERROR",
-            Self::SystemError => "SYSTEM_ERROR",
+            Self::Accepted => "AC",
+            Self::WrongAnswer => "WA",
+            Self::TimeLimitExceeded => "TLE",
+            Self::MemoryLimitExceeded => "MLE",
+            Self::RuntimeError => "RE",
+            Self::CompilationError => "CE",
+            Self::SystemError => "SE",
         }
     }
 }
@@ -35,6 +36,8 @@ pub struct TestResult {
     pub memory_used_kb: i64,
     pub output: Option<String>,
     pub error_message: Option<String>,
+    /// Share of the test's points awarded by a custom checker.
+    pub score: Option<f64>,
 }
 
 #[derive(Debug, Clone, Serialize, Deserialize)]
@@ -59,3 +62,85 @@ impl SubmissionResult {
         self.test_results.len()
     }
 }
+
+/// Judge outcome pushed onto the results queue; the API records it, scores
+/// the submission and refreshes the scoreboards.
+#[derive(Debug, Clone, Serialize)]
+pub struct JudgeReport {
+    pub submission_id: Uuid,
+    pub verdict: String,
+    pub execution_time: i64,
+    pub memory_used: i64,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub compile_error: Option<String>,
+    pub tests: Vec<TestReport>,
+}
+
+#[derive(Debug, Clone, Serialize)]
+pub struct TestReport {
+    pub test_case_id: Uuid,
+    pub verdict: String,
+    pub execution_time: i64,
+    pub memory_used: i64,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub output: Option<String>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub error_message: Option<String>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub score: Option<f64>,
+}
+
+/// Longest output of a test kept in a report.
+const MAX_REPORTED_OUTPUT: usize = 4096;
+
+impl JudgeReport {
+    /// Report of a submission the judge could not run to completion.
+    pub fn system_error(submission_id: Uuid) -> Self {
+        Self {
+            submission_id,
+            verdict: Verdict::SystemError.as_str().to_string(),
+            execution_time: 0,
+            memory_used: 0,
+            compile_error: None,
+            tests: Vec::new(),
+        }
+    }
+}
+
+impl From<&SubmissionResult> for JudgeReport {
+    fn from(result: &SubmissionResult) -> Self {
+        Self {
+            submission_id: result.submission_id,
+            verdict: result.final_verdict.as_str().to_string(),
+            execution_time: result.total_time_ms as i64,
+            memory_used: result.max_memory_kb,
+            compile_error: result.compilation_output.clone(),
+            tests: result.test_results.iter().map(TestReport::from).collect(),
+        }
+    }
+}
+
+impl From<&TestResult> for TestReport {
+    fn from(result: &TestResult) -> Self {
+        Self {
+            test_case_id: result.test_case_id,
+            verdict: result.verdict.as_str().to_string(),
+            execution_time: result.execution_time_ms as i64,
+            memory_used: result.memory_used_kb,
+            output: result.output.as_deref().map(truncate),
+            error_message: result.error_message.as_deref().map(truncate),
+            score: result.score,
+        }
+    }
+}
+
+fn truncate(text: &str) -> String {
+    if text.len() <= MAX_REPORTED_OUTPUT {
+        return text.to_string();
+    }
+    let mut end = MAX_REPORTED_OUTPUT;
+    while !text.is_char_boundary(end) {
+        end -= 1;
+    }
+    format!("{}…", &text[..end])
+}
@@ -10,4 +10,5 @@ pub struct TestCase {
     pub time_limit: Option<i32>,
     pub memory_limit: Option<i64>,
     pub is_sample: bool,
+    pub group_id: Option<Uuid>,
 }
@@ -0,0 +1,13 @@
+use serde::{Deserialize, Serialize};
+use uuid::Uuid;
+
+/// Subtask of a problem: a set of its test cases scored together. Under the
+/// "all" rule a group needs every test in full; under "min" it earns its
+/// lowest test score. A group only scores if every group it depends on was
+/// solved in full.
+#[derive(Debug, Clone, Serialize, Deserialize)]
+pub struct TestGroup {
+    pub id: Uuid,
+    pub scoring_rule: String,
+    pub depends_on: Vec<Uuid>,
+}
@@ -1,18 +1,45 @@
 use anyhow::{Context, Result};
 use std::fs;
+use std::io::Read;
 use std::path::{Path, PathBuf};
-use std::process::{Command, Stdio};
+use std::process::{Child, Command, ExitStatus, Stdio};
+use std::thread;
 use std::time::{Duration, Instant};
 use tracing::{error, info};
 use uuid::Uuid;
 
 use crate::config::ExecutionConfig;
-use crate::models::{SubmissionLanguage, TestResult, Verdict};
+use crate::models::{Checker, Interactor, SubmissionLanguage, TestResult, Verdict};
+
+/// Time and memory granted to a custom checker for one test.
+const CHECKER_TIME_LIMIT_MS: u64 = 10000;
+const CHECKER_MEMORY_LIMIT_MB: i64 = 512;
+
+/// Memory granted to an interactor, and how long it may keep running after
+/// the solution's time limit.
+const INTERACTOR_MEMORY_LIMIT_MB: i64 = 512;
+const INTERACTOR_GRACE_MS: u64 = 2000;
 
 pub struct Executor {
     config: ExecutionConfig,
 }
 
+/// A checker built once per submission and run for each of its tests. Its
+/// work dir is removed when it is dropped, unless cleanup is disabled.
+pub struct PreparedChecker {
+    work_dir: PathBuf,
+    program: std::result::Result<(&'static str, Vec<String>), ExecutionOutput>,
+    cleanup: bool,
+}
+
+impl Drop for PreparedChecker {
+    fn drop(&mut self) {
+        if self.cleanup {
+            let _ = fs::remove_dir_all(&self.work_dir);
+        }
+    }
+}
+
 impl Executor {
     pub fn new(config: ExecutionConfig) -> Self {
         Self { config }
@@ -24,6 +51,7 @@ impl Executor {
         code: &str,
         input: &str,
         expected_output: &str,
+        checker: Option<&PreparedChecker>,
         time_limit_ms: u64,
         memory_limit_mb: i64,
     ) -> Result<TestResult> {
@@ -50,6 +78,7 @@ impl Executor {
                 memory_used_kb: 0,
                 output: None,
                 error_message: Some("Language not implemented".to_string()),
+                score: None,
             }),
         };
 
@@ -59,7 +88,280 @@ impl Executor {
             let _ = fs::remove_dir_all(&work_dir);
         }
 
-        self.evaluate_output(output, expected_output, elapsed, time_limit_ms)
+        // Only a clean run within the time limit is worth checking
+        let checked = match checker {
+            Some(checker)
+                if !output.timed_out
+                    && output.exit_code == 0
+                    && elapsed <= time_limit_ms as f64 =>
+            {
+                Some(
+                    self.run_checker(checker, input, &output.stdout, expected_output)
+                        .await?,
+                )
+            }
+            _ => None,
+        };
+
+        self.evaluate_output(output, expected_output, checked, elapsed, time_limit_ms)
+    }
+
+    /// Builds a checker for judging one submission; C++ checkers are
+    /// compiled here rather than for every test.
+    pub async fn prepare_checker(&self, checker: &Checker) -> Result<PreparedChecker> {
+        let work_dir = self.create_work_dir()?;
+        let filename = format!("checker.{}", checker.language.file_extension());
+        fs::write(work_dir.join(&filename), &checker.code)
+            .context("Failed to write checker source")?;
+
+        let program = self
+            .prepare_program(&checker.language, &work_dir, &filename, "checker")
+            .await?;
+
+        Ok(PreparedChecker {
+            work_dir,
+            program,
+            cleanup: self.config.cleanup_on_success,
+        })
+    }
+
+    async fn run_checker(
+        &self,
+        checker: &PreparedChecker,
+        input: &str,
+        output: &str,
+        expected_output: &str,
+    ) -> Result<ExecutionOutput> {
+        let (image, program) = match &checker.program {
+            Ok(program) => program,
+            // g++ exits with 1, which must not read as a rejection
+            Err(compile_output) => {
+                return Ok(ExecutionOutput {
+                    stdout: String::new(),
+                    stderr: format!("Checker compilation failed:\n{}", compile_output.stderr),
+                    exit_code: -1,
+                    timed_out: compile_output.timed_out,
+                });
+            }
+        };
+
+        // Tests run one after another, so they can share the checker's dir
+        let work_dir = &checker.work_dir;
+        fs::write(work_dir.join("input.txt"), input).context("Failed to write checker input")?;
+        fs::write(work_dir.join("output.txt"), output).context("Failed to write checker output")?;
+        fs::write(work_dir.join("answer.txt"), expected_output)
+            .context("Failed to write checker answer")?;
+
+        let mut cmd: Vec<&str> = program.iter().map(String::as_str).collect();
+        cmd.extend(["input.txt", "output.txt", "answer.txt"]);
+
+        self.execute_docker(
+            image,
+            &cmd,
+            &[(work_dir.to_str().unwrap(), "/app")],
+            "",
+            CHECKER_TIME_LIMIT_MS,
+            CHECKER_MEMORY_LIMIT_MB,
+        ).await
+    }
+
+    /// Runs one test of an interactive problem: the solution and the
+    /// interactor run in separate containers with their stdin and stdout
+    /// cross-wired, and the interactor's exit code decides the verdict.
+    pub async fn execute_interactive_test(
+        &self,
+        language: &SubmissionLanguage,
+        code: &str,
+        interactor: &Interactor,
+        input: &str,
+        answer: &str,
+        time_limit_ms: u64,
+        memory_limit_mb: i64,
+    ) -> Result<TestResult> {
+        let solution_dir = self.create_work_dir()?;
+        let filename = language.source_filename();
+        fs::write(solution_dir.join(filename), code)
+            .context("Failed to write source file")?;
+
+        let interactor_dir = self.create_work_dir()?;
+        fs::write(interactor_dir.join("input.txt"), input)
+            .context("Failed to write interactor input")?;
+        fs::write(interactor_dir.join("answer.txt"), answer)
+            .context("Failed to write interactor answer")?;
+        let interactor_file = format!("interactor.{}", interactor.language.file_extension());
+        fs::write(interactor_dir.join(&interactor_file), &interactor.code)
+            .context("Failed to write interactor source")?;
+
+        let solution_cmd = match self.prepare_program(language, &solution_dir, filename, "solution").await? {
+            Ok(cmd) => cmd,
+            Err(compile_output) => return Ok(TestResult {
+                test_case_id: Uuid::nil(),
+                verdict: Verdict::CompilationError,
+                execution_time_ms: 0.0,
+                memory_used_kb: 0,
+                output: None,
+                error_message: Some(compile_output.stderr),
+                score: None,
+            }),
+        };
+        let interactor_cmd = match self.prepare_program(&interactor.language, &interactor_dir, &interactor_file, "interactor").await? {
+            Ok(mut cmd) => {
+                cmd.1.extend(["input.txt".to_string(), "answer.txt".to_string()]);
+                cmd
+            }
+            Err(compile_output) => return Ok(TestResult {
+                test_case_id: Uuid::nil(),
+                verdict: Verdict::SystemError,
+                execution_time_ms: 0.0,
+                memory_used_kb: 0,
+                output: None,
+                error_message: Some(format!("Interactor failed to build: {}", compile_output.stderr)),
+                score: None,
+            }),
+        };
+
+        let solution_name = format!("judge_{}", Uuid::new_v4());
+        let interactor_name = format!("judge_{}", Uuid::new_v4());
+        let solution_args = Self::interactive_docker_args(
+            &solution_name,
+            solution_cmd.0,
+            &solution_cmd.1,
+            solution_dir.to_str().unwrap(),
+            memory_limit_mb,
+        );
+        let interactor_args = Self::interactive_docker_args(
+            &interactor_name,
+            interactor_cmd.0,
+            &interactor_cmd.1,
+            interactor_dir.to_str().unwrap(),
+            INTERACTOR_MEMORY_LIMIT_MB,
+        );
+
+        let start = Instant::now();
+        let (solution, interactor_run) = tokio::task::spawn_blocking(move || {
+            run_interaction(
+                solution_args,
+                interactor_args,
+                [solution_name, interactor_name],
+                Duration::from_millis(time_limit_ms),
+                Duration::from_millis(time_limit_ms + INTERACTOR_GRACE_MS),
+            )
+        })
+        .await
+        .context("Interaction task failed")?
+        .context("Failed to run interaction")?;
+        let elapsed = start.elapsed().as_secs_f64() * 1000.0;
+
+        if self.config.cleanup_on_success {
+            let _ = fs::remove_dir_all(&solution_dir);
+            let _ = fs::remove_dir_all(&interactor_dir);
+        }
+
+        Ok(Self::evaluate_interaction(solution, interactor_run, elapsed, time_limit_ms))
+    }
+
+    /// Builds a program in its work dir and returns the image and command
+    /// that run it, or the compiler output if the build failed.
+    async fn prepare_program(
+        &self,
+        language: &SubmissionLanguage,
+        work_dir: &Path,
+        filename: &str,
+        binary: &str,
+    ) -> Result<std::result::Result<(&'static str, Vec<String>), ExecutionOutput>> {
+        match language {
+            SubmissionLanguage::Python => Ok(Ok((
+                "klaus-judge-python:latest",
+                vec!["python".to_string(), filename.to_string()],
+            ))),
+            SubmissionLanguage::Cpp => {
+                let compile_output = self.execute_docker(
+                    "klaus-judge-cpp:latest",
+                    &["g++", "-O2", "-o", binary, filename],
+                    &[(work_dir.to_str().unwrap(), "/app")],
+                    "",
+                    10000, // 10s compile timeout
+                    1024, // 1GB compile memory
+                ).await?;
+
+                if compile_output.exit_code != 0 {
+                    return Ok(Err(compile_output));
+                }
+                Ok(Ok(("klaus-judge-cpp:latest", vec![format!("./{}", binary)])))
+            }
+            _ => Ok(Err(ExecutionOutput {
+                stdout: String::new(),
+                stderr: "Language not implemented".to_string(),
+                exit_code: -1,
+                timed_out: false,
+            })),
+        }
+    }
+
+    fn interactive_docker_args(
+        name: &str,
+        image: &str,
+        cmd: &[String],
+        work_dir: &str,
+        memory_limit_mb: i64,
+    ) -> Vec<String> {
+        let mut args: Vec<String> = vec![
+            "run".to_string(),
+            "--rm".to_string(),
+            "-i".to_string(),
+            "--network=none".to_string(),
+            format!("--name={}", name),
+            format!("--memory={}m", memory_limit_mb),
+            "--cpus=1".to_string(),
+            "-v".to_string(),
+            format!("{}:/app:rw", work_dir),
+            image.to_string(),
+        ];
+        args.extend(cmd.iter().cloned());
+        args
+    }
+
+    fn evaluate_interaction(
+        solution: ExecutionOutput,
+        interactor: ExecutionOutput,
+        elapsed_ms: f64,
+        time_limit_ms: u64,
+    ) -> TestResult {
+        let message = |text: &str| {
+            let text = text.trim();
+            if text.is_empty() { None } else { Some(text.to_string()) }
+        };
+
+        let (verdict, error_message) = if solution.timed_out {
+            (Verdict::TimeLimitExceeded, Some("Time limit exceeded".to_string()))
+        } else if interactor.timed_out {
+            (Verdict::SystemError, Some("Interactor did not finish".to_string()))
+        } else {
+            match interactor.exit_code {
+                // A rejected interaction is a wrong answer even if the
+                // solution crashed because the interactor hung up on it
+                1 => (Verdict::WrongAnswer, message(&interactor.stderr)),
+                0 if solution.exit_code != 0 => (Verdict::RuntimeError, message(&solution.stderr)),
+                0 if elapsed_ms > time_limit_ms as f64 => {
+                    (Verdict::TimeLimitExceeded, Some("Time limit exceeded".to_string()))
+                }
+                0 => (Verdict::Accepted, message(&interactor.stderr)),
+                _ => (
+                    Verdict::SystemError,
+                    Some(format!("Interactor failed: {}", interactor.stderr)),
+                ),
+            }
+        };
+
+        TestResult {
+            test_case_id: Uuid::nil(),
+            verdict,
+            execution_time_ms: elapsed_ms,
+            memory_used_kb: 0,
+            output: None,
+            error_message,
+            score: None,
+        }
     }
 
     fn create_work_dir(&self) -> Result<PathBuf> {
@@ -215,6 +517,7 @@ impl Executor {
         &self,
         output: ExecutionOutput,
         expected: &str,
+        checked: Option<ExecutionOutput>,
         elapsed_ms: f64,
         time_limit_ms: u64,
     ) -> Result<TestResult> {
@@ -226,6 +529,7 @@ impl Executor {
                 memory_used_kb: 0,
                 output: Some(output.stdout),
                 error_message: Some("Time limit exceeded".to_string()),
+                score: None,
             });
         }
 
@@ -237,9 +541,14 @@ impl Executor {
                 memory_used_kb: 0,
                 output: Some(output.stdout.clone()),
                 error_message: Some(output.stderr),
+                score: None,
             });
         }
 
+        if let Some(checked) = checked {
+            return Ok(Self::evaluate_checker(checked, output.stdout, elapsed_ms));
+        }
+
         let actual = output.stdout.trim();
         let expected = expected.trim();
 
@@ -262,8 +571,44 @@ impl Executor {
             memory_used_kb: 0,
             output: Some(output.stdout),
             error_message: error_msg,
+            score: None,
         })
     }
+
+    /// Turns the checker's exit code and stdout into the test's verdict and score.
+    fn evaluate_checker(checked: ExecutionOutput, stdout: String, elapsed_ms: f64) -> TestResult {
+        let verdict = match (checked.timed_out, checked.exit_code) {
+            (false, 0) => Verdict::Accepted,
+            (false, 1) => Verdict::WrongAnswer,
+            _ => Verdict::SystemError,
+        };
+
+        let mut lines = checked.stdout.lines();
+        let (score, message) = match lines.next().map(|l| l.trim().parse::<f64>()) {
+            Some(Ok(score)) if (0.0..=1.0).contains(&score) => {
+                (Some(score), lines.collect::<Vec<_>>().join("\n"))
+            }
+            _ => (None, checked.stdout.clone()),
+        };
+
+        let error_message = if verdict == Verdict::SystemError {
+            Some(format!("Checker failed: {}", checked.stderr))
+        } else if message.trim().is_empty() {
+            None
+        } else {
+            Some(message.trim().to_string())
+        };
+
+        TestResult {
+            test_case_id: Uuid::nil(),
+            verdict,
+            execution_time_ms: elapsed_ms,
+            memory_used_kb: 0,
+            output: Some(stdout),
+            error_message,
+            score,
+        }
+    }
 }
 
 pub struct ExecutionOutput {
@@ -272,3 +617,113 @@ pub struct ExecutionOutput {
     pub exit_code: i32,
     pub timed_out: bool,
 }
+
+/// Runs the solution and the interactor with each one's stdout feeding the
+/// other's stdin. A process still running at its deadline is killed (through
+/// `docker kill`, since killing the client would leave the container running)
+/// and marked as timed out.
+fn run_interaction(
+    solution_args: Vec<String>,
+    interactor_args: Vec<String>,
+    container_names: [String; 2],
+    solution_timeout: Duration,
+    interactor_timeout: Duration,
+) -> std::io::Result<(ExecutionOutput, ExecutionOutput)> {
+    let mut solution = Command::new("docker")
+        .args(&solution_args)
+        .stdin(Stdio::piped())
+        .stdout(Stdio::piped())
+        .stderr(Stdio::piped())
+        .spawn()?;
+    let solution_stdout = solution.stdout.take().expect("solution stdout is piped");
+    let mut solution_stdin = solution.stdin.take().expect("solution stdin is piped");
+
+    let mut interactor = match Command::new("docker")
+        .args(&interactor_args)
+        .stdin(Stdio::from(solution_stdout))
+        .stdout(Stdio::piped())
+        .stderr(Stdio::piped())
+        .spawn()
+    {
+        Ok(child) => child,
+        Err(e) => {
+            kill_container(&container_names[0]);
+            let _ = solution.kill();
+            let _ = solution.wait();
+            return Err(e);
+        }
+    };
+    let mut interactor_stdout = interactor.stdout.take().expect("interactor stdout is piped");
+
+    // Relay the interactor's answers; dropping the pipe at the end gives the
+    // solution EOF once the interactor is done
+    let relay = thread::spawn(move || {
+        let _ = std::io::copy(&mut interactor_stdout, &mut solution_stdin);
+    });
+    let solution_stderr = read_in_background(solution.stderr.take());
+    let interactor_stderr = read_in_background(interactor.stderr.take());
+
+    let start = Instant::now();
+    let mut solution_status = None;
+    let mut interactor_status = None;
+    let mut solution_timed_out = false;
+    let mut interactor_timed_out = false;
+
+    while solution_status.is_none() || interactor_status.is_none() {
+        if solution_status.is_none() {
+            solution_status = solution.try_wait()?;
+            if solution_status.is_none() && start.elapsed() >= solution_timeout {
+                solution_timed_out = true;
+                solution_status = Some(stop(&mut solution, &container_names[0])?);
+            }
+        }
+        if interactor_status.is_none() {
+            interactor_status = interactor.try_wait()?;
+            if interactor_status.is_none() && start.elapsed() >= interactor_timeout {
+                interactor_timed_out = true;
+                interactor_status = Some(stop(&mut interactor, &container_names[1])?);
+            }
+        }
+        thread::sleep(Duration::from_millis(5));
+    }
+
+    let _ = relay.join();
+
+    let output = |status: Option<ExitStatus>, stderr: thread::JoinHandle<String>, timed_out: bool| {
+        ExecutionOutput {
+            stdout: String::new(),
+            stderr: stderr.join().unwrap_or_default(),
+            exit_code: status.and_then(|s| s.code()).unwrap_or(-1),
+            timed_out,
+        }
+    };
+
+    Ok((
+        output(solution_status, solution_stderr, solution_timed_out),
+        output(interactor_status, interactor_stderr, interactor_timed_out),
+    ))
+}
+
+fn stop(child: &mut Child, container_name: &str) -> std::io::Result<ExitStatus> {
+    kill_container(container_name);
+    let _ = child.kill();
+    child.wait()
+}
+
+fn kill_container(name: &str) {
+    let _ = Command::new("docker")
+        .args(["kill", name])
+        .stdout(Stdio::null())
+        .stderr(Stdio::null())
+        .status();
+}
+
+fn read_in_background<R: Read + Send + 'static>(pipe: Option<R>) -> thread::JoinHandle<String> {
+    thread::spawn(move || {
+        let mut text = String::new();
+        if let Some(mut pipe) = pipe {
+            let _ = pipe.read_to_string(&mut text);
+        }
+        text
+    })
+}
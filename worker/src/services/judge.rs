@@ -1,10 +1,11 @@
 use anyhow::{Context, Result};
+use std::collections::{HashMap, HashSet};
 use tracing::{error, info, warn};
 use uuid::Uuid;
 
 use crate::config::{ExecutionConfig, WorkerConfig};
 use crate::database::{self, DbPool};
-use crate::models::{SubmissionResult, Verdict};
+use crate::models::{JudgeReport, SubmissionResult, TestGroup, TestResult, Verdict};
 use crate::services::{executor::Executor, queue::QueueService};
 
 pub struct JudgeWorker {
@@ -61,20 +62,22 @@ impl JudgeWorker {
 
         info!("⚖️  Judging submission {}", id);
 
-        if let Err(e) = self.judge_submission(id).await {
-            error!("Failed to judge submission {}: {:#}", id, e);
-            let _ = database::submission::update_submission_status(
-                &self.db_pool,
-                id,
-                "SYSTEM_ERROR",
-            )
-            .await;
-        }
+        let report = match self.judge_submission(id).await {
+            Ok(report) => report,
+            Err(e) => {
+                error!("Failed to judge submission {}: {:#}", id, e);
+                JudgeReport::system_error(id)
+            }
+        };
+
+        // The API records the verdict, scores the submission and refreshes
+        // the scoreboards
+        self.queue.push_result(&report).await?;
 
         Ok(true)
     }
 
-    async fn judge_submission(&self, submission_id: Uuid) -> Result<()> {
+    async fn judge_submission(&self, submission_id: Uuid) -> Result<JudgeReport> {
         database::submission::update_submission_status(
             &self.db_pool,
             submission_id,
@@ -90,38 +93,95 @@ impl JudgeWorker {
             .await
             .context("Failed to fetch test cases")?;
 
+        let checker = database::checkers::fetch_checker(&self.db_pool, submission.problem_id)
+            .await
+            .context("Failed to fetch checker")?;
+
+        // Interactive problems are judged by their interactor instead
+        let problem_type = database::problems::fetch_problem_type(&self.db_pool, submission.problem_id)
+            .await
+            .context("Failed to fetch problem type")?;
+        let interactor = if problem_type == "interactive" {
+            let interactor = database::interactors::fetch_interactor(&self.db_pool, submission.problem_id)
+                .await
+                .context("Failed to fetch interactor")?;
+            if interactor.is_none() {
+                warn!("⚠️  Interactive problem {} has no interactor", submission.problem_id);
+                return Ok(JudgeReport::system_error(submission_id));
+            }
+            interactor
+        } else {
+            None
+        };
+
+        // Partial scores need the result of every test
+        let run_all_tests = database::problems::is_partially_scored(&self.db_pool, submission_id)
+            .await
+            .context("Failed to fetch scoring mode")?;
+
         if test_cases.is_empty() {
             warn!("⚠️  No test cases found for problem {}", submission.problem_id);
-            database::submission::update_submission_verdict(
-                &self.db_pool,
-                submission_id,
-                "SYSTEM_ERROR",
-                0.0,
-                0,
-            )
-            .await?;
-            return Ok(());
+            return Ok(JudgeReport::system_error(submission_id));
         }
 
+        let groups = database::test_groups::fetch_test_groups(&self.db_pool, submission.problem_id)
+            .await
+            .context("Failed to fetch test groups")?;
+        let mut subtasks = SubtaskTracker::new(groups);
+
+        // The checker is built once and reused for every test
+        let checker = match &checker {
+            Some(checker) if interactor.is_none() => {
+                Some(self.executor.prepare_checker(checker).await?)
+            }
+            _ => None,
+        };
+
         let mut results = Vec::new();
         let mut total_time = 0.0;
         let mut max_memory = 0i64;
         let mut final_verdict = Verdict::Accepted;
 
         for test_case in test_cases {
+            // Tests of a group that can no longer earn points are not run;
+            // the API scores missing results as 0
+            if let Some(group_id) = test_case.group_id {
+                if subtasks.is_settled(group_id) {
+                    info!("⏭️  Skipping test case {} of settled group {}", test_case.id, group_id);
+                    continue;
+                }
+            }
+
             info!("🧪 Running test case {}", test_case.id);
 
-            let mut result = self
-                .executor
-                .execute_test(
-                    &submission.language,
-                    &submission.code,
-                    &test_case.input,
-                    &test_case.expected_output,
-                    test_case.time_limit.unwrap_or(5000) as u64,
-                    test_case.memory_limit.unwrap_or(256),
-                )
-                .await?;
+            let mut result = match &interactor {
+                Some(interactor) => {
+                    self.executor
+                        .execute_interactive_test(
+                            &submission.language,
+                            &submission.code,
+                            interactor,
+                            &test_case.input,
+                            &test_case.expected_output,
+                            test_case.time_limit.unwrap_or(5000) as u64,
+                            test_case.memory_limit.unwrap_or(256),
+                        )
+                        .await?
+                }
+                None => {
+                    self.executor
+                        .execute_test(
+                            &submission.language,
+                            &submission.code,
+                            &test_case.input,
+                            &test_case.expected_output,
+                            checker.as_ref(),
+                            test_case.time_limit.unwrap_or(5000) as u64,
+                            test_case.memory_limit.unwrap_or(256),
+                        )
+                        .await?
+                }
+            };
 
             result.test_case_id = test_case.id;
             total_time += result.execution_time_ms;
@@ -131,10 +191,14 @@ impl JudgeWorker {
                 final_verdict = result.verdict.clone();
             }
 
+            if let Some(group_id) = test_case.group_id {
+                subtasks.record(group_id, &result);
+            }
+
             results.push(result);
 
-            // Stop on first failure (optional optimization)
-            if final_verdict != Verdict::Accepted {
+            // All-or-nothing scoring is decided by the first failure
+            if final_verdict != Verdict::Accepted && !run_all_tests {
                 break;
             }
         }
@@ -155,15 +219,77 @@ impl JudgeWorker {
             submission_result.passed_tests()
         );
 
-        database::submission::update_submission_verdict(
-            &self.db_pool,
-            submission_id,
-            final_verdict.as_str(),
-            total_time,
-            max_memory,
-        )
-        .await?;
+        Ok(JudgeReport::from(&submission_result))
+    }
+}
+
+/// Tracks subtask outcomes while a submission is judged, mirroring how the
+/// API scores test groups, so tests that cannot change the score are skipped.
+struct SubtaskTracker {
+    groups: HashMap<Uuid, TestGroup>,
+    /// Groups that can no longer earn any points
+    zeroed: HashSet<Uuid>,
+    /// Groups with at least one test below full marks
+    not_full: HashSet<Uuid>,
+}
+
+impl SubtaskTracker {
+    fn new(groups: Vec<TestGroup>) -> Self {
+        Self {
+            groups: groups.into_iter().map(|g| (g.id, g)).collect(),
+            zeroed: HashSet::new(),
+            not_full: HashSet::new(),
+        }
+    }
 
-        Ok(())
+    fn record(&mut self, group_id: Uuid, result: &TestResult) {
+        let score = match result.score {
+            Some(score) => score.clamp(0.0, 1.0),
+            None if result.verdict == Verdict::Accepted => 1.0,
+            None => 0.0,
+        };
+        if score >= 1.0 {
+            return;
+        }
+        self.not_full.insert(group_id);
+
+        // "min" groups keep their lowest score, "all" groups need every test
+        let all_or_nothing = self
+            .groups
+            .get(&group_id)
+            .map_or(true, |g| g.scoring_rule != "min");
+        if score <= 0.0 || all_or_nothing {
+            self.zeroed.insert(group_id);
+        }
+    }
+
+    /// Whether the group's remaining tests can no longer change its score:
+    /// it already earns nothing, or a group it depends on cannot be solved
+    /// in full.
+    fn is_settled(&self, group_id: Uuid) -> bool {
+        if self.zeroed.contains(&group_id) {
+            return true;
+        }
+        let mut visiting = HashSet::new();
+        visiting.insert(group_id);
+        self.groups.get(&group_id).is_some_and(|g| {
+            g.depends_on
+                .iter()
+                .any(|dep| !self.can_pass(*dep, &mut visiting))
+        })
+    }
+
+    /// Whether the group and everything it depends on can still be solved
+    /// in full; visiting guards against dependency cycles.
+    fn can_pass(&self, group_id: Uuid, visiting: &mut HashSet<Uuid>) -> bool {
+        if self.not_full.contains(&group_id) || !visiting.insert(group_id) {
+            return false;
+        }
+        let ok = match self.groups.get(&group_id) {
+            Some(g) => g.depends_on.iter().all(|dep| self.can_pass(*dep, visiting)),
+            None => false,
+        };
+        visiting.remove(&group_id);
+        ok
     }
 }
@@ -3,9 +3,12 @@ use redis::aio::ConnectionManager;
 use redis::AsyncCommands;
 use tracing::{info, warn};
 
+use crate::models::JudgeReport;
+
 pub struct QueueService {
     conn: ConnectionManager,
     queue_name: String,
+    results_queue: String,
 }
 
 impl QueueService {
@@ -20,6 +23,7 @@ impl QueueService {
         Ok(Self {
             conn,
             queue_name: config.queue_name.clone(),
+            results_queue: config.results_queue.clone(),
         })
     }
 
@@ -43,6 +47,17 @@ impl QueueService {
         }
     }
 
+    /// Hands a judge report to the API, which records the verdict.
+    pub async fn push_result(&mut self, report: &JudgeReport) -> Result<()> {
+        let payload = serde_json::to_string(report).context("Failed to encode judge report")?;
+        self.conn
+            .lpush::<_, _, ()>(&self.results_queue, payload)
+            .await
+            .context("Failed to push judge report")?;
+        info!("📤 Reported submission: {}", report.submission_id);
+        Ok(())
+    }
+
     pub async fn get_queue_length(&mut self) -> Result<i64> {
         self.conn
             .llen(&self.queue_name)